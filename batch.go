@@ -0,0 +1,43 @@
+package clog
+
+import "bytes"
+
+// Batch runs fn with a logger whose output is buffered, then writes
+// everything fn logged to the output in a single write. Lines logged
+// inside a batch are therefore contiguous, never interleaved with lines
+// from other goroutines:
+//
+//	clog.Batch(func(l *clog.Logger) {
+//	    l.Info().Str("step", "fetch").Msg("Deploying")
+//	    l.Info().Str("step", "apply").Msg("Deploying")
+//	})
+//
+// The batch logger shares the logger's configuration as of this call, and
+// sub-loggers created from it inherit the buffer; none of them should be
+// used after fn returns. Batches nest: an inner
+// batch is flushed into the outer one. The buffer is also flushed if fn
+// panics. Entries sent to a custom [Handler] bypass the buffer, as do
+// animations, which should not be started inside a batch.
+func (l *Logger) Batch(fn func(*Logger)) {
+	l.mu.Lock()
+	child := l.clone()
+	child.mu = l.mu
+	child.batch = &bytes.Buffer{}
+	child.atomicLevel.Store(int32(child.gateLevel())) //nolint:gosec // Level values are small constants (0-6)
+	l.mu.Unlock()
+
+	defer func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if child.batch.Len() > 0 {
+			_, _ = l.teeWriter().Write(child.batch.Bytes())
+		}
+	}()
+
+	fn(child)
+}
+
+// Batch runs fn with a buffered logger derived from the [Default] logger.
+// See [Logger.Batch].
+func Batch(fn func(*Logger)) { Default.Batch(fn) }
@@ -0,0 +1,126 @@
+package clog
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatch(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage, PartFields)
+
+	l.Batch(func(b *Logger) {
+		b.Info().Msg("one")
+		assert.Empty(t, buf.String(), "output should be buffered until the batch ends")
+		b.Info().Msg("two")
+	})
+
+	assert.Equal(t, "one\ntwo\n", buf.String())
+}
+
+func TestBatchLevelForField(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage)
+	l.SetLevelForField("component", "db", DebugLevel)
+
+	l.Batch(func(b *Logger) {
+		b.Debug().Str("component", "db").Msg("query")
+		b.Debug().Msg("plain")
+	})
+
+	assert.Equal(t, "query\n", buf.String())
+}
+
+func TestBatchConcurrent(t *testing.T) {
+	const (
+		batches = 8
+		lines   = 50
+	)
+
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage, PartFields)
+
+	var wg sync.WaitGroup
+	for g := range batches {
+		wg.Go(func() {
+			l.Batch(func(b *Logger) {
+				for i := range lines {
+					b.Info().Int("i", i).Msg(fmt.Sprintf("g%d", g))
+				}
+			})
+		})
+	}
+	wg.Wait()
+
+	out := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, out, batches*lines)
+
+	// Each batch's lines must be contiguous and in order.
+	for start := 0; start < len(out); start += lines {
+		group := strings.Fields(out[start])[0]
+		for i := range lines {
+			assert.Equal(t, fmt.Sprintf("%s i=%d", group, i), out[start+i])
+		}
+	}
+}
+
+func TestBatchNested(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage)
+
+	l.Batch(func(outer *Logger) {
+		outer.Info().Msg("one")
+		outer.Batch(func(inner *Logger) {
+			inner.Info().Msg("two")
+		})
+		assert.Empty(t, buf.String(), "inner batch should flush into the outer one")
+		outer.With().Str("k", "v").Logger().Info().Msg("three")
+	})
+
+	assert.Equal(t, "one\ntwo\nthree\n", buf.String())
+}
+
+func TestBatchFlushesOnPanic(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage)
+
+	assert.Panics(t, func() {
+		l.Batch(func(b *Logger) {
+			b.Info().Msg("before")
+			panic("boom")
+		})
+	})
+
+	assert.Equal(t, "before\n", buf.String())
+}
+
+func TestBatchTap(t *testing.T) {
+	var buf, tap bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage)
+	l.Tap(&tap)
+
+	l.Batch(func(b *Logger) {
+		b.Info().Msg("one")
+	})
+
+	assert.Equal(t, "one\n", buf.String())
+	assert.Equal(t, "one\n", tap.String())
+}
@@ -10,21 +10,38 @@
 package clog
 
 import (
+	"bytes"
+	"cmp"
 	"context"
 	"fmt"
 	"io"
 	"maps"
 	"os"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"github.com/charmbracelet/lipgloss"
 )
 
 // ErrorKey is the default field key used by [Event.Err] and [Context.Err].
 const ErrorKey = "error"
 
+// CallerKey is the field key used by [Event.Caller] and friends.
+const CallerKey = "caller"
+
+// DryRunKey is the field key added to every entry by [Logger.SetDryRun].
+const DryRunKey = "dry_run"
+
+// Field keys added by [Event.Retry].
+const (
+	retryAttemptKey = "attempt"
+	retryBackoffKey = "backoff"
+)
+
 const (
 	// LevelTrace is the "trace" level string.
 	LevelTrace = "trace"
@@ -59,6 +76,28 @@ var defaultPrefixes = LevelMap{
 	FatalLevel: "💥",
 }
 
+// asciiPrefixes are plain-text prefixes for terminals without emoji support.
+var asciiPrefixes = LevelMap{
+	TraceLevel: "[.]",
+	DebugLevel: "[?]",
+	InfoLevel:  "[*]",
+	DryLevel:   "[~]",
+	WarnLevel:  "[!]",
+	ErrorLevel: "[x]",
+	FatalLevel: "[X]",
+}
+
+// nerdFontPrefixes are Nerd Font (Font Awesome) glyph prefixes.
+var nerdFontPrefixes = LevelMap{
+	TraceLevel: "\uf002", // nf-fa-search
+	DebugLevel: "\uf188", // nf-fa-bug
+	InfoLevel:  "\uf05a", // nf-fa-info_circle
+	DryLevel:   "\uf0c3", // nf-fa-flask
+	WarnLevel:  "\uf071", // nf-fa-warning
+	ErrorLevel: "\uf057", // nf-fa-times_circle
+	FatalLevel: "\uf1e2", // nf-fa-bomb
+}
+
 // levelLabels are the short text labels for each level.
 var levelLabels = LevelMap{
 	TraceLevel: "TRC",
@@ -165,6 +204,45 @@ const (
 	AlignCenter
 )
 
+// TimestampPreset selects a common timestamp format for
+// [Logger.SetTimestampPreset].
+type TimestampPreset int
+
+const (
+	// TimestampTimeOnly renders the time of day with milliseconds
+	// (15:04:05.000). This is the default.
+	TimestampTimeOnly TimestampPreset = iota
+	// TimestampDateTime renders the date and time with milliseconds
+	// (2006-01-02 15:04:05.000).
+	TimestampDateTime
+	// TimestampRFC3339 renders [time.RFC3339] with the zone offset.
+	TimestampRFC3339
+	// TimestampRFC3339Nano renders [time.RFC3339Nano] with the zone offset.
+	TimestampRFC3339Nano
+	// TimestampUnix renders whole seconds since the Unix epoch.
+	TimestampUnix
+	// TimestampUnixMilli renders milliseconds since the Unix epoch.
+	TimestampUnixMilli
+)
+
+// Timestamp layouts. The Unix presets are not Go layouts; they are
+// recognised by [formatTimestamp].
+const (
+	defaultTimeFormat   = "15:04:05.000"
+	timeFormatUnix      = "unix"
+	timeFormatUnixMilli = "unixmilli"
+)
+
+// timestampPresetFormats maps each [TimestampPreset] to its layout.
+var timestampPresetFormats = map[TimestampPreset]string{
+	TimestampTimeOnly:    defaultTimeFormat,
+	TimestampDateTime:    "2006-01-02 15:04:05.000",
+	TimestampRFC3339:     time.RFC3339,
+	TimestampRFC3339Nano: time.RFC3339Nano,
+	TimestampUnix:        timeFormatUnix,
+	TimestampUnixMilli:   timeFormatUnixMilli,
+}
+
 // ColorMode controls how a [Logger] determines colour and hyperlink output.
 //
 // ColorMode implements [encoding.TextMarshaler] and [encoding.TextUnmarshaler],
@@ -195,6 +273,59 @@ const (
 	QuoteNever
 )
 
+// EmptyMessageMode controls how the message part renders when an entry has
+// no message (e.g. from [Event.Send]).
+type EmptyMessageMode int
+
+const (
+	// EmptyMessageHide skips the message part entirely. This is the default.
+	EmptyMessageHide EmptyMessageMode = iota
+	// EmptyMessageKeep renders the placeholder set with
+	// [Logger.SetEmptyMessagePlaceholder] in place of the message, so
+	// fields stay in the same column as on lines with a message.
+	EmptyMessageKeep
+)
+
+// PrefixStyle selects a built-in set of level prefixes for
+// [Logger.SetPrefixStyle].
+type PrefixStyle int
+
+const (
+	// PrefixEmoji uses the default emoji prefixes (see [DefaultPrefixes]).
+	PrefixEmoji PrefixStyle = iota
+	// PrefixASCII uses plain-text prefixes such as "[*]" and "[!]"
+	// (see [ASCIIPrefixes]).
+	PrefixASCII
+	// PrefixNerdFont uses Nerd Font glyphs (see [NerdFontPrefixes]).
+	PrefixNerdFont
+)
+
+// RulePosition selects where [Logger.SetLevelRule] draws a horizontal rule
+// around an entry.
+type RulePosition int
+
+const (
+	// RuleNone draws no rule. This is the default.
+	RuleNone RulePosition = iota
+	// RuleBefore draws a rule above the entry.
+	RuleBefore
+	// RuleAfter draws a rule below the entry.
+	RuleAfter
+	// RuleBoth draws a rule above and below the entry.
+	RuleBoth = RuleBefore | RuleAfter
+)
+
+// TruncateMode selects which part of a message [Logger.SetMessageTruncate]
+// removes.
+type TruncateMode int
+
+const (
+	// TruncateEnd keeps the start of the message (e.g. "long mess…").
+	TruncateEnd TruncateMode = iota
+	// TruncateMiddle keeps both ends of the message (e.g. "long…age").
+	TruncateMiddle
+)
+
 // Part identifies a component of a formatted log line.
 type Part int
 
@@ -209,48 +340,111 @@ const (
 	PartMessage
 	// PartFields is the structured fields component.
 	PartFields
+	// PartGoroutine is the goroutine or worker id field (see
+	// [Logger.SetReportGoroutine] and [Logger.WithWorker]). When absent
+	// from the parts, the id is rendered with the other fields.
+	PartGoroutine
 )
 
 // ctxKey is the private context key used by [Logger.WithContext] and [Ctx].
 type ctxKey struct{}
 
+// fieldMatch identifies a field key and formatted value for
+// [Logger.SetLevelForField].
+type fieldMatch struct {
+	key, value string
+}
+
 // Logger is the main structured logger.
 type Logger struct {
 	mu *sync.Mutex
 
-	atomicLevel             atomic.Int32 // lock-free level check for newEvent() hot path
-	elapsedFormatFunc       func(time.Duration) string
-	elapsedMinimum          time.Duration
-	elapsedPrecision        int
-	elapsedRound            time.Duration
-	exitFunc                func(int) // called by Fatal-level events; defaults to os.Exit
-	fieldSort               Sort
-	fieldStyleLevel         Level
-	fieldTimeFormat         string
-	fields                  []Field
-	handler                 Handler
-	labelWidth              int
-	labels                  LevelMap
-	labelsPadded            LevelMap
-	level                   Level
-	levelAlign              Align
-	omitEmpty               bool
-	omitZero                bool
-	output                  *Output
-	parts                   []Part
-	percentFormatFunc       func(float64) string
-	percentPrecision        int
-	prefix                  *string // nil = use default emoji for level
-	prefixes                LevelMap
-	quantityUnitsIgnoreCase bool
-	quoteOpen               rune // 0 means default ('"' via strconv.Quote)
-	quoteClose              rune // 0 means same as quoteOpen (or default)
-	quoteMode               QuoteMode
-	reportTimestamp         bool
-	separatorText           string
-	styles                  *Styles
-	timeFormat              string
-	timeLocation            *time.Location
+	alwaysStyleFieldsLevel     Level
+	atomicLevel                atomic.Int32 // lock-free level check for newEvent() hot path
+	autoColorKeys              map[string]bool
+	batch                      *bytes.Buffer // set by Batch; collects output until the batch ends
+	boolWords                  boolWords     // set by SetBoolWords
+	collapseRepeatTimestamp    bool
+	confirmDefault             *bool // set by SetConfirmDefault; nil = no default
+	defaultFields              []Field
+	dictSeparator              string
+	disabled                   atomic.Bool // kill switch checked before the level in newEvent()
+	dryRun                     bool        // set by SetDryRun
+	durationColumnWidth        int
+	durationUsesQuantityStyles bool
+	elapsedFormatFunc          func(time.Duration) string
+	elapsedMinimum             time.Duration
+	elapsedPrecision           int
+	elapsedRound               time.Duration
+	emptyMessageMode           EmptyMessageMode
+	emptyMessagePlaceholder    string
+	emptyRepr                  string
+	errorFields                *atomic.Uint64 // entries logged with Event.Err; shared with sub-loggers
+	exitFunc                   func(int)      // called by Fatal-level events; defaults to os.Exit
+	fatalExits                 bool
+	fieldKeyAlign              bool
+	fieldLevels                map[fieldMatch]Level
+	fieldOrder                 []string
+	fieldPriorities            map[string]int
+	fieldSort                  Sort
+	fieldStyleLevel            Level
+	fieldTimeFormat            string
+	fields                     []Field
+	handler                    Handler
+	highlightMessageJSON       bool
+	indent                     string    // accumulated Tree indentation
+	input                      io.Reader // set by SetInput; nil = os.Stdin
+	keyTruncate                map[string]truncateSpec
+	labelWidth                 int
+	labels                     LevelMap
+	labelsPadded               LevelMap
+	lastTimestamp              string // last rendered timestamp, for collapseRepeatTimestamp
+	leadingTagField            string // set by SetLeadingTagField
+	level                      Level
+	levelAlign                 Align
+	levelBadge                 bool
+	levelRules                 map[Level]RulePosition // set by SetLevelRule
+	maxLineLen                 int
+	measurePrecision           int
+	messageTruncateLen         int // set by SetMessageTruncate; 0 = unlimited
+	messageTruncateMode        TruncateMode
+	nilRepr                    string
+	nowFunc                    func() time.Time // nil = time.Now
+	numberGrouping             rune             // 0 = no digit grouping
+	omitEmpty                  bool
+	omitPredicate              func(Field) bool // overrides omitEmpty/omitZero when set
+	omitZero                   bool
+	onceKeys                   *sync.Map // keys seen by Event.Once; shared with sub-loggers
+	output                     *Output
+	parts                      []Part
+	percentFormatFunc          func(float64) string
+	percentPrecision           int
+	prefix                     *string // nil = use default emoji for level
+	prefixPlaceholderWhenEmpty bool
+	prefixes                   LevelMap
+	quantityColumnWidth        int
+	quantityThousandsSep       rune // 0 = no digit grouping
+	quantityUnitsIgnoreCase    bool
+	quoteOpen                  rune // 0 means default ('"' via strconv.Quote)
+	quoteClose                 rune // 0 means same as quoteOpen (or default)
+	quoteMode                  QuoteMode
+	reportGoroutine            bool
+	reportTimestamp            bool
+	sanitizeControl            bool
+	scrapeToken                func(Entry) string // set by SetScrapeToken; nil = no token
+	sectionRule                bool
+	separatorText              string
+	statsPrecision             int
+	styles                     *Styles
+	tap                        io.Writer // set by Tap(); receives a copy of everything written to output
+	timeFormat                 string
+	timeLocation               *time.Location
+	timestampGradient          []ColorStop
+	timestampGradientStart     time.Time
+	timestampGradientWindow    time.Duration
+	treeIndent                 string
+	worker                     *int // set by WithWorker; nil = none
+	wrapSlices                 bool
 }
 
 // New creates a new [Logger] that writes to the given [Output].
@@ -258,12 +452,19 @@ func New(output *Output) *Logger {
 	l := &Logger{
 		mu: &sync.Mutex{},
 
+		alwaysStyleFieldsLevel:  FatalLevel + 1,
+		dictSeparator:           ".",
 		elapsedMinimum:          time.Second,
 		elapsedRound:            time.Second,
+		emptyMessagePlaceholder: "-",
+		errorFields:             new(atomic.Uint64),
 		exitFunc:                os.Exit,
+		fatalExits:              true,
 		fieldStyleLevel:         InfoLevel,
 		fieldTimeFormat:         time.RFC3339,
 		labels:                  DefaultLabels(),
+		measurePrecision:        defaultMeasurePrecision,
+		onceKeys:                new(sync.Map),
 		level:                   InfoLevel,
 		levelAlign:              AlignRight,
 		output:                  output,
@@ -271,9 +472,11 @@ func New(output *Output) *Logger {
 		prefixes:                DefaultPrefixes(),
 		quantityUnitsIgnoreCase: true,
 		separatorText:           "=",
+		statsPrecision:          2,
 		styles:                  DefaultStyles(),
-		timeFormat:              "15:04:05.000",
+		timeFormat:              defaultTimeFormat,
 		timeLocation:            time.Local,
+		treeIndent:              "  ",
 	}
 	l.atomicLevel.Store(int32(InfoLevel))
 	l.labelWidth = computeLabelWidth(l.labels)
@@ -286,6 +489,59 @@ func NewWriter(w io.Writer) *Logger {
 	return New(NewOutput(w, ColorAuto))
 }
 
+// SetAlwaysStyleFieldsAtOrAbove sets a level at or above which field values
+// are always styled, bypassing [Logger.SetFieldStyleLevel]. For example,
+// SetAlwaysStyleFieldsAtOrAbove(ErrorLevel) keeps errors colourful while
+// SetFieldStyleLevel silences styling for everything else. Defaults to
+// above [FatalLevel], which never bypasses the field style level.
+func (l *Logger) SetAlwaysStyleFieldsAtOrAbove(level Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.alwaysStyleFieldsLevel = level
+}
+
+// SetAutoColorKeys colours the values of fields named by keys with a hue
+// derived from a hash of the value, so each distinct value (e.g. a hostname
+// or request id) gets a stable colour without a [Styles.Values] entry.
+// [Styles.Keys] styles take precedence. Calling it with no keys disables
+// auto-colouring.
+func (l *Logger) SetAutoColorKeys(keys ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(keys) == 0 {
+		l.autoColorKeys = nil
+		return
+	}
+
+	m := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		m[k] = true
+	}
+	l.autoColorKeys = m
+}
+
+// SetBoolWords sets the words bool field values and their slices are
+// rendered with, e.g. "yes"/"no" or "on"/"off". [Styles.Values] styles keyed
+// by true and false still apply. An empty word keeps the default ("true" or
+// "false"), so SetBoolWords("", "") restores the defaults. Custom
+// [Handler]s receive the bool values unchanged.
+func (l *Logger) SetBoolWords(trueWord, falseWord string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.boolWords = boolWords{yes: trueWord, no: falseWord}
+}
+
+// SetCollapseRepeatTimestamp sets whether a timestamp equal to the previous
+// line's is replaced by spaces of the same width, keeping columns aligned
+// while reducing noise when many entries share a timestamp. Defaults to
+// false.
+func (l *Logger) SetCollapseRepeatTimestamp(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.collapseRepeatTimestamp = enabled
+}
+
 // SetColorMode sets the colour mode by recreating the logger's [Output]
 // with the given mode.
 func (l *Logger) SetColorMode(mode ColorMode) {
@@ -295,6 +551,77 @@ func (l *Logger) SetColorMode(mode ColorMode) {
 	l.output = NewOutput(w, mode)
 }
 
+// SetColorProfile overrides the colour profile of the logger's [Output]
+// (see [Output.SetColorProfile]).
+func (l *Logger) SetColorProfile(p ColorProfile) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.output.SetColorProfile(p)
+}
+
+// SetConfirmDefault sets the answer [Logger.Confirm] returns when the user
+// presses enter without typing anything, or when the input is not a
+// terminal (instead of [ErrNotInteractive]).
+func (l *Logger) SetConfirmDefault(answer bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.confirmDefault = &answer
+}
+
+// SetDefaultFields sets fields that are added to every entry logged by l.
+// Unlike [Logger.With], default fields replace any previously set and can be
+// skipped for a single entry with [Event.WithoutDefaults]. They are merged
+// before context and event fields and are subject to the same sort and omit
+// rules. Call with no arguments to clear them.
+func (l *Logger) SetDefaultFields(fields ...Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.defaultFields = slices.Clone(fields)
+}
+
+// SetDictSeparator sets the separator joining parent and child keys when
+// [Event.Dict] and [Context.Dict] flatten nested fields. Defaults to ".".
+// An empty separator restores the default.
+func (l *Logger) SetDictSeparator(sep string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if sep == "" {
+		sep = "."
+	}
+	l.dictSeparator = sep
+}
+
+// SetDryRun marks every entry, whatever its level, as part of a dry run:
+// the [DryLevel] prefix and message style are used, a dry_run=true field
+// (see [DryRunKey]) is added, and custom [Handler]s see [Entry.DryRun] set,
+// so they can skip side effects. Unlike [Logger.Dry], which marks a single
+// entry, this suits a --dry-run flag. A prefix set with [Logger.SetPrefix]
+// or [Event.Prefix] is kept. Defaults to false.
+func (l *Logger) SetDryRun(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.dryRun = enabled
+}
+
+// SetDurationColumnWidth right-aligns duration and elapsed field values
+// within n visible columns, so repeated lines keep their values lined up.
+// Defaults to 0 (no padding).
+func (l *Logger) SetDurationColumnWidth(n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.durationColumnWidth = n
+}
+
+// SetDurationUsesQuantityStyles routes [time.Duration] field values through
+// quantity styling ([Styles.FieldQuantityNumber], [Styles.FieldQuantityUnit],
+// [Styles.QuantityUnits] and [Styles.QuantityThresholds]) instead of the
+// duration styles. Defaults to false.
+func (l *Logger) SetDurationUsesQuantityStyles(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.durationUsesQuantityStyles = enabled
+}
+
 // SetElapsedFormatFunc sets a custom format function for Elapsed fields.
 // When set to nil (the default), the built-in [formatElapsed] is used.
 func (l *Logger) SetElapsedFormatFunc(fn func(time.Duration) string) {
@@ -328,6 +655,49 @@ func (l *Logger) SetElapsedRound(d time.Duration) {
 	l.elapsedRound = d
 }
 
+// SetEmptyMessageMode sets how the message part renders for entries without
+// a message. Defaults to [EmptyMessageHide].
+func (l *Logger) SetEmptyMessageMode(mode EmptyMessageMode) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.emptyMessageMode = mode
+}
+
+// SetEmptyMessagePlaceholder sets the text shown in place of an empty
+// message when the mode is [EmptyMessageKeep]. Defaults to "-". An empty
+// placeholder still reserves the message column.
+func (l *Logger) SetEmptyMessagePlaceholder(placeholder string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.emptyMessagePlaceholder = placeholder
+}
+
+// SetEmptyRepr sets the text rendered for nil field values (e.g. "∅") and
+// empty string values (e.g. "(empty)"). The text is rendered verbatim,
+// without quoting, and styled via [Styles.Values] (nil and "" keys). An
+// empty argument keeps the default rendering for that case.
+func (l *Logger) SetEmptyRepr(nilRepr, emptyStrRepr string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.nilRepr = nilRepr
+	l.emptyRepr = emptyStrRepr
+}
+
+// SetEnabled enables or disables all logging on the logger. When disabled,
+// every level returns a nil [Event], so nothing is written and no handler
+// is called. The check is a single atomic load.
+//
+// Like other settings, the flag is copied into sub-loggers when they are
+// created (see [Logger.With]): sub-loggers created earlier keep logging
+// until they are disabled themselves, while those created from a disabled
+// logger start disabled.
+//
+// WARNING: this includes [FatalLevel]. A disabled logger's Fatal().Msg()
+// does not log and does NOT exit the process; code after it keeps running.
+func (l *Logger) SetEnabled(enabled bool) {
+	l.disabled.Store(!enabled)
+}
+
 // SetExitFunc sets the function called by Fatal-level events.
 // Defaults to [os.Exit]. This can be used in tests to intercept fatal exits.
 // If fn is nil, the default [os.Exit] is used.
@@ -340,6 +710,55 @@ func (l *Logger) SetExitFunc(fn func(int)) {
 	l.exitFunc = fn
 }
 
+// SetFatalExits sets whether Fatal-level events exit the program after
+// logging. Defaults to true. When false, the entry is still logged at
+// [FatalLevel] but the exit function is not called and control returns to
+// the caller, which is useful in tests and library code.
+func (l *Logger) SetFatalExits(exits bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.fatalExits = exits
+}
+
+// SetFieldKeyAlign sets whether the keys of each entry's fields are padded
+// to the width of the longest key in that entry, aligning the separators
+// (e.g. "id   =1 name =a status=ok"). Widths ignore ANSI escapes. Alignment
+// is per entry, not across lines. Defaults to false.
+func (l *Logger) SetFieldKeyAlign(align bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.fieldKeyAlign = align
+}
+
+// SetFieldOrder sets keys that are always rendered first, in the given
+// order, ahead of the remaining fields. The remaining fields keep their
+// order, or are sorted by [Logger.SetFieldSort]. Call with no keys to
+// clear the order.
+//
+//	logger.SetFieldOrder("op", "id")
+//	logger.Info().Str("id", "42").Str("x", "y").Str("op", "get").Msg("done")
+//	// INF ℹ️ done op=get id=42 x=y
+func (l *Logger) SetFieldOrder(keys ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.fieldOrder = slices.Clone(keys)
+}
+
+// SetFieldPriority sets the priority of fields named key for
+// [Logger.SetMaxLineLen]. When a line is too long, fields are dropped
+// lowest priority first; fields without a priority have priority 0.
+func (l *Logger) SetFieldPriority(key string, priority int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	m := maps.Clone(l.fieldPriorities)
+	if m == nil {
+		m = make(map[string]int)
+	}
+	m[key] = priority
+	l.fieldPriorities = m
+}
+
 // SetFieldSort sets the sort order for fields in log output.
 // Default [SortNone] preserves insertion order.
 func (l *Logger) SetFieldSort(sort Sort) {
@@ -373,12 +792,63 @@ func (l *Logger) SetHandler(h Handler) {
 	l.handler = h
 }
 
+// SetHighlightMessageJSON enables or disables syntax highlighting of a JSON
+// object or array embedded in the message (e.g. "response: {"ok":true}").
+// The first span of balanced braces or brackets that is valid JSON is
+// rendered with [Styles.FieldJSON]; the rest of the message keeps its usual
+// style. Disabled by default.
+func (l *Logger) SetHighlightMessageJSON(enable bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.highlightMessageJSON = enable
+}
+
+// SetInput sets the reader [Logger.Confirm] reads answers from. Defaults to
+// [os.Stdin]. Readers other than an [*os.File] are always treated as
+// interactive.
+func (l *Logger) SetInput(r io.Reader) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.input = r
+}
+
+// SetKeyTruncate shortens string values of fields named key to the first
+// head and last tail runes joined by "…" (e.g. "abcd…wxyz"). Values that
+// already fit are left untouched, as are numbers and bools. Passing zero
+// for both head and tail removes the rule.
+func (l *Logger) SetKeyTruncate(key string, head, tail int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	m := maps.Clone(l.keyTruncate)
+	if head <= 0 && tail <= 0 {
+		delete(m, key)
+	} else {
+		if m == nil {
+			m = make(map[string]truncateSpec)
+		}
+		m[key] = truncateSpec{head: max(head, 0), tail: max(tail, 0)}
+	}
+	l.keyTruncate = m
+}
+
+// SetLeadingTagField renders the field named key as a tag at the start of
+// each line, ahead of the timestamp and level (e.g. "[tenant-a] INF ℹ️ msg"),
+// styled with [Styles.LeadingTag]. The field is removed from the fields
+// part so it is not shown twice. Entries without the field have no tag.
+// An empty key disables the tag. Custom [Handler]s still receive the field.
+func (l *Logger) SetLeadingTagField(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.leadingTagField = key
+}
+
 // SetLevel sets the minimum log level.
 func (l *Logger) SetLevel(level Level) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	l.level = level
-	l.atomicLevel.Store(int32(level)) //nolint:gosec // Level values are small constants (0-6)
+	l.atomicLevel.Store(int32(l.gateLevel())) //nolint:gosec // Level values are small constants (0-6)
 }
 
 // SetLevelAlign sets the alignment mode for level labels.
@@ -389,6 +859,44 @@ func (l *Logger) SetLevelAlign(align Align) {
 	l.recomputePaddedLabels()
 }
 
+// SetLevelBadge sets whether level labels are rendered as badges: padded
+// with a space on either side, with the colour of the level's
+// [Styles.Levels] style as the background (e.g. " ERR " on red), or the
+// style set in [Styles.LevelBadges]. Badges keep labels aligned, as every
+// label gains the same padding. When colours are disabled the plain label
+// is rendered. Defaults to false.
+func (l *Logger) SetLevelBadge(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.levelBadge = enabled
+}
+
+// SetLevelForField sets the minimum level for events carrying a field named
+// key whose value (formatted with [fmt.Sprint]) equals value. Fields from the
+// event, the logger's context and its default fields are all considered.
+// This raises or lowers verbosity for one subsystem without changing the
+// global level:
+//
+//	logger.SetLevel(clog.InfoLevel)
+//	logger.SetLevelForField("component", "db", clog.DebugLevel)
+//
+// When several overrides match, the most verbose applies. Events below the
+// global level are no longer skipped cheaply while an override is more
+// verbose than the global level, as fields are only known once the event
+// is sent.
+func (l *Logger) SetLevelForField(key, value string, level Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	m := maps.Clone(l.fieldLevels)
+	if m == nil {
+		m = make(map[fieldMatch]Level)
+	}
+	m[fieldMatch{key: key, value: value}] = level
+	l.fieldLevels = m
+	l.atomicLevel.Store(int32(l.gateLevel())) //nolint:gosec // Level values are small constants (0-6)
+}
+
 // SetLabelWidth sets an explicit minimum width for level labels.
 // If width is 0, the width is computed automatically from the current labels.
 func (l *Logger) SetLabelWidth(width int) {
@@ -414,21 +922,129 @@ func (l *Logger) SetLevelLabels(labels LevelMap) {
 	l.recomputePaddedLabels()
 }
 
+// SetLevelRule draws a horizontal rule, styled with [Styles.Rule], before
+// and/or after entries at level, so that e.g. errors stand out among info
+// lines. Rules span the terminal width, or the entry width when the output
+// is not a terminal, in which case they are drawn with dashes. [RuleNone]
+// removes the rule. Entries sent to a custom [Handler] are unaffected,
+// unless it renders them with the pretty formatter, as [PrettyHandler] and
+// [Logger.UseHandlerMiddleware] do.
+func (l *Logger) SetLevelRule(level Level, position RulePosition) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	m := maps.Clone(l.levelRules)
+	if position == RuleNone {
+		delete(m, level)
+	} else {
+		if m == nil {
+			m = make(map[Level]RulePosition)
+		}
+		m[level] = position
+	}
+	l.levelRules = m
+}
+
+// SetMaxLineLen sets the maximum visible width of a log line, measured
+// ignoring ANSI escapes. When a line is wider, fields are dropped in
+// [Logger.SetFieldPriority] order until it fits, and a "…+N" marker shows
+// how many were dropped. The timestamp, level, prefix and message are never
+// dropped. 0 (the default) disables the limit.
+func (l *Logger) SetMaxLineLen(n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.maxLineLen = max(n, 0)
+}
+
+// SetMeasurePrecision sets the maximum number of decimal places for
+// [Event.Measure] fields. Trailing zeros are dropped, so 5.1 renders "5.1"
+// and 0.1+0.2 renders "0.3". A negative precision uses the fewest digits
+// that represent each value exactly. Defaults to 6.
+func (l *Logger) SetMeasurePrecision(precision int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.measurePrecision = precision
+}
+
+// SetMessageTruncate shortens messages wider than maxLen columns to maxLen,
+// including the "…" marking the cut. [TruncateEnd] keeps the start of the
+// message and [TruncateMiddle] keeps both ends, so that a trailing id
+// survives. Widths ignore ANSI escapes and count wide runes as two
+// columns. Only the message part is affected. 0 (the default) disables
+// truncation.
+func (l *Logger) SetMessageTruncate(maxLen int, mode TruncateMode) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.messageTruncateLen = max(maxLen, 0)
+	l.messageTruncateMode = mode
+}
+
+// SetNumberGrouping sets the digit grouping separator for integer and float
+// field values and their slices (e.g. ',' renders 9876543210 as
+// "9,876,543,210"). Floats group the integer part only. Defaults to 0 (no
+// grouping). To keep a number such as an ID ungrouped, log it with
+// [Event.Str] or [Event.Stringer].
+func (l *Logger) SetNumberGrouping(sep rune) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.numberGrouping = sep
+}
+
+// SetNumberThresholds sets style thresholds for number fields with the
+// given key (e.g. "errors" red at 100 and above). Thresholds are evaluated
+// in order and the first whose Value the number meets or exceeds wins; its
+// [ThresholdStyle.Number] replaces [Styles.FieldNumber]. [Styles.Keys] and
+// [Styles.Values] still take priority. Empty thresholds remove the key.
+// See [Styles.NumberThresholds]; the logger's [Styles] are copied, so other
+// loggers sharing them are unaffected.
+func (l *Logger) SetNumberThresholds(key string, thresholds []Threshold) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	styles := *l.styles
+	styles.NumberThresholds = maps.Clone(styles.NumberThresholds)
+	if len(thresholds) == 0 {
+		delete(styles.NumberThresholds, key)
+	} else {
+		if styles.NumberThresholds == nil {
+			styles.NumberThresholds = make(ThresholdMap)
+		}
+		styles.NumberThresholds[key] = slices.Clone(thresholds)
+	}
+	l.styles = &styles
+}
+
 // SetOmitEmpty enables or disables omitting fields with empty values.
-// Empty means nil, empty strings, and nil or empty slices/maps.
+// Empty means nil, empty strings, and nil or empty slices/maps. It replaces
+// any predicate set by [Logger.SetOmitPredicate].
 func (l *Logger) SetOmitEmpty(omit bool) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	l.omitEmpty = omit
+	l.omitPredicate = nil
+}
+
+// SetOmitPredicate sets a function that decides which fields are dropped
+// from each entry: fields for which fn returns true are omitted. It takes
+// precedence over [Logger.SetOmitEmpty] and [Logger.SetOmitZero], which
+// act as presets and clear it. Pass nil to restore them.
+//
+//	l.SetOmitPredicate(func(f clog.Field) bool { return f.Value == "N/A" })
+func (l *Logger) SetOmitPredicate(fn func(Field) bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.omitPredicate = fn
 }
 
 // SetOmitZero enables or disables omitting fields with zero values.
 // Zero means the zero value for any type (0, false, "", nil, etc.).
-// This is a superset of [Logger.SetOmitEmpty].
+// This is a superset of [Logger.SetOmitEmpty]. It replaces any predicate
+// set by [Logger.SetOmitPredicate].
 func (l *Logger) SetOmitZero(omit bool) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	l.omitZero = omit
+	l.omitPredicate = nil
 }
 
 // SetOutput sets the output.
@@ -450,6 +1066,59 @@ func (l *Logger) Output() *Output {
 	return l.output
 }
 
+// RawWriter returns an [io.Writer] that writes bytes unchanged to the
+// logger's current output while holding the logger's lock, so raw output
+// such as a banner never interleaves mid-line with log entries. Unlike
+// Output().Writer(), writes are serialised with the logger and follow
+// [Logger.SetOutput]. No formatting or colour handling is applied.
+func (l *Logger) RawWriter() io.Writer { return rawWriter{l} }
+
+// rawWriter is the [io.Writer] returned by [Logger.RawWriter].
+type rawWriter struct{ l *Logger }
+
+func (w rawWriter) Write(p []byte) (int, error) {
+	w.l.mu.Lock()
+	defer w.l.mu.Unlock()
+	return w.l.teeWriter().Write(p)
+}
+
+// Tap copies everything the logger writes to its output, byte for byte
+// and with colours already resolved, to w as well. Unlike passing an
+// [io.MultiWriter] to [Logger.SetOutput], the output's colour detection
+// is unaffected, which makes Tap suited to golden tests. Animations and
+// groups started after the call are also copied. Sub-loggers created
+// after the call inherit the tap. A nil w is equivalent to [Logger.Untap].
+func (l *Logger) Tap(w io.Writer) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tap = w
+}
+
+// Untap stops copying output to the writer set by [Logger.Tap].
+func (l *Logger) Untap() {
+	l.Tap(nil)
+}
+
+// writer returns the writer entries are written to: [Logger.teeWriter],
+// with colours converted to the output's [ColorProfile]. The caller must
+// hold l.mu.
+func (l *Logger) writer() io.Writer {
+	return l.output.colorWriter(l.teeWriter())
+}
+
+// teeWriter returns the output's writer, teed to the [Logger.Tap] writer
+// if one is set, or the [Logger.Batch] buffer inside a batch. The caller
+// must hold l.mu.
+func (l *Logger) teeWriter() io.Writer {
+	if l.batch != nil {
+		return l.batch
+	}
+	if l.tap == nil {
+		return l.output.Writer()
+	}
+	return io.MultiWriter(l.output.Writer(), l.tap)
+}
+
 // SetParts sets the order in which parts appear in log output.
 // Parts not included in the order are hidden. Parts can be reordered freely.
 // Panics if no parts are provided.
@@ -479,6 +1148,35 @@ func (l *Logger) SetPercentPrecision(precision int) {
 	l.percentPrecision = precision
 }
 
+// SetPercentThresholds sets discrete colour bands for Percent fields, used
+// instead of the gradient for values at or above a band's AtLeast; see
+// [Styles.PercentThresholds]. The logger's [Styles] are copied, so other
+// loggers sharing them are unaffected. Pass nil to restore the gradient.
+//
+//	l.SetPercentThresholds([]clog.PercentThreshold{
+//		{AtLeast: 0, Style: green},
+//		{AtLeast: 80, Style: yellow},
+//		{AtLeast: 95, Style: red},
+//	})
+func (l *Logger) SetPercentThresholds(thresholds []PercentThreshold) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	styles := *l.styles
+	styles.PercentThresholds = slices.Clone(thresholds)
+	l.styles = &styles
+}
+
+// SetPrefixPlaceholderWhenEmpty controls how [PartPrefix] renders when the
+// resolved prefix is empty (e.g. via [Event.Prefix] with ""). When enabled,
+// the prefix is replaced by spaces matching the widest configured prefix so
+// messages stay aligned with lines that have one. Defaults to false, which
+// omits the part entirely.
+func (l *Logger) SetPrefixPlaceholderWhenEmpty(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.prefixPlaceholderWhenEmpty = enabled
+}
+
 // SetPrefixes sets the emoji prefixes used for each level.
 // Pass a map from [Level] to prefix string. Missing levels fall back to the defaults.
 func (l *Logger) SetPrefixes(prefixes LevelMap) {
@@ -490,6 +1188,42 @@ func (l *Logger) SetPrefixes(prefixes LevelMap) {
 	l.prefixes = merged
 }
 
+// SetPrefixStyle replaces the logger's level prefixes with a built-in set.
+// It is shorthand for [Logger.SetPrefixes] with [DefaultPrefixes],
+// [ASCIIPrefixes] or [NerdFontPrefixes].
+func (l *Logger) SetPrefixStyle(style PrefixStyle) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	switch style {
+	case PrefixASCII:
+		l.prefixes = ASCIIPrefixes()
+	case PrefixNerdFont:
+		l.prefixes = NerdFontPrefixes()
+	case PrefixEmoji:
+		l.prefixes = DefaultPrefixes()
+	}
+}
+
+// SetQuantityColumnWidth right-aligns quantity field values within n
+// visible columns, so repeated lines keep their values lined up.
+// Defaults to 0 (no padding).
+func (l *Logger) SetQuantityColumnWidth(n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.quantityColumnWidth = n
+}
+
+// SetQuantityThousandsSep sets the digit grouping character accepted in the
+// numeric part of quantities (e.g. ',' for "1,000MB"). The separator is kept
+// with the number when styling and ignored when matching thresholds.
+// Defaults to 0 (no grouping).
+func (l *Logger) SetQuantityThousandsSep(sep rune) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.quantityThousandsSep = sep
+}
+
 // SetQuantityUnitsIgnoreCase sets whether quantity unit matching is
 // case-insensitive. Defaults to true.
 func (l *Logger) SetQuantityUnitsIgnoreCase(ignoreCase bool) {
@@ -529,6 +1263,16 @@ func (l *Logger) SetQuoteMode(mode QuoteMode) {
 	l.quoteMode = mode
 }
 
+// SetReportGoroutine enables or disables tagging each entry with a
+// "goroutine" field holding the id of the logging goroutine. The id is parsed
+// from [runtime.Stack], so this adds a small cost to every entry. Ids set by
+// [Logger.WithWorker] take precedence.
+func (l *Logger) SetReportGoroutine(report bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.reportGoroutine = report
+}
+
 // SetReportTimestamp enables or disables timestamp reporting.
 func (l *Logger) SetReportTimestamp(report bool) {
 	l.mu.Lock()
@@ -536,6 +1280,46 @@ func (l *Logger) SetReportTimestamp(report bool) {
 	l.reportTimestamp = report
 }
 
+// SetSanitizeControl sets whether raw control characters in messages,
+// prefixes, field keys and field values are escaped before output (e.g.
+// ESC becomes the literal text \x1b). Values of types clog does not
+// render itself, such as maps and structs, are replaced by their escaped
+// string when they contain control characters. This stops untrusted input from injecting terminal
+// escape sequences. Newlines and tabs are kept, and the styling clog adds
+// itself is unaffected. Defaults to false.
+func (l *Logger) SetSanitizeControl(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sanitizeControl = enabled
+}
+
+// SetScrapeToken prepends the token returned by fn to every line, ahead of
+// all parts and the indent, so that log scrapers can match a stable prefix
+// such as "clog:inf:" regardless of the timestamp or emoji prefix:
+//
+//	l.SetScrapeToken(func(e clog.Entry) string {
+//	    return "clog:" + strings.ToLower(e.Level.String()) + ":"
+//	})
+//
+// The token is never styled, even under [ColorAlways], and is separated
+// from the line by a space. An empty token adds nothing. fn is called with
+// the logger's lock held, so it must not log. Entries sent to a custom
+// [Handler] are unaffected. nil (the default) disables the token.
+func (l *Logger) SetScrapeToken(fn func(Entry) string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.scrapeToken = fn
+}
+
+// SetSectionRule sets whether [Logger.Section] draws a horizontal rule
+// beneath the title, spanning the terminal width (or the title width when
+// the output is not a terminal). Defaults to false.
+func (l *Logger) SetSectionRule(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sectionRule = enabled
+}
+
 // SetSeparatorText sets the separator between field keys and values.
 // Defaults to "=".
 func (l *Logger) SetSeparatorText(sep string) {
@@ -544,6 +1328,15 @@ func (l *Logger) SetSeparatorText(sep string) {
 	l.separatorText = sep
 }
 
+// SetStatsPrecision sets the number of decimal places for the min, avg and
+// max of [Event.Stats] fields. A negative precision uses the fewest digits
+// that represent each value exactly. Defaults to 2.
+func (l *Logger) SetStatsPrecision(precision int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.statsPrecision = precision
+}
+
 // SetStyles sets the display styles. If styles is nil, [DefaultStyles] is used.
 func (l *Logger) SetStyles(styles *Styles) {
 	l.mu.Lock()
@@ -554,7 +1347,8 @@ func (l *Logger) SetStyles(styles *Styles) {
 	l.styles = styles
 }
 
-// SetTimeFormat sets the timestamp format string.
+// SetTimeFormat sets the timestamp layout (see [time.Layout]). Defaults to
+// "15:04:05.000"; see also [Logger.SetTimestampPreset].
 func (l *Logger) SetTimeFormat(format string) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
@@ -572,15 +1366,103 @@ func (l *Logger) SetTimeLocation(loc *time.Location) {
 	l.timeLocation = loc
 }
 
-// With returns a [Context] for building a sub-logger with preset fields.
-//
-//	logger := clog.With().Str("component", "auth").Logger()
-//	logger.Info().Str("user", "john").Msg("Authenticated")
-func (l *Logger) With() *Context {
+// SetTimestampGradient colours the timestamp by how much time has passed
+// since this call, interpolating across stops from the first (at the time
+// of the call) to the last (once window has elapsed). With a dim first stop
+// and a bright last one, older lines in a long transcript appear faded.
+// The gradient replaces the foreground of [Styles.Timestamp] and only
+// applies when colours are enabled. A window of zero always uses the last
+// stop. Pass nil stops to disable.
+func (l *Logger) SetTimestampGradient(stops []ColorStop, window time.Duration) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
-	fields := make([]Field, len(l.fields))
-	copy(fields, l.fields)
+	l.timestampGradient = slices.Clone(stops)
+	l.timestampGradientStart = time.Now()
+	l.timestampGradientWindow = window
+}
+
+// SetTimestampPreset sets the timestamp format to one of the common
+// [TimestampPreset] formats, replacing any [Logger.SetTimeFormat] layout.
+// Unknown presets are ignored.
+func (l *Logger) SetTimestampPreset(preset TimestampPreset) {
+	format, ok := timestampPresetFormats[preset]
+	if !ok {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.timeFormat = format
+}
+
+// SetTreeIndent sets the indentation added per nesting depth for [Tree]
+// children. Defaults to two spaces. Trees created afterwards use the new
+// indentation.
+func (l *Logger) SetTreeIndent(indent string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.treeIndent = indent
+}
+
+// SetWrapSlices controls whether slice fields that would run past the
+// output's width ([Output.Width]) are broken between elements onto
+// continuation lines, aligned under the first element. Outputs without a
+// known width, such as files and pipes, are never wrapped. Disabled by
+// default.
+func (l *Logger) SetWrapSlices(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.wrapSlices = enabled
+}
+
+// UseHandlerMiddleware wraps the logger's current [Handler] with the given
+// middleware. The first middleware is outermost, so entries pass through
+// them in argument order before reaching the handler. When no handler is
+// set, the built-in pretty formatter is wrapped instead.
+//
+//	logger.SetHandler(clog.NewJSONHandler(os.Stdout, nil))
+//	logger.UseHandlerMiddleware(
+//	    clog.FieldInjector(clog.Field{Key: "host", Value: hostname}),
+//	    clog.Sampler(10),
+//	)
+func (l *Logger) UseHandlerMiddleware(mw ...HandlerMiddleware) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	h := l.handler
+	if h == nil {
+		h = HandlerFunc(renderEntry)
+	}
+
+	for _, m := range slices.Backward(mw) {
+		if m != nil {
+			h = m(h)
+		}
+	}
+	l.handler = h
+}
+
+// renderEntry writes e with the pretty formatter of the logger that
+// emitted it, so that sub-loggers sharing a middleware chain render with
+// their own configuration. It runs with that logger's mu held. Entries not
+// emitted by a logger are dropped.
+func renderEntry(e Entry) {
+	l := e.logger
+	if l == nil {
+		return
+	}
+	_, _ = io.WriteString(l.writer(), l.render(e, l.entryParts(e))+"\n")
+}
+
+// With returns a [Context] for building a sub-logger with preset fields.
+//
+//	logger := clog.With().Str("component", "auth").Logger()
+//	logger.Info().Str("user", "john").Msg("Authenticated")
+func (l *Logger) With() *Context {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fields := make([]Field, len(l.fields))
+	copy(fields, l.fields)
 
 	c := &Context{
 		logger: l,
@@ -617,6 +1499,11 @@ func (l *Logger) Error() *Event { return l.newEvent(ErrorLevel) }
 // Fatal returns a new [Event] at fatal level.
 func (l *Logger) Fatal() *Event { return l.newEvent(FatalLevel) }
 
+// Enabled reports whether logging is enabled. See [Logger.SetEnabled].
+func (l *Logger) Enabled() bool {
+	return !l.disabled.Load()
+}
+
 // Level returns the current minimum log level.
 func (l *Logger) Level() Level {
 	l.mu.Lock()
@@ -624,18 +1511,113 @@ func (l *Logger) Level() Level {
 	return l.level
 }
 
+// ErrorFieldCount returns the number of entries logged with [Event.Err]
+// since the logger was created or [Logger.ResetErrorFieldCount] was called,
+// whatever their level. The count is shared with sub-loggers, so it can
+// back a final summary:
+//
+//	if n := clog.Default.ErrorFieldCount(); n > 0 {
+//		clog.Warn().Msgf("Completed with %d errors", n)
+//	}
+func (l *Logger) ErrorFieldCount() uint64 {
+	return l.errorFields.Load()
+}
+
+// ResetErrorFieldCount resets the count returned by
+// [Logger.ErrorFieldCount] to zero.
+func (l *Logger) ResetErrorFieldCount() {
+	l.errorFields.Store(0)
+}
+
+// ResetOnce forgets the keys seen by [Event.Once], so that each logs again.
+// The keys are shared with sub-loggers, which are reset too. Intended for
+// tests.
+func (l *Logger) ResetOnce() {
+	l.onceKeys.Clear()
+}
+
+// omitFunc returns the predicate for fields dropped by [Logger.log], or nil
+// if none are dropped. The caller must hold l.mu.
+func (l *Logger) omitFunc() func(Field) bool {
+	switch {
+	case l.omitPredicate != nil:
+		return l.omitPredicate
+	case l.omitZero:
+		return isZeroField
+	case l.omitEmpty:
+		return isEmptyField
+	}
+	return nil
+}
+
+// now returns the current time from the logger's clock.
+func (l *Logger) now() time.Time {
+	if l.nowFunc != nil {
+		return l.nowFunc()
+	}
+	return time.Now()
+}
+
+// gateLevel returns the lowest level any event can be logged at: the
+// global level, or a more verbose [Logger.SetLevelForField] override.
+// The caller must hold l.mu.
+func (l *Logger) gateLevel() Level {
+	level := l.level
+	for _, fl := range l.fieldLevels {
+		level = min(level, fl)
+	}
+	return level
+}
+
+// styleFieldsLevel returns the minimum level at which field values are
+// styled: the field style level, lowered by [Logger.SetAlwaysStyleFieldsAtOrAbove].
+// The caller must hold l.mu.
+func (l *Logger) styleFieldsLevel() Level {
+	return min(l.fieldStyleLevel, l.alwaysStyleFieldsLevel)
+}
+
+// fieldLevel returns the minimum level for an event carrying the given
+// fields: the most verbose matching [Logger.SetLevelForField] override, or
+// the global level when none match. The caller must hold l.mu.
+func (l *Logger) fieldLevel(fieldSets ...[]Field) Level {
+	level, matched := l.level, false
+	for _, fields := range fieldSets {
+		for _, f := range fields {
+			v, _ := unwrapNoted(f.Value)
+			fl, ok := l.fieldLevels[fieldMatch{key: f.Key, value: fmt.Sprint(v)}]
+			if !ok {
+				continue
+			}
+			if !matched || fl < level {
+				level, matched = fl, true
+			}
+		}
+	}
+	return level
+}
+
+// dictSep returns the separator used to flatten [Dict] keys.
+func (l *Logger) dictSep() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.dictSeparator
+}
+
 // colorsDisabled returns true if this logger should suppress colours.
 func (l *Logger) colorsDisabled() bool {
 	return l.output.ColorsDisabled()
 }
 
-// exit calls the logger's exit function (used by Fatal-level events).
+// exit calls the logger's exit function (used by Fatal-level events),
+// unless disabled with [Logger.SetFatalExits].
 func (l *Logger) exit(code int) {
 	l.mu.Lock()
-	fn := l.exitFunc
+	fn, exits := l.exitFunc, l.fatalExits
 	l.mu.Unlock()
 
-	fn(code)
+	if exits {
+		fn(code)
+	}
 }
 
 // formatLabel returns the pre-computed padded level label.
@@ -646,6 +1628,49 @@ func (l *Logger) formatLabel(level Level) string {
 	return l.labelsPadded[level]
 }
 
+// styledLevel styles a (padded) level label with [Styles.Levels], or as a
+// badge (see [Logger.SetLevelBadge]) when badge is set. Colours are
+// omitted when noColor is set, leaving the plain label.
+func styledLevel(label string, level Level, styles *Styles, badge, noColor bool) string {
+	if noColor {
+		return label
+	}
+
+	if badge {
+		label = " " + label + " "
+		if style := levelBadgeStyle(level, styles); style != nil {
+			return style.Render(label)
+		}
+		return label
+	}
+
+	if style := styles.Levels[level]; style != nil {
+		return style.Render(label)
+	}
+	return label
+}
+
+// levelBadgeStyle returns the [Styles.LevelBadges] style for level, or one
+// derived from the foreground of its [Styles.Levels] style: bold black text
+// on that colour. Returns nil if neither is set.
+func levelBadgeStyle(level Level, styles *Styles) Style {
+	if style := styles.LevelBadges[level]; style != nil {
+		return style
+	}
+
+	base := styles.Levels[level]
+	if base == nil {
+		return nil
+	}
+
+	fg := base.GetForeground()
+	if _, ok := fg.(lipgloss.NoColor); ok {
+		return nil
+	}
+
+	return new(lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("0")).Background(fg))
+}
+
 // recomputePaddedLabels rebuilds the labelsPadded cache from the current
 // labels, labelWidth, and levelAlign settings. Must be called with l.mu held.
 func (l *Logger) recomputePaddedLabels() {
@@ -680,128 +1705,380 @@ func (l *Logger) recomputePaddedLabels() {
 func (l *Logger) log(e *Event, msg string) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
-	// Merge logger context fields with event fields.
+	// Merge default fields, then logger context fields, with event fields.
+	ctxFields := l.fields
+	if len(l.defaultFields) > 0 && !e.noDefaults {
+		ctxFields = slices.Concat(l.defaultFields, l.fields)
+	}
+
+	if len(l.fieldLevels) > 0 && e.level < l.fieldLevel(ctxFields, e.fields) {
+		return
+	}
+
+	// Counted only once the entry is known to be emitted.
+	if e.err != nil {
+		l.errorFields.Add(1)
+	}
+
+	evFields := e.fields
+	if l.fieldSort == SortNoneThenAscending && len(evFields) > 1 {
+		evFields = slices.Clone(evFields)
+		slices.SortStableFunc(evFields, func(a, b Field) int {
+			return strings.Compare(a.Key, b.Key)
+		})
+	}
+
 	var allFields []Field
-	needsFilter := l.omitZero || l.omitEmpty
+	omit := l.omitFunc()
+	needsFilter := omit != nil
 	switch {
-	case len(l.fields) == 0 && len(e.fields) == 0:
+	case len(ctxFields) == 0 && len(evFields) == 0:
 		// no fields
-	case len(l.fields) == 0:
+	case len(ctxFields) == 0:
 		if needsFilter {
-			allFields = slices.Clone(e.fields)
+			allFields = slices.Clone(evFields)
 		} else {
-			allFields = e.fields
+			allFields = evFields
 		}
-	case len(e.fields) == 0:
+	case len(evFields) == 0:
 		if needsFilter {
-			allFields = slices.Clone(l.fields)
+			allFields = slices.Clone(ctxFields)
 		} else {
-			allFields = l.fields
+			allFields = ctxFields
 		}
 	default:
-		allFields = slices.Concat(l.fields, e.fields)
+		allFields = slices.Concat(ctxFields, evFields)
 	}
 
-	if l.omitZero {
-		allFields = slices.DeleteFunc(allFields, func(f Field) bool {
-			return isZeroValue(f.Value)
-		})
-	} else if l.omitEmpty {
-		allFields = slices.DeleteFunc(allFields, func(f Field) bool {
-			return isEmptyValue(f.Value)
-		})
+	if omit != nil {
+		allFields = slices.DeleteFunc(allFields, omit)
 	}
 
 	prefix := l.resolvePrefix(e)
+	if l.sanitizeControl {
+		msg = escapeControl(msg)
+		prefix = escapeControl(prefix)
+		allFields = escapeControlFields(allFields)
+	}
+
+	// Added after filtering so that a worker id of 0 is never omitted.
+	if id, ok := l.goroutineID(); ok {
+		allFields = slices.Concat([]Field{{Key: goroutineKey, Value: id}}, allFields)
+	}
+
+	if l.dryRun {
+		allFields = append(slices.Clip(allFields), Field{Key: DryRunKey, Value: true})
+	}
+
+	entry := Entry{
+		DryRun:  l.dryRun,
+		Level:   e.level,
+		Message: msg,
+		Prefix:  prefix,
+		Fields:  allFields,
+		logger:  l,
+
+		hiddenParts: e.hiddenParts,
+		onlyParts:   e.onlyParts,
+	}
+	if !e.timestamp.IsZero() {
+		entry.Time = e.timestamp.In(l.timeLocation)
+	} else if l.reportTimestamp {
+		entry.Time = l.now().In(l.timeLocation)
+	}
 
 	// Delegate to custom handler if set.
 	if l.handler != nil {
-		entry := Entry{
-			Level:   e.level,
-			Message: msg,
-			Prefix:  prefix,
-			Fields:  allFields,
+		l.handler.Log(entry)
+		return
+	}
+
+	_, _ = io.WriteString(l.writer(), l.render(entry, l.entryParts(entry))+"\n")
+}
+
+// levelRule returns the rule drawn by [Logger.SetLevelRule] around line.
+// The caller must hold l.mu.
+func (l *Logger) levelRule(line string) string {
+	char := sectionRuleChar
+	if !l.output.IsTTY() {
+		char = "-"
+	}
+
+	width := l.output.Width()
+	if width <= 0 {
+		width = lipgloss.Width(line)
+	}
+
+	var style Style
+	if !l.colorsDisabled() {
+		style = l.styles.Rule
+	}
+
+	var buf strings.Builder
+	emitStyled(&buf, strings.Repeat(char, width), style)
+	return buf.String()
+}
+
+// Render formats an [Entry] as a single line (without a trailing newline)
+// using the logger's built-in pretty formatter, with any rules set by
+// [Logger.SetLevelRule] on their own lines. The logger's [Handler], if
+// any, is ignored. Parts set on the event with [Event.OnlyParts] and
+// [Event.HidePart] are honoured.
+func (l *Logger) Render(e Entry) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.render(e, l.entryParts(e))
+}
+
+// entryParts returns the parts to render e with: the logger's parts, or
+// those set by [Event.OnlyParts], less any hidden by [Event.HidePart].
+// The caller must hold l.mu.
+func (l *Logger) entryParts(e Entry) []Part {
+	parts := l.parts
+	if e.onlyParts != nil {
+		parts = e.onlyParts
+	}
+	if len(e.hiddenParts) > 0 {
+		parts = slices.DeleteFunc(slices.Clone(parts), func(p Part) bool {
+			return slices.Contains(e.hiddenParts, p)
+		})
+	}
+	return parts
+}
+
+// render formats an entry with the built-in pretty formatter, laying out
+// parts in order. When the line is wider than [Logger.SetMaxLineLen],
+// fields are dropped in [Logger.SetFieldPriority] order until it fits.
+// Any [Logger.SetLevelRule] rules are added on their own lines.
+// The caller must hold l.mu.
+func (l *Logger) render(e Entry, parts []Part) string {
+	line := l.renderFitted(e, parts)
+
+	if pos := l.levelRules[e.Level]; pos != RuleNone {
+		rule := l.levelRule(line)
+		if pos&RuleBefore != 0 {
+			line = rule + "\n" + line
 		}
-		if !e.timestamp.IsZero() {
-			entry.Time = e.timestamp.In(l.timeLocation)
-		} else if l.reportTimestamp {
-			entry.Time = time.Now().In(l.timeLocation)
+		if pos&RuleAfter != 0 {
+			line += "\n" + rule
 		}
+	}
+	return line
+}
 
-		l.handler.Log(entry)
-		return
+// renderFitted renders e with [Logger.renderLine], dropping fields until
+// the line fits [Logger.SetMaxLineLen]. The caller must hold l.mu.
+func (l *Logger) renderFitted(e Entry, parts []Part) string {
+	ts := l.renderTimestamp(e.Time)
+	line := l.renderLine(e, parts, ts, 0)
+	if l.maxLineLen <= 0 || len(e.Fields) == 0 || lipgloss.Width(line) <= l.maxLineLen {
+		return line
+	}
+
+	all := e.Fields
+	drop := make([]bool, len(all))
+	for n, i := range l.fieldDropOrder(all) {
+		drop[i] = true
+
+		e.Fields = make([]Field, 0, len(all)-n-1)
+		for j, f := range all {
+			if !drop[j] {
+				e.Fields = append(e.Fields, f)
+			}
+		}
+
+		line = l.renderLine(e, parts, ts, n+1)
+		if lipgloss.Width(line) <= l.maxLineLen {
+			break
+		}
+	}
+	return line
+}
+
+// fieldDropOrder returns the indices of fields in the order they are
+// dropped to fit [Logger.SetMaxLineLen]: lowest priority first and, among
+// equal priorities, rightmost first. The caller must hold l.mu.
+func (l *Logger) fieldDropOrder(fields []Field) []int {
+	order := make([]int, len(fields))
+	for i := range order {
+		order[i] = len(fields) - 1 - i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(l.fieldPriorities[fields[a].Key], l.fieldPriorities[fields[b].Key])
+	})
+	return order
+}
+
+// renderTimestamp formats t for the timestamp part, or returns "" when t is
+// zero. With [Logger.SetCollapseRepeatTimestamp], a timestamp equal to the
+// previous line's is replaced by spaces of the same width. The caller must
+// hold l.mu.
+func (l *Logger) renderTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
 	}
 
-	// Built-in pretty formatter.
+	ts := formatTimestamp(t, l.timeFormat)
+	if !l.collapseRepeatTimestamp {
+		return ts
+	}
+	if ts == l.lastTimestamp {
+		return strings.Repeat(" ", lipgloss.Width(ts))
+	}
+	l.lastTimestamp = ts
+	return ts
+}
+
+// renderLine formats an entry as a single line laid out in order, using ts
+// from [Logger.renderTimestamp] for the timestamp part. dropped is the
+// number of fields removed by [Logger.render], shown as a "…+N" marker
+// after the remaining fields when non-zero. The caller must hold l.mu.
+func (l *Logger) renderLine(e Entry, order []Part, ts string, dropped int) string {
 	noColor := l.colorsDisabled()
 
+	// The scrape token comes before everything, including the indent.
+	lead := l.indent
+	if l.scrapeToken != nil {
+		if token := l.scrapeToken(e); token != "" {
+			lead = token + " " + lead
+		}
+	}
+
+	opts := formatFieldsOpts{
+		autoColorKeys:              l.autoColorKeys,
+		boolWords:                  l.boolWords,
+		durationColumnWidth:        l.durationColumnWidth,
+		durationUsesQuantityStyles: l.durationUsesQuantityStyles,
+		elapsedFormatFunc:          l.elapsedFormatFunc,
+		elapsedMinimum:             l.elapsedMinimum,
+		elapsedPrecision:           l.elapsedPrecision,
+		elapsedRound:               l.elapsedRound,
+		emptyRepr:                  l.emptyRepr,
+		fieldKeyAlign:              l.fieldKeyAlign,
+		fieldOrder:                 l.fieldOrder,
+		fieldSort:                  l.fieldSort,
+		fieldStyleLevel:            l.styleFieldsLevel(),
+		keyTruncate:                l.keyTruncate,
+		level:                      e.Level,
+		measurePrecision:           l.measurePrecision,
+		nilRepr:                    l.nilRepr,
+		noColor:                    noColor,
+		numberGrouping:             l.numberGrouping,
+		percentFormatFunc:          l.percentFormatFunc,
+		percentPrecision:           l.percentPrecision,
+		quantityColumnWidth:        l.quantityColumnWidth,
+		quantityThousandsSep:       l.quantityThousandsSep,
+		quantityUnitsIgnoreCase:    l.quantityUnitsIgnoreCase,
+		quoteOpen:                  l.quoteOpen,
+		quoteClose:                 l.quoteClose,
+		quoteMode:                  l.quoteMode,
+		separatorText:              l.separatorText,
+		statsPrecision:             l.statsPrecision,
+		styles:                     l.styles,
+		timeFormat:                 l.fieldTimeFormat,
+	}
+
+	// With PartGoroutine, the goroutine field moves out of the fields part.
+	fields := e.Fields
+	var goroutine []Field
+	if slices.Contains(order, PartGoroutine) {
+		if i := slices.IndexFunc(fields, func(f Field) bool { return f.Key == goroutineKey }); i >= 0 {
+			goroutine = fields[i : i+1]
+			fields = slices.Delete(slices.Clone(fields), i, i+1)
+		}
+	}
+
 	var partsArr [8]string
 	parts := partsArr[:0]
 
-	for _, p := range l.parts {
+	if l.leadingTagField != "" {
+		if i := slices.IndexFunc(fields, func(f Field) bool { return f.Key == l.leadingTagField }); i >= 0 {
+			parts = append(parts, l.leadingTag(fields[i], noColor))
+			fields = slices.Delete(slices.Clone(fields), i, i+1)
+		}
+	}
+
+	for _, p := range order {
 		var s string
 
 		switch p {
 		case PartTimestamp:
-			if e.timestamp.IsZero() && !l.reportTimestamp {
+			if ts == "" {
 				continue
 			}
 
-			var now time.Time
-			if !e.timestamp.IsZero() {
-				now = e.timestamp.In(l.timeLocation)
-			} else {
-				now = time.Now().In(l.timeLocation)
-			}
-			ts := now.Format(l.timeFormat)
-			if noColor || l.styles.Timestamp == nil {
+			switch {
+			case noColor, strings.TrimSpace(ts) == "":
 				s = ts
-			} else {
+			case len(l.timestampGradient) > 0:
+				s = l.timestampGradientStyle(e.Time).Render(ts)
+			case l.styles.Timestamp != nil:
 				s = l.styles.Timestamp.Render(ts)
+			default:
+				s = ts
 			}
 		case PartLevel:
-			label := l.formatLabel(e.level)
-			if style := l.styles.Levels[e.level]; !noColor && style != nil {
-				s = style.Render(label)
-			} else {
-				s = label
-			}
+			s = styledLevel(l.formatLabel(e.Level), e.Level, l.styles, l.levelBadge, noColor)
 		case PartPrefix:
-			if prefix == "" {
-				continue
-			}
+			if e.Prefix == "" {
+				if !l.prefixPlaceholderWhenEmpty {
+					continue
+				}
 
-			s = prefix
+				s = strings.Repeat(" ", l.widestPrefix())
+			} else {
+				s = e.Prefix
+			}
 		case PartMessage:
+			msg := truncateText(e.Message, l.messageTruncateLen, l.messageTruncateMode)
+			msgLevel := e.Level
+			if e.DryRun {
+				msgLevel = DryLevel
+			}
 			if msg == "" {
-				continue
+				if l.emptyMessageMode != EmptyMessageKeep {
+					continue
+				}
+
+				msg = l.emptyMessagePlaceholder
+				if msg == "" {
+					// Reserve the column even with an empty placeholder.
+					parts = append(parts, "")
+					continue
+				}
 			}
 
-			if style := l.styles.Messages[e.level]; !noColor && style != nil {
+			if l.highlightMessageJSON && !noColor && l.styles.FieldJSON != nil {
+				if start, end, ok := findJSONSpan(msg); ok {
+					s = l.highlightMessage(msg, start, end, msgLevel)
+					break
+				}
+			}
+
+			if style := l.styles.Messages[msgLevel]; !noColor && style != nil {
 				s = style.Render(msg)
 			} else {
 				s = msg
 			}
 		case PartFields:
-			s = strings.TrimLeft(formatFields(allFields, formatFieldsOpts{
-				elapsedFormatFunc:       l.elapsedFormatFunc,
-				elapsedMinimum:          l.elapsedMinimum,
-				elapsedPrecision:        l.elapsedPrecision,
-				elapsedRound:            l.elapsedRound,
-				fieldSort:               l.fieldSort,
-				fieldStyleLevel:         l.fieldStyleLevel,
-				level:                   e.level,
-				noColor:                 noColor,
-				percentFormatFunc:       l.percentFormatFunc,
-				percentPrecision:        l.percentPrecision,
-				quantityUnitsIgnoreCase: l.quantityUnitsIgnoreCase,
-				quoteOpen:               l.quoteOpen,
-				quoteClose:              l.quoteClose,
-				quoteMode:               l.quoteMode,
-				separatorText:           l.separatorText,
-				styles:                  l.styles,
-				timeFormat:              l.fieldTimeFormat,
-			}), " ")
+			col := lipgloss.Width(lead)
+			for _, p := range parts {
+				col += lipgloss.Width(p) + 1
+			}
+			if l.wrapSlices {
+				if w := l.output.Width(); w > col {
+					opts.wrapWidth = w - col
+				}
+			}
+			// Continuation lines of [Event.Lines] fields and wrapped slices
+			// are padded past the parts before.
+			opts.indent = col
+			s = strings.TrimLeft(formatFields(fields, opts), " ")
+			if dropped > 0 {
+				s = strings.TrimLeft(s+" "+droppedFieldsMarker+strconv.Itoa(dropped), " ")
+			}
+		case PartGoroutine:
+			s = strings.TrimLeft(formatFields(goroutine, opts), " ")
 		}
 
 		if s != "" {
@@ -816,16 +2093,83 @@ func (l *Logger) log(e *Event, msg string) {
 		}
 		lineBuf.WriteString(p)
 	}
-	lineBuf.WriteByte('\n')
-	_, _ = io.WriteString(l.output.Writer(), lineBuf.String())
+
+	line := lineBuf.String()
+	if style := l.styles.LineByLevel[e.Level]; !noColor && style != nil {
+		line = styleLine(line, style)
+	}
+	return lead + line
+}
+
+// leadingTag renders f as the bracketed tag set by
+// [Logger.SetLeadingTagField]. The caller must hold l.mu.
+func (l *Logger) leadingTag(f Field, noColor bool) string {
+	val, _ := unwrapNoted(f.Value)
+	valStr, _ := formatValue(
+		val,
+		QuoteNever,
+		0,
+		0,
+		l.fieldTimeFormat,
+		l.percentPrecision,
+		l.elapsedPrecision,
+	)
+
+	tag := "[" + valStr + "]"
+	if style := l.styles.LeadingTag; !noColor && style != nil {
+		return style.Render(tag)
+	}
+	return tag
+}
+
+// highlightMessage renders msg with the JSON span msg[start:end]
+// highlighted by [Styles.FieldJSON] and the surrounding text in the level's
+// message style. The caller must hold l.mu.
+func (l *Logger) highlightMessage(msg string, start, end int, level Level) string {
+	style := l.styles.Messages[level]
+
+	var buf strings.Builder
+	if start > 0 {
+		emitStyled(&buf, msg[:start], style)
+	}
+	buf.WriteString(highlightJSON(msg[start:end], l.styles.FieldJSON))
+	if end < len(msg) {
+		emitStyled(&buf, msg[end:], style)
+	}
+	return buf.String()
+}
+
+// droppedFieldsMarker precedes the number of fields dropped to fit
+// [Logger.SetMaxLineLen].
+const droppedFieldsMarker = "…+"
+
+// ansiReset is the SGR sequence that clears all text attributes.
+const ansiReset = "\x1b[0m"
+
+// styleLine wraps an assembled line in style as the outermost layer. The
+// style's opening sequence is re-emitted after every reset produced by
+// inner part styles so that attributes such as backgrounds span the whole
+// line.
+func styleLine(line string, style Style) string {
+	const marker = "\x00"
+
+	open, closing, ok := strings.Cut(style.Render(marker), marker)
+	if !ok || open == "" {
+		return line
+	}
+
+	return open + strings.ReplaceAll(line, ansiReset, ansiReset+open) + closing
 }
 
 // newEvent creates a new [Event] for the given level.
 // Returns nil if the level is below the logger's minimum (all Event methods
 // are no-ops on nil).
 func (l *Logger) newEvent(level Level) *Event {
-	// Fast path: lock-free level check to skip disabled events without
-	// acquiring the mutex.
+	// Fast path: lock-free enabled and level checks to skip disabled events
+	// without acquiring the mutex.
+	if l.disabled.Load() {
+		return nil
+	}
 	//nolint:gosec // Level values are small constants (0-6)
 	if int32(level) < l.atomicLevel.Load() {
 		return nil
@@ -846,9 +2190,57 @@ func (l *Logger) resolvePrefix(e *Event) string {
 	if l.prefix != nil {
 		return *l.prefix
 	}
+	if l.dryRun {
+		return l.prefixes[DryLevel]
+	}
 	return l.prefixes[e.level]
 }
 
+// formatTimestamp formats t with layout, handling the Unix presets that
+// [time.Time.Format] cannot produce.
+func formatTimestamp(t time.Time, layout string) string {
+	switch layout {
+	case timeFormatUnix:
+		return strconv.FormatInt(t.Unix(), 10)
+	case timeFormatUnixMilli:
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	default:
+		return t.Format(layout)
+	}
+}
+
+// timestampGradientStyle returns the timestamp style with its foreground
+// taken from the timestamp gradient at t's position within the window.
+// The caller must hold l.mu.
+func (l *Logger) timestampGradientStyle(t time.Time) lipgloss.Style {
+	var style lipgloss.Style
+	if l.styles.Timestamp != nil {
+		style = *l.styles.Timestamp
+	}
+
+	pos := 1.0
+	if l.timestampGradientWindow > 0 {
+		pos = float64(t.Sub(l.timestampGradientStart)) / float64(l.timestampGradientWindow)
+		pos = max(0, min(1, pos))
+	}
+
+	c := interpolateGradient(pos, l.timestampGradient)
+	return style.Foreground(lipgloss.Color(c.Clamped().Hex()))
+}
+
+// widestPrefix returns the widest visible width among the configured
+// prefixes. The caller must hold l.mu.
+func (l *Logger) widestPrefix() int {
+	width := 0
+	if l.prefix != nil {
+		width = lipgloss.Width(*l.prefix)
+	}
+	for _, p := range l.prefixes {
+		width = max(width, lipgloss.Width(p))
+	}
+	return width
+}
+
 // Config holds configuration options for the [Default] logger.
 type Config struct {
 	// Output is the output to use (defaults to [Stdout]([ColorAuto])).
@@ -897,6 +2289,18 @@ func DefaultPrefixes() LevelMap {
 	return maps.Clone(defaultPrefixes)
 }
 
+// ASCIIPrefixes returns a copy of the plain-text prefixes for each level
+// (e.g. "[*]" for info, "[!]" for warn), used by [PrefixASCII].
+func ASCIIPrefixes() LevelMap {
+	return maps.Clone(asciiPrefixes)
+}
+
+// NerdFontPrefixes returns a copy of the Nerd Font glyph prefixes for each
+// level, used by [PrefixNerdFont]. They require a patched Nerd Font.
+func NerdFontPrefixes() LevelMap {
+	return maps.Clone(nerdFontPrefixes)
+}
+
 // SetVerbose enables or disables verbose mode on the [Default] logger.
 // When verbose is true, it always enables debug logging. When false, it
 // respects the log level environment variable if set.
@@ -929,12 +2333,47 @@ func IsVerbose() bool {
 
 // Package-level convenience functions that use the [Default] logger.
 
+// SetAlwaysStyleFieldsAtOrAbove sets the always-styled field level on the
+// [Default] logger.
+func SetAlwaysStyleFieldsAtOrAbove(level Level) { Default.SetAlwaysStyleFieldsAtOrAbove(level) }
+
+// SetAutoColorKeys sets the hash-coloured field keys on the [Default] logger.
+func SetAutoColorKeys(keys ...string) { Default.SetAutoColorKeys(keys...) }
+
+// SetBoolWords sets the words bool values are rendered with on the [Default] logger.
+func SetBoolWords(trueWord, falseWord string) { Default.SetBoolWords(trueWord, falseWord) }
+
+// SetCollapseRepeatTimestamp sets repeated-timestamp collapsing on the
+// [Default] logger.
+func SetCollapseRepeatTimestamp(enabled bool) { Default.SetCollapseRepeatTimestamp(enabled) }
+
 // SetColorMode sets the colour mode on the [Default] logger by recreating
 // its [Output] with the given mode.
 func SetColorMode(mode ColorMode) {
 	Default.SetColorMode(mode)
 }
 
+// SetColorProfile sets the colour profile on the [Default] logger's [Output].
+func SetColorProfile(p ColorProfile) { Default.SetColorProfile(p) }
+
+// SetConfirmDefault sets the default [Logger.Confirm] answer on the [Default] logger.
+func SetConfirmDefault(answer bool) { Default.SetConfirmDefault(answer) }
+
+// SetDefaultFields sets the default fields on the [Default] logger.
+func SetDefaultFields(fields ...Field) { Default.SetDefaultFields(fields...) }
+
+// SetDictSeparator sets the dict key separator on the [Default] logger.
+func SetDictSeparator(sep string) { Default.SetDictSeparator(sep) }
+
+// SetDryRun sets dry-run mode on the [Default] logger.
+func SetDryRun(enabled bool) { Default.SetDryRun(enabled) }
+
+// SetDurationColumnWidth sets the duration column width on the [Default] logger.
+func SetDurationColumnWidth(n int) { Default.SetDurationColumnWidth(n) }
+
+// SetDurationUsesQuantityStyles sets whether durations use quantity styling on the [Default] logger.
+func SetDurationUsesQuantityStyles(enabled bool) { Default.SetDurationUsesQuantityStyles(enabled) }
+
 // SetElapsedFormatFunc sets the elapsed format function on the [Default] logger.
 func SetElapsedFormatFunc(fn func(time.Duration) string) { Default.SetElapsedFormatFunc(fn) }
 
@@ -947,9 +2386,36 @@ func SetElapsedPrecision(precision int) { Default.SetElapsedPrecision(precision)
 // SetElapsedRound sets the elapsed rounding granularity on the [Default] logger.
 func SetElapsedRound(d time.Duration) { Default.SetElapsedRound(d) }
 
+// SetEmptyMessageMode sets the empty message mode on the [Default] logger.
+func SetEmptyMessageMode(mode EmptyMessageMode) { Default.SetEmptyMessageMode(mode) }
+
+// SetEmptyMessagePlaceholder sets the empty message placeholder on the [Default] logger.
+func SetEmptyMessagePlaceholder(placeholder string) {
+	Default.SetEmptyMessagePlaceholder(placeholder)
+}
+
+// SetEmptyRepr sets the nil and empty-string representations on the [Default] logger.
+func SetEmptyRepr(nilRepr, emptyStrRepr string) { Default.SetEmptyRepr(nilRepr, emptyStrRepr) }
+
+// SetEnabled enables or disables all logging on the [Default] logger,
+// including Fatal. See [Logger.SetEnabled].
+func SetEnabled(enabled bool) { Default.SetEnabled(enabled) }
+
 // SetExitFunc sets the fatal-exit function on the [Default] logger.
 func SetExitFunc(fn func(int)) { Default.SetExitFunc(fn) }
 
+// SetFatalExits sets whether Fatal-level events exit on the [Default] logger.
+func SetFatalExits(exits bool) { Default.SetFatalExits(exits) }
+
+// SetFieldKeyAlign sets per-entry field key alignment on the [Default] logger.
+func SetFieldKeyAlign(align bool) { Default.SetFieldKeyAlign(align) }
+
+// SetFieldOrder sets the leading field keys on the [Default] logger.
+func SetFieldOrder(keys ...string) { Default.SetFieldOrder(keys...) }
+
+// SetFieldPriority sets a field's drop priority on the [Default] logger.
+func SetFieldPriority(key string, priority int) { Default.SetFieldPriority(key, priority) }
+
 // SetFieldSort sets the field sort order on the [Default] logger.
 func SetFieldSort(sort Sort) { Default.SetFieldSort(sort) }
 
@@ -962,18 +2428,61 @@ func SetFieldTimeFormat(format string) { Default.SetFieldTimeFormat(format) }
 // SetHandler sets the log handler on the [Default] logger.
 func SetHandler(h Handler) { Default.SetHandler(h) }
 
+// SetHighlightMessageJSON enables or disables message JSON highlighting on the [Default] logger.
+func SetHighlightMessageJSON(enable bool) { Default.SetHighlightMessageJSON(enable) }
+
+// SetInput sets the [Logger.Confirm] input reader on the [Default] logger.
+func SetInput(r io.Reader) { Default.SetInput(r) }
+
+// SetKeyTruncate sets a per-key head/tail truncation rule on the [Default] logger.
+func SetKeyTruncate(key string, head, tail int) { Default.SetKeyTruncate(key, head, tail) }
+
+// SetLeadingTagField sets the field rendered as a leading tag on the [Default] logger.
+func SetLeadingTagField(key string) { Default.SetLeadingTagField(key) }
+
 // SetLevel sets the minimum log level on the [Default] logger.
 func SetLevel(level Level) { Default.SetLevel(level) }
 
 // SetLevelAlign sets the level-label alignment on the [Default] logger.
 func SetLevelAlign(align Align) { Default.SetLevelAlign(align) }
 
+// SetLevelBadge sets whether level labels render as badges on the [Default] logger.
+func SetLevelBadge(enabled bool) { Default.SetLevelBadge(enabled) }
+
+// SetLevelForField sets a per-field-value minimum level on the [Default] logger.
+func SetLevelForField(key, value string, level Level) {
+	Default.SetLevelForField(key, value, level)
+}
+
 // SetLevelLabels sets the level labels on the [Default] logger.
 func SetLevelLabels(labels LevelMap) { Default.SetLevelLabels(labels) }
 
+// SetLevelRule sets the rule drawn around entries at level on the [Default] logger.
+func SetLevelRule(level Level, position RulePosition) { Default.SetLevelRule(level, position) }
+
+// SetMaxLineLen sets the maximum line width on the [Default] logger.
+func SetMaxLineLen(n int) { Default.SetMaxLineLen(n) }
+
+// SetMeasurePrecision sets the [Event.Measure] precision on the [Default] logger.
+func SetMeasurePrecision(precision int) { Default.SetMeasurePrecision(precision) }
+
+// SetMessageTruncate sets the message truncation width and mode on the [Default] logger.
+func SetMessageTruncate(maxLen int, mode TruncateMode) { Default.SetMessageTruncate(maxLen, mode) }
+
+// SetNumberGrouping sets the number digit grouping separator on the [Default] logger.
+func SetNumberGrouping(sep rune) { Default.SetNumberGrouping(sep) }
+
+// SetNumberThresholds sets per-key number style thresholds on the [Default] logger.
+func SetNumberThresholds(key string, thresholds []Threshold) {
+	Default.SetNumberThresholds(key, thresholds)
+}
+
 // SetOmitEmpty enables or disables omitting empty fields on the [Default] logger.
 func SetOmitEmpty(omit bool) { Default.SetOmitEmpty(omit) }
 
+// SetOmitPredicate sets the field omit predicate on the [Default] logger.
+func SetOmitPredicate(fn func(Field) bool) { Default.SetOmitPredicate(fn) }
+
 // SetOmitZero enables or disables omitting zero-value fields on the [Default] logger.
 func SetOmitZero(omit bool) { Default.SetOmitZero(omit) }
 
@@ -992,9 +2501,26 @@ func SetPercentFormatFunc(fn func(float64) string) { Default.SetPercentFormatFun
 // SetPercentPrecision sets the percent precision on the [Default] logger.
 func SetPercentPrecision(precision int) { Default.SetPercentPrecision(precision) }
 
+// SetPercentThresholds sets the percent colour bands on the [Default] logger.
+func SetPercentThresholds(thresholds []PercentThreshold) {
+	Default.SetPercentThresholds(thresholds)
+}
+
+// SetPrefixPlaceholderWhenEmpty sets whether an empty prefix is padded on the [Default] logger.
+func SetPrefixPlaceholderWhenEmpty(enabled bool) { Default.SetPrefixPlaceholderWhenEmpty(enabled) }
+
 // SetPrefixes sets the level prefixes on the [Default] logger.
 func SetPrefixes(prefixes LevelMap) { Default.SetPrefixes(prefixes) }
 
+// SetPrefixStyle selects a built-in prefix set on the [Default] logger.
+func SetPrefixStyle(style PrefixStyle) { Default.SetPrefixStyle(style) }
+
+// SetQuantityColumnWidth sets the quantity column width on the [Default] logger.
+func SetQuantityColumnWidth(n int) { Default.SetQuantityColumnWidth(n) }
+
+// SetQuantityThousandsSep sets the quantity digit grouping character on the [Default] logger.
+func SetQuantityThousandsSep(sep rune) { Default.SetQuantityThousandsSep(sep) }
+
 // SetQuantityUnitsIgnoreCase sets case-insensitive quantity unit matching on the [Default] logger.
 func SetQuantityUnitsIgnoreCase(ignoreCase bool) { Default.SetQuantityUnitsIgnoreCase(ignoreCase) }
 
@@ -1007,12 +2533,27 @@ func SetQuoteChars(openChar, closeChar rune) { Default.SetQuoteChars(openChar, c
 // SetQuoteMode sets the quoting behaviour on the [Default] logger.
 func SetQuoteMode(mode QuoteMode) { Default.SetQuoteMode(mode) }
 
+// SetReportGoroutine enables or disables goroutine ids on the [Default] logger.
+func SetReportGoroutine(report bool) { Default.SetReportGoroutine(report) }
+
 // SetReportTimestamp enables or disables timestamps on the [Default] logger.
 func SetReportTimestamp(report bool) { Default.SetReportTimestamp(report) }
 
+// SetSanitizeControl sets control-character escaping on the [Default] logger.
+func SetSanitizeControl(enabled bool) { Default.SetSanitizeControl(enabled) }
+
+// SetScrapeToken sets the line token function on the [Default] logger.
+func SetScrapeToken(fn func(Entry) string) { Default.SetScrapeToken(fn) }
+
+// SetSectionRule sets whether sections draw a rule on the [Default] logger.
+func SetSectionRule(enabled bool) { Default.SetSectionRule(enabled) }
+
 // SetSeparatorText sets the key/value separator on the [Default] logger.
 func SetSeparatorText(sep string) { Default.SetSeparatorText(sep) }
 
+// SetStatsPrecision sets the stats precision on the [Default] logger.
+func SetStatsPrecision(precision int) { Default.SetStatsPrecision(precision) }
+
 // SetStyles sets the display styles on the [Default] logger.
 func SetStyles(styles *Styles) { Default.SetStyles(styles) }
 
@@ -1022,6 +2563,23 @@ func SetTimeFormat(format string) { Default.SetTimeFormat(format) }
 // SetTimeLocation sets the timestamp timezone on the [Default] logger.
 func SetTimeLocation(loc *time.Location) { Default.SetTimeLocation(loc) }
 
+// SetTimestampGradient sets the timestamp gradient on the [Default] logger.
+func SetTimestampGradient(stops []ColorStop, window time.Duration) {
+	Default.SetTimestampGradient(stops, window)
+}
+
+// SetTimestampPreset sets the timestamp format preset on the [Default] logger.
+func SetTimestampPreset(preset TimestampPreset) { Default.SetTimestampPreset(preset) }
+
+// SetTreeIndent sets the per-depth [Tree] indentation on the [Default] logger.
+func SetTreeIndent(indent string) { Default.SetTreeIndent(indent) }
+
+// SetWrapSlices sets whether slice fields wrap on the [Default] logger.
+func SetWrapSlices(enabled bool) { Default.SetWrapSlices(enabled) }
+
+// UseHandlerMiddleware wraps the handler of the [Default] logger.
+func UseHandlerMiddleware(mw ...HandlerMiddleware) { Default.UseHandlerMiddleware(mw...) }
+
 // Ctx retrieves the logger from ctx. Returns [Default] if ctx is nil
 // or contains no logger.
 func Ctx(ctx context.Context) *Logger {
@@ -1039,6 +2597,27 @@ func WithContext(ctx context.Context) context.Context {
 	return Default.WithContext(ctx)
 }
 
+// LevelContext returns a copy of ctx carrying a sub-logger of [Ctx](ctx)
+// whose level is lowered to level, enabling more verbose logging for code
+// that retrieves its logger from the returned context. The logger in ctx is
+// unaffected, and a logger that is already more verbose keeps its level.
+func LevelContext(ctx context.Context, level Level) context.Context {
+	l := Ctx(ctx)
+	return l.With().Level(min(l.Level(), level)).Logger().WithContext(ctx)
+}
+
+// DebugContext returns a copy of ctx whose logger logs at debug level.
+// See [LevelContext].
+func DebugContext(ctx context.Context) context.Context {
+	return LevelContext(ctx, DebugLevel)
+}
+
+// TraceContext returns a copy of ctx whose logger logs at trace level.
+// See [LevelContext].
+func TraceContext(ctx context.Context) context.Context {
+	return LevelContext(ctx, TraceLevel)
+}
+
 // With returns a [Context] for building a sub-logger from the [Default] logger.
 func With() *Context { return Default.With() }
 
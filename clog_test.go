@@ -3,13 +3,18 @@ package clog
 import (
 	"bytes"
 	"context"
+	"database/sql"
+	"errors"
 	"io"
+	"maps"
 	"strconv"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lucasb-eyer/go-colorful"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -413,6 +418,9 @@ func TestPackageLevelSetters(t *testing.T) {
 	SetTimeFormat("2006-01-02")
 	assert.Equal(t, "2006-01-02", Default.timeFormat)
 
+	SetTimestampPreset(TimestampRFC3339)
+	assert.Equal(t, time.RFC3339, Default.timeFormat)
+
 	h := HandlerFunc(func(_ Entry) {})
 	SetHandler(h)
 	assert.NotNil(t, Default.handler)
@@ -572,6 +580,25 @@ func TestLogFormattedOutputEmptyPrefix(t *testing.T) {
 	assert.Equal(t, "INF hello\n", buf.String())
 }
 
+func TestSetPrefixPlaceholderWhenEmpty(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetPrefixPlaceholderWhenEmpty(true)
+
+	l.Info().Msg("with")
+	l.Info().Prefix("").Msg("without")
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+
+	msgColumn := func(line, msg string) int {
+		return lipgloss.Width(line[:strings.Index(line, msg)])
+	}
+	assert.Equal(t, msgColumn(lines[0], "with"), msgColumn(lines[1], "without"))
+	assert.Equal(t, "INF    without", lines[1])
+}
+
 func TestLogFormattedOutputWithTimestamp(t *testing.T) {
 	var buf bytes.Buffer
 
@@ -641,6 +668,56 @@ func TestLoadLogLevelFromEnvUnrecognised(t *testing.T) {
 	assert.Equal(t, InfoLevel, Default.level)
 }
 
+func TestSetLeadingTagField(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetLeadingTagField("tenant")
+	l.Info().Str("tenant", "tenant-a").Int("n", 1).Msg("msg")
+	l.Info().Int("n", 2).Msg("untagged")
+
+	assert.Equal(t, "[tenant-a] INF ℹ️ msg n=1\nINF ℹ️ untagged n=2\n", buf.String())
+}
+
+func TestSetLeadingTagFieldContext(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetLeadingTagField("tenant")
+	l.SetReportTimestamp(true)
+	l.nowFunc = func() time.Time { return testLoggerTime }
+	l.SetTimeFormat("15:04")
+
+	sub := l.With().Str("tenant", "tenant-b").Logger()
+	sub.Warn().Msg("msg")
+
+	assert.Equal(t, "[tenant-b] "+testLoggerTime.Format("15:04")+" WRN ⚠️ msg\n", buf.String())
+}
+
+func TestSetLeadingTagFieldStyled(t *testing.T) {
+	withTrueColor(t)
+
+	var buf bytes.Buffer
+
+	l := New(NewOutput(&buf, ColorAlways))
+	l.SetParts(PartMessage)
+	l.SetLeadingTagField("tenant")
+	l.Info().Str("tenant", "a").Msg("msg")
+
+	assert.Equal(t, l.styles.LeadingTag.Render("[a]")+" msg\n", buf.String())
+}
+
+func TestSetLeadingTagFieldHandler(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := NewWriter(io.Discard)
+	l.SetLeadingTagField("tenant")
+	l.SetHandler(NewJSONHandler(&buf, nil))
+	l.Info().Str("tenant", "a").Msg("msg")
+
+	assert.Contains(t, buf.String(), `"tenant":"a"`)
+}
+
 func TestSetLevelLabels(t *testing.T) {
 	l := NewWriter(io.Discard)
 	l.SetLevelLabels(LevelMap{WarnLevel: "WARN"}) //nolint:exhaustive // intentionally partial
@@ -657,6 +734,62 @@ func TestSetLevelAlign(t *testing.T) {
 	assert.Equal(t, AlignLeft, l.levelAlign)
 }
 
+func TestSetLevelBadge(t *testing.T) {
+	withTrueColor(t)
+
+	var buf bytes.Buffer
+
+	l := New(NewOutput(&buf, ColorAlways))
+	l.SetParts(PartLevel, PartMessage)
+	l.SetLevelBadge(true)
+	l.Error().Msg("failed")
+
+	badge := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("0")).Background(lipgloss.Color("1"))
+	want := badge.Render(" ERR ")
+	assert.Contains(t, want, ";41m", "badge should use a red background")
+	assert.Equal(t, want+" failed\n", buf.String())
+}
+
+func TestSetLevelBadgeCustomStyle(t *testing.T) {
+	withTrueColor(t)
+
+	var buf bytes.Buffer
+
+	custom := new(lipgloss.NewStyle().Background(lipgloss.Color("4")))
+	styles := DefaultStyles()
+	styles.LevelBadges = LevelStyleMap{InfoLevel: custom}
+
+	l := New(NewOutput(&buf, ColorAlways))
+	l.SetStyles(styles)
+	l.SetParts(PartLevel)
+	l.SetLevelBadge(true)
+	l.Info().Send()
+
+	assert.Equal(t, custom.Render(" INF ")+"\n", buf.String())
+}
+
+func TestSetLevelBadgeAligned(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartLevel, PartMessage)
+	l.SetLevelLabels(LevelMap{InfoLevel: "INFO", ErrorLevel: "ERROR"}) //nolint:exhaustive // intentionally partial
+	l.SetLevelAlign(AlignLeft)
+	l.SetLevelBadge(true)
+	l.Info().Msg("a")
+	l.Error().Msg("b")
+
+	// Without colours, the plain (aligned) label is rendered.
+	assert.Equal(t, "INFO  a\nERROR b\n", buf.String())
+
+	// With colours, every label gains the same padding.
+	assert.Equal(
+		t,
+		lipgloss.Width(styledLevel(l.formatLabel(InfoLevel), InfoLevel, l.styles, true, false)),
+		lipgloss.Width(styledLevel(l.formatLabel(ErrorLevel), ErrorLevel, l.styles, true, false)),
+	)
+}
+
 func TestFormatLabelAlignNone(t *testing.T) {
 	l := NewWriter(io.Discard)
 	l.SetLevelAlign(AlignNone)
@@ -733,6 +866,36 @@ func TestPackageLevelSetPrefixes(t *testing.T) {
 	assert.Equal(t, ">>>", Default.prefixes[InfoLevel])
 }
 
+func TestSetPrefixStyle(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartPrefix, PartMessage)
+
+	l.SetPrefixStyle(PrefixASCII)
+	l.Info().Msg("ascii")
+	l.Warn().Msg("ascii")
+
+	l.SetPrefixStyle(PrefixNerdFont)
+	l.Info().Msg("nerd")
+
+	l.SetPrefixStyle(PrefixEmoji)
+	l.Info().Msg("emoji")
+
+	want := "[*] ascii\n" +
+		"[!] ascii\n" +
+		"\uf05a nerd\n" +
+		"ℹ️ emoji\n"
+	assert.Equal(t, want, buf.String())
+}
+
+func TestPrefixSetsCoverAllLevels(t *testing.T) {
+	for level := range defaultPrefixes {
+		assert.NotEmpty(t, ASCIIPrefixes()[level], level.String())
+		assert.NotEmpty(t, NerdFontPrefixes()[level], level.String())
+	}
+}
+
 func TestSetTimeLocation(t *testing.T) {
 	l := NewWriter(io.Discard)
 	loc := time.UTC
@@ -1016,6 +1179,60 @@ func TestDefaultParts(t *testing.T) {
 	assert.Equal(t, PartTimestamp, order2[0])
 }
 
+func TestStylesOverlay(t *testing.T) {
+	base := DefaultStyles()
+	bold := new(lipgloss.NewStyle().Bold(true))
+
+	got := base.Overlay(&Styles{Messages: LevelStyleMap{ErrorLevel: bold}})
+
+	want := *base
+	want.Messages = maps.Clone(base.Messages)
+	want.Messages[ErrorLevel] = bold
+	assert.Equal(t, &want, got)
+	assert.Same(t, bold, got.Messages[ErrorLevel])
+	assert.Same(t, base.Messages[WarnLevel], got.Messages[WarnLevel])
+	assert.Same(t, base.FieldString, got.FieldString)
+
+	// The base is left untouched and shares no maps with the result.
+	assert.NotSame(t, bold, base.Messages[ErrorLevel])
+	got.Keys["path"] = bold
+	assert.NotContains(t, base.Keys, "path")
+}
+
+func TestStylesOverlayMerge(t *testing.T) {
+	red := new(lipgloss.NewStyle().Foreground(lipgloss.Color("1")))
+	blue := new(lipgloss.NewStyle().Foreground(lipgloss.Color("4")))
+
+	base := &Styles{
+		FieldString: red,
+		Keys:        StyleMap{"host": red, "path": red},
+		Values:      ValueStyleMap{true: red},
+	}
+	got := base.Overlay(&Styles{
+		FieldString:     blue,
+		Keys:            StyleMap{"path": blue},
+		PercentGradient: []ColorStop{{Position: 0}},
+	})
+
+	assert.Same(t, blue, got.FieldString)
+	assert.Equal(t, StyleMap{"host": red, "path": blue}, got.Keys)
+	assert.Equal(t, ValueStyleMap{true: red}, got.Values)
+	assert.Len(t, got.PercentGradient, 1)
+	assert.Nil(t, got.Levels)
+}
+
+func TestStylesOverlayNil(t *testing.T) {
+	blue := new(lipgloss.NewStyle().Foreground(lipgloss.Color("4")))
+
+	var base *Styles
+	got := base.Overlay(&Styles{FieldString: blue})
+	assert.Same(t, blue, got.FieldString)
+
+	styles := DefaultStyles()
+	assert.Equal(t, styles, styles.Overlay(nil))
+	assert.NotSame(t, styles, styles.Overlay(nil))
+}
+
 func TestPerLevelMessageStyle(t *testing.T) {
 	t.Run("uses_per_level_style", func(t *testing.T) {
 		var buf bytes.Buffer
@@ -1054,6 +1271,150 @@ func TestSubLoggerInheritsPartOrder(t *testing.T) {
 	assert.Equal(t, "hello INF k=v\n", buf.String())
 }
 
+func TestNumberGroupingDisabledByDefault(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Info().Int("request_id", 9876543210).Msg("done")
+
+	assert.Equal(t, "INF ℹ️ done request_id=9876543210\n", buf.String())
+}
+
+func TestNumberGrouping(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetNumberGrouping(',')
+	l.Info().
+		Int("request_id", 9876543210).
+		Int64("delta", -1234567).
+		Uint("small", 999).
+		Float64("ratio", 12345.678).
+		Ints("counts", []int{1000, -20000, 3}).
+		Str("id", "9876543210").
+		Msg("done")
+
+	assert.Equal(
+		t,
+		"INF ℹ️ done request_id=9,876,543,210 delta=-1,234,567 small=999 ratio=12,345.678"+
+			" counts=[1,000, -20,000, 3] id=9876543210\n",
+		buf.String(),
+	)
+}
+
+func TestNumberGroupingStyled(t *testing.T) {
+	withTrueColor(t)
+
+	styles := DefaultStyles()
+	opts := formatFieldsOpts{
+		level:          InfoLevel,
+		numberGrouping: '_',
+		styles:         styles,
+	}
+
+	n := styles.FieldNumber.Render
+	got := formatFields([]Field{
+		{Key: "n", Value: 1234567},
+		{Key: "ns", Value: []uint64{1000, 10}},
+	}, opts)
+
+	want := " " + styles.KeyDefault.Render("n") + styles.Separator.Render("=") + n("1_234_567") +
+		" " + styles.KeyDefault.Render("ns") + styles.Separator.Render("=") +
+		"[" + n("1_000") + ", " + n("10") + "]"
+	assert.Equal(t, want, got)
+}
+
+func TestSetNumberThresholds(t *testing.T) {
+	withTrueColor(t)
+
+	var buf bytes.Buffer
+
+	red := new(lipgloss.NewStyle().Foreground(lipgloss.Color("1")))
+	amber := new(lipgloss.NewStyle().Foreground(lipgloss.Color("3")))
+	styles := DefaultStyles()
+
+	l := New(NewOutput(&buf, ColorAlways))
+	l.SetParts(PartFields)
+	l.SetNumberThresholds("errors", []Threshold{
+		{Value: 100, Style: ThresholdStyle{Number: red}},
+		{Value: 10, Style: ThresholdStyle{Number: amber}},
+	})
+
+	kv := func(v string) string {
+		return styles.KeyDefault.Render("errors") + styles.Separator.Render("=") + v + "\n"
+	}
+
+	l.Info().Int("errors", 150).Send()
+	assert.Equal(t, kv(red.Render("150")), buf.String())
+
+	buf.Reset()
+	l.Info().Float64("errors", 10.5).Send()
+	assert.Equal(t, kv(amber.Render("10.5")), buf.String())
+
+	buf.Reset()
+	l.Info().Int("errors", 5).Send()
+	assert.Equal(t, kv(styles.FieldNumber.Render("5")), buf.String())
+
+	// Other keys are unaffected.
+	buf.Reset()
+	l.Info().Int("warnings", 150).Send()
+	assert.Equal(
+		t,
+		styles.KeyDefault.Render("warnings")+styles.Separator.Render("=")+styles.FieldNumber.Render("150")+"\n",
+		buf.String(),
+	)
+
+	// Empty thresholds remove the key.
+	buf.Reset()
+	l.SetNumberThresholds("errors", nil)
+	l.Info().Int("errors", 150).Send()
+	assert.Equal(t, kv(styles.FieldNumber.Render("150")), buf.String())
+}
+
+func TestSetNumberThresholdsKeyStylePriority(t *testing.T) {
+	withTrueColor(t)
+
+	var buf bytes.Buffer
+
+	red := new(lipgloss.NewStyle().Foreground(lipgloss.Color("1")))
+	blue := new(lipgloss.NewStyle().Foreground(lipgloss.Color("4")))
+	styles := DefaultStyles()
+	styles.Keys["errors"] = blue
+
+	l := New(NewOutput(&buf, ColorAlways))
+	l.SetStyles(styles)
+	l.SetParts(PartFields)
+	l.SetNumberThresholds("errors", []Threshold{{Value: 100, Style: ThresholdStyle{Number: red}}})
+	l.Info().Int("errors", 150).Send()
+
+	assert.Contains(t, buf.String(), blue.Render("150"))
+}
+
+func TestNumberThresholdsStyles(t *testing.T) {
+	withTrueColor(t)
+
+	var buf bytes.Buffer
+
+	red := new(lipgloss.NewStyle().Foreground(lipgloss.Color("1")))
+	styles := DefaultStyles().Overlay(&Styles{
+		NumberThresholds: ThresholdMap{
+			"errors": {{Value: 100, Style: ThresholdStyle{Number: red}}},
+		},
+	})
+
+	l := New(NewOutput(&buf, ColorAlways))
+	l.SetStyles(styles)
+	l.SetParts(PartFields)
+	l.Info().Int("errors", 150).Send()
+
+	assert.Contains(t, buf.String(), red.Render("150"))
+
+	// Setting thresholds copies the styles, leaving the theme untouched.
+	l.SetNumberThresholds("warnings", []Threshold{{Value: 1, Style: ThresholdStyle{Number: red}}})
+	assert.NotContains(t, styles.NumberThresholds, "warnings")
+	assert.Contains(t, l.styles.NumberThresholds, "errors")
+}
+
 func TestOmitEmptyDisabledByDefault(t *testing.T) {
 	l := NewWriter(io.Discard)
 	assert.False(t, l.omitEmpty)
@@ -1151,175 +1512,654 @@ func TestOmitZeroSupersedesOmitEmpty(t *testing.T) {
 	assert.Equal(t, "nonzero", got.Fields[0].Key)
 }
 
-func TestOmitEmptyFormattedOutput(t *testing.T) {
-	var buf bytes.Buffer
-
-	l := New(TestOutput(&buf))
-	l.SetOmitEmpty(true)
-	l.Info().Str("a", "").Str("b", "keep").Msg("test")
-
-	assert.Equal(t, "INF ℹ️ test b=keep\n", buf.String())
-}
-
-func TestOmitZeroFormattedOutput(t *testing.T) {
+func TestAutoColorKeysPlainWithoutColor(t *testing.T) {
 	var buf bytes.Buffer
 
 	l := New(TestOutput(&buf))
-	l.SetOmitZero(true)
-	l.Info().Int("a", 0).Int("b", 1).Msg("test")
+	l.SetAutoColorKeys("host")
+	l.Info().Str("host", "web-1").Msg("up")
 
-	assert.Equal(t, "INF ℹ️ test b=1\n", buf.String())
+	assert.Equal(t, "INF ℹ️ up host=web-1\n", buf.String())
 }
 
-func TestSubLoggerInheritsOmitSettings(t *testing.T) {
+func TestSetAutoColorKeys(t *testing.T) {
 	l := NewWriter(io.Discard)
-	l.SetOmitEmpty(true)
-	l.SetOmitZero(true)
+	l.SetAutoColorKeys("host", "request_id")
+	assert.Equal(t, map[string]bool{"host": true, "request_id": true}, l.autoColorKeys)
 
-	sub := l.With().Str("k", "v").Logger()
-
-	assert.True(t, sub.omitEmpty)
-	assert.True(t, sub.omitZero)
+	l.SetAutoColorKeys()
+	assert.Nil(t, l.autoColorKeys)
 }
 
-func TestPackageLevelSetOmitEmpty(t *testing.T) {
-	origDefault := Default
-	defer func() { Default = origDefault }()
+func TestHighlightMessageJSON(t *testing.T) {
+	withTrueColor(t)
 
-	Default = NewWriter(io.Discard)
-	SetOmitEmpty(true)
+	var buf bytes.Buffer
 
-	assert.True(t, Default.omitEmpty)
+	l := New(NewTestOutputColor(&buf, 80))
+	l.SetParts(PartMessage)
+	l.SetHighlightMessageJSON(true)
+	l.Info().Msg(`response: {"ok":true}`)
+
+	styles := DefaultStyles()
+	want := "response: " + highlightJSON(`{"ok":true}`, styles.FieldJSON) + "\n"
+	assert.Equal(t, want, buf.String())
+	assert.Contains(t, buf.String(), "\x1b[")
 }
 
-func TestPackageLevelSetOmitZero(t *testing.T) {
-	origDefault := Default
-	defer func() { Default = origDefault }()
+func TestHighlightMessageJSONDisabled(t *testing.T) {
+	withTrueColor(t)
 
-	Default = NewWriter(io.Discard)
-	SetOmitZero(true)
+	var buf bytes.Buffer
 
-	assert.True(t, Default.omitZero)
-}
+	l := New(NewTestOutputColor(&buf, 80))
+	l.SetParts(PartMessage)
+	l.Info().Msg(`response: {"ok":true}`)
 
-func TestOmitQuotesDisabledByDefault(t *testing.T) {
-	l := NewWriter(io.Discard)
-	assert.Equal(t, QuoteAuto, l.quoteMode)
+	assert.Equal(t, "response: {\"ok\":true}\n", buf.String())
 }
 
-func TestQuoteChar(t *testing.T) {
+func TestHighlightMessageJSONNoColor(t *testing.T) {
 	var buf bytes.Buffer
 
 	l := New(TestOutput(&buf))
-	l.SetQuoteChar('\'')
-	l.Info().Str("msg", "hello world").Msg("test")
+	l.SetHighlightMessageJSON(true)
+	l.Info().Msg(`response: {"ok": true}`)
 
-	assert.Equal(t, "INF ℹ️ test msg='hello world'\n", buf.String())
+	assert.Equal(t, "INF ℹ️ response: {\"ok\": true}\n", buf.String())
 }
 
-func TestQuoteCharInStringSlice(t *testing.T) {
+func TestFieldKeyAlign(t *testing.T) {
 	var buf bytes.Buffer
 
 	l := New(TestOutput(&buf))
-	l.SetQuoteChar('\'')
-	l.Info().Strs("args", []string{"hello world", "ok"}).Msg("test")
+	l.SetFieldKeyAlign(true)
+	l.Info().Int("id", 1).Str("name", "api").Str("status", "ok").Msg("summary")
+	l.Info().Int("n", 2).Msg("next")
 
-	assert.Equal(t, "INF ℹ️ test args=['hello world', ok]\n", buf.String())
+	assert.Equal(t, "INF ℹ️ summary id    =1 name  =api status=ok\nINF ℹ️ next n=2\n", buf.String())
 }
 
-func TestQuoteCharInAnySlice(t *testing.T) {
-	var buf bytes.Buffer
+func TestFieldKeyAlignIgnoresANSI(t *testing.T) {
+	withTrueColor(t)
 
-	l := New(TestOutput(&buf))
-	l.SetQuoteChar('\'')
-	l.Info().Anys("vals", []any{"hello world", 1}).Msg("test")
+	styles := DefaultStyles()
+	opts := formatFieldsOpts{
+		fieldKeyAlign: true,
+		level:         InfoLevel,
+		styles:        styles,
+	}
 
-	assert.Equal(t, "INF ℹ️ test vals=['hello world', 1]\n", buf.String())
+	got := formatFields([]Field{{Key: "a", Value: 1}, {Key: "abc", Value: 2}}, opts)
+
+	require.Contains(t, got, "\x1b[")
+	want := " " + styles.KeyDefault.Render("a") + "  " + styles.Separator.Render("=") +
+		styles.FieldNumber.Render("1") +
+		" " + styles.KeyDefault.Render("abc") + styles.Separator.Render("=") +
+		styles.FieldNumber.Render("2")
+	assert.Equal(t, want, got)
 }
 
-func TestQuoteCharDefaultUsesStrconvQuote(t *testing.T) {
+func TestSetMessageTruncate(t *testing.T) {
 	var buf bytes.Buffer
 
 	l := New(TestOutput(&buf))
-	// Default quoteChar (0) should use strconv.Quote with escaping.
-	l.Info().Str("msg", "hello world").Msg("test")
+	l.SetParts(PartMessage, PartFields)
+	l.SetMessageTruncate(20, TruncateMiddle)
+	l.Info().Str("k", "v").Msg("Deploying release for request req-0042")
 
-	assert.Equal(t, "INF ℹ️ test msg=\"hello world\"\n", buf.String())
-}
-
-func TestPackageLevelSetQuoteChar(t *testing.T) {
-	origDefault := Default
-	defer func() { Default = origDefault }()
+	assert.Equal(t, "Deploying … req-0042 k=v\n", buf.String())
 
-	Default = NewWriter(io.Discard)
-	SetQuoteChar('\'')
+	buf.Reset()
+	l.SetMessageTruncate(20, TruncateEnd)
+	l.Info().Msg("Deploying release for request req-0042")
+	assert.Equal(t, "Deploying release f…\n", buf.String())
 
-	assert.Equal(t, '\'', Default.quoteOpen)
-	assert.Equal(t, '\'', Default.quoteClose)
+	buf.Reset()
+	l.SetMessageTruncate(0, TruncateEnd)
+	l.Info().Msg("Deploying release for request req-0042")
+	assert.Equal(t, "Deploying release for request req-0042\n", buf.String())
 }
 
-func TestQuoteChars(t *testing.T) {
+func TestMaxLineLenDropsLowestPriority(t *testing.T) {
 	var buf bytes.Buffer
 
 	l := New(TestOutput(&buf))
-	l.SetQuoteChars('[', ']')
-	l.Info().Str("msg", "hello world").Msg("test")
+	l.SetParts(PartLevel, PartMessage, PartFields)
+	l.SetMaxLineLen(40)
+	l.SetFieldPriority("user", 10)
+	l.SetFieldPriority("trace", -1)
+	l.Info().
+		Str("user", "alice").
+		Str("trace", "0123456789abcdef").
+		Str("path", "/api").
+		Msg("request")
 
-	assert.Equal(t, "INF ℹ️ test msg=[hello world]\n", buf.String())
+	// "INF request user=alice trace=0123456789abcdef path=/api" is 56 wide;
+	// dropping the lowest-priority trace field makes it fit.
+	assert.Equal(t, "INF request user=alice path=/api …+1\n", buf.String())
 }
 
-func TestQuoteCharsInStringSlice(t *testing.T) {
+func TestMaxLineLenDropsRightmostOnTies(t *testing.T) {
 	var buf bytes.Buffer
 
 	l := New(TestOutput(&buf))
-	l.SetQuoteChars('«', '»')
-	l.Info().Strs("args", []string{"hello world", "ok"}).Msg("test")
+	l.SetParts(PartMessage, PartFields)
+	l.SetMaxLineLen(12)
+	l.Info().Int("a", 1).Int("b", 2).Int("c", 3).Msg("msg")
 
-	assert.Equal(t, "INF ℹ️ test args=[«hello world», ok]\n", buf.String())
+	assert.Equal(t, "msg a=1 …+2\n", buf.String())
 }
 
-func TestPackageLevelSetQuoteChars(t *testing.T) {
-	origDefault := Default
-	defer func() { Default = origDefault }()
+func TestMaxLineLenKeepsMessage(t *testing.T) {
+	var buf bytes.Buffer
 
-	Default = NewWriter(io.Discard)
-	SetQuoteChars('[', ']')
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage, PartFields)
+	l.SetMaxLineLen(5)
+	l.Info().Int("a", 1).Msg("a long message")
 
-	assert.Equal(t, '[', Default.quoteOpen)
-	assert.Equal(t, ']', Default.quoteClose)
+	assert.Equal(t, "a long message …+1\n", buf.String())
 }
 
-func TestQuoteModeAuto(t *testing.T) {
+func TestMaxLineLenFits(t *testing.T) {
 	var buf bytes.Buffer
 
 	l := New(TestOutput(&buf))
-	// QuoteAuto is the default — simple strings unquoted, spaced strings quoted.
-	l.Info().Str("simple", "timeout").Str("spaced", "hello world").Msg("test")
+	l.SetMaxLineLen(80)
+	l.Info().Int("a", 1).Msg("short")
 
-	assert.Equal(t, "INF ℹ️ test simple=timeout spaced=\"hello world\"\n", buf.String())
+	assert.Equal(t, "INF ℹ️ short a=1\n", buf.String())
 }
 
-func TestQuoteModeAlways(t *testing.T) {
+func TestMaxLineLenIgnoresANSI(t *testing.T) {
+	withTrueColor(t)
+
 	var buf bytes.Buffer
 
-	l := New(TestOutput(&buf))
-	l.SetQuoteMode(QuoteAlways)
-	l.Info().Str("reason", "timeout").Msg("test")
+	l := New(NewTestOutputColor(&buf, 80))
+	l.SetParts(PartMessage, PartFields)
+	l.SetMaxLineLen(len("msg a=1 b=2"))
+	l.Info().Int("a", 1).Int("b", 2).Msg("msg")
 
-	assert.Equal(t, "INF ℹ️ test reason=\"timeout\"\n", buf.String())
+	assert.NotContains(t, StripANSI(buf.String()), "…")
+	assert.Equal(t, "msg a=1 b=2\n", StripANSI(buf.String()))
 }
 
-func TestQuoteModeNever(t *testing.T) {
+func TestSanitizeControl(t *testing.T) {
 	var buf bytes.Buffer
 
 	l := New(TestOutput(&buf))
 	l.SetQuoteMode(QuoteNever)
-	l.Info().Str("msg", "hello world").Msg("test")
+	l.SetSanitizeControl(true)
+	l.Info().
+		Str("input", "\x1b[2J").
+		StrNote("name", "\x1b[31mred", "user").
+		Msg("got \x1b[2J")
 
-	assert.Equal(t, "INF ℹ️ test msg=hello world\n", buf.String())
+	got := buf.String()
+	assert.NotContains(t, got, "\x1b")
+	assert.Equal(t, `INF ℹ️ got \x1b[2J input=\x1b[2J name=\x1b[31mred (user)`+"\n", got)
 }
 
-func TestQuoteModeAlwaysInStringSlice(t *testing.T) {
+func TestSanitizeControlErrorAndPrefix(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetQuoteMode(QuoteNever)
+	l.SetSanitizeControl(true)
+
+	cause := errors.New("bad \x1b[2Jinput")
+	var got Entry
+	l.SetHandler(HandlerFunc(func(e Entry) { got = e }))
+	l.Error().Prefix("\x1b]0;title\a>").Err(cause).Msg("failed")
+
+	assert.Equal(t, `\x1b]0;title\x07>`, got.Prefix)
+	require.Len(t, got.Fields, 1)
+	err, ok := got.Fields[0].Value.(error)
+	require.True(t, ok, "the value stays an error")
+	assert.Equal(t, `bad \x1b[2Jinput`, err.Error())
+	assert.ErrorIs(t, err, cause)
+
+	l.SetHandler(nil)
+	l.Error().Prefix("\x1b[2J>").Err(cause).Msg("failed")
+
+	assert.Equal(t, `ERR \x1b[2J> failed error=bad \x1b[2Jinput`+"\n", buf.String())
+}
+
+func TestSanitizeControlWrappedValues(t *testing.T) {
+	const bad = "\x1b[2J"
+
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetQuoteMode(QuoteNever)
+	l.SetSanitizeControl(true)
+	l.SetParts(PartFields)
+	l.Info().Diff("d", bad, "z").Send()
+	l.Info().Any("m", map[string]string{"k": bad}).Send()
+	l.Info().Anys("a", []any{1, bad}).Send()
+	l.Info().Cmd("c", "echo", bad).Send()
+	l.Info().ValidationErrors("errors", map[string]string{"p" + bad: bad}).Send()
+
+	got := buf.String()
+	assert.NotContains(t, got, "\x1b")
+	assert.Equal(t, strings.Join([]string{
+		`d=\x1b[2J → z`,
+		`m=map[k:\x1b[2J]`,
+		`a=[1, \x1b[2J]`,
+		`c=echo '\x1b[2J'`,
+		`errors=[p\x1b[2J: \x1b[2J]`,
+	}, "\n")+"\n", got)
+}
+
+func TestSanitizeControlDisabled(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetQuoteMode(QuoteNever)
+	l.Info().Str("input", "\x1b[2J").Msg("msg")
+
+	assert.Equal(t, "INF ℹ️ msg input=\x1b[2J\n", buf.String())
+}
+
+func TestSanitizeControlKeepsStyling(t *testing.T) {
+	withTrueColor(t)
+
+	var buf bytes.Buffer
+
+	l := New(NewTestOutputColor(&buf, 80))
+	l.SetSanitizeControl(true)
+	l.Info().Str("input", "\x1b[2J").Msg("msg")
+
+	got := buf.String()
+	assert.Contains(t, got, "\x1b[", "clog's own styling is kept")
+	assert.NotContains(t, got, "\x1b[2J")
+}
+
+func TestOmitPredicate(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetOmitPredicate(func(f Field) bool { return f.Value == "N/A" })
+	l.Info().Str("a", "N/A").Str("b", "").Int("c", 0).Str("d", "keep").Msg("test")
+
+	assert.Equal(t, "INF ℹ️ test b= c=0 d=keep\n", buf.String())
+}
+
+func TestOmitPredicateOverridesOmitEmpty(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetOmitEmpty(true)
+	l.SetOmitPredicate(func(f Field) bool { return f.Key == "drop" })
+	l.Info().Str("drop", "x").Str("empty", "").Msg("test")
+
+	// The predicate replaces the OmitEmpty check; nil restores it.
+	l.SetOmitPredicate(nil)
+	l.Info().Str("drop", "x").Str("empty", "").Msg("test")
+
+	assert.Equal(t, "INF ℹ️ test empty=\nINF ℹ️ test drop=x\n", buf.String())
+}
+
+func TestOmitZeroClearsOmitPredicate(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetOmitPredicate(func(Field) bool { return true })
+	l.SetOmitZero(true)
+	l.Info().Int("zero", 0).Int("one", 1).Msg("test")
+
+	assert.Equal(t, "INF ℹ️ test one=1\n", buf.String())
+}
+
+func TestOmitPredicateDoesNotMutateContextFields(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	sub := l.With().Str("a", "N/A").Str("b", "keep").Logger()
+	sub.SetOmitPredicate(func(f Field) bool { return f.Value == "N/A" })
+	sub.Info().Msg("first")
+	sub.SetOmitPredicate(nil)
+	sub.Info().Msg("second")
+
+	assert.Equal(t, "INF ℹ️ first b=keep\nINF ℹ️ second a=N/A b=keep\n", buf.String())
+}
+
+func TestOmitEmptyFormattedOutput(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetOmitEmpty(true)
+	l.Info().Str("a", "").Str("b", "keep").Msg("test")
+
+	assert.Equal(t, "INF ℹ️ test b=keep\n", buf.String())
+}
+
+func TestOmitZeroFormattedOutput(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetOmitZero(true)
+	l.Info().Int("a", 0).Int("b", 1).Msg("test")
+
+	assert.Equal(t, "INF ℹ️ test b=1\n", buf.String())
+}
+
+func TestSetLevelRule(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetLevelRule(ErrorLevel, RuleBefore)
+	l.Info().Msg("ok")
+	l.Error().Msg("failed")
+
+	// Not a terminal: the rule is drawn with dashes, matching the entry width.
+	assert.Equal(t, "INF ℹ️ ok\n-------------\nERR ❌ failed\n", buf.String())
+}
+
+func TestSetLevelRulePositions(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage)
+	l.SetLevelRule(WarnLevel, RuleAfter)
+	l.SetLevelRule(ErrorLevel, RuleBoth)
+	l.Warn().Msg("warn")
+	l.Error().Msg("err")
+
+	assert.Equal(t, "warn\n----\n---\nerr\n---\n", buf.String())
+
+	buf.Reset()
+	l.SetLevelRule(ErrorLevel, RuleNone)
+	l.Error().Msg("err")
+
+	assert.Equal(t, "err\n", buf.String())
+}
+
+func TestSetLevelRuleHandlers(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage, PartFields)
+	l.SetLevelRule(ErrorLevel, RuleBefore)
+	l.UseHandlerMiddleware(FieldInjector(Field{Key: "h", Value: 1}))
+	l.Error().Msg("boom")
+
+	assert.Equal(t, "--------\nboom h=1\n", buf.String())
+
+	buf.Reset()
+	sink := NewWriter(io.Discard)
+	sink.SetHandler(PrettyHandler(l))
+	sink.Error().Msg("boom")
+
+	assert.Equal(t, "----\nboom\n", buf.String())
+	assert.Equal(t, "----\nboom", l.Render(Entry{Level: ErrorLevel, Message: "boom"}))
+}
+
+func TestSetLevelRuleTerminal(t *testing.T) {
+	withTrueColor(t)
+
+	var buf bytes.Buffer
+
+	l := New(NewTestOutputColor(&buf, 8))
+	l.SetParts(PartMessage)
+	l.SetLevelRule(ErrorLevel, RuleBefore)
+	l.Error().Msg("failed")
+
+	rule := DefaultStyles().Rule.Render("────────")
+	assert.True(t, strings.HasPrefix(buf.String(), rule+"\n"), buf.String())
+}
+
+func TestSetLevelForField(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartLevel, PartMessage, PartFields)
+	l.SetLevelForField("component", "db", DebugLevel)
+
+	l.Debug().Str("component", "db").Msg("query")
+	l.Debug().Str("component", "api").Msg("request")
+	l.Debug().Msg("plain")
+	l.Info().Str("component", "api").Msg("served")
+
+	assert.Equal(t, "DBG query component=db\nINF served component=api\n", buf.String())
+}
+
+func TestSetLevelForFieldContext(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartLevel, PartMessage)
+	l.SetLevelForField("component", "db", TraceLevel)
+	l.SetLevelForField("component", "noisy", WarnLevel)
+	l.SetLevelForField("shard", "3", DebugLevel)
+
+	db := l.With().Str("component", "db").Logger()
+	db.Trace().Msg("db trace")
+
+	noisy := l.With().Str("component", "noisy").Logger()
+	noisy.Info().Msg("noisy info")
+	noisy.Warn().Msg("noisy warn")
+
+	// Non-string values match by their formatted form.
+	l.Debug().Int("shard", 3).Msg("shard debug")
+
+	// The most verbose matching override wins.
+	noisy.Debug().Int("shard", 3).Msg("noisy shard debug")
+
+	want := "TRC db trace\n" +
+		"WRN noisy warn\n" +
+		"DBG shard debug\n" +
+		"DBG noisy shard debug\n"
+	assert.Equal(t, want, buf.String())
+}
+
+func TestSetLevelForFieldGate(t *testing.T) {
+	l := NewWriter(io.Discard)
+	assert.Equal(t, int32(InfoLevel), l.atomicLevel.Load())
+
+	l.SetLevelForField("component", "db", DebugLevel)
+	assert.Equal(t, int32(DebugLevel), l.atomicLevel.Load())
+	assert.Equal(t, InfoLevel, l.Level())
+
+	// Less verbose overrides do not lower the gate.
+	l.SetLevelForField("component", "noisy", ErrorLevel)
+	l.SetLevel(WarnLevel)
+	assert.Equal(t, int32(DebugLevel), l.atomicLevel.Load())
+
+	sub := l.With().Logger()
+	assert.Equal(t, int32(DebugLevel), sub.atomicLevel.Load())
+}
+
+func TestSQLNullValues(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage, PartFields)
+
+	l.Info().
+		Any("s", sql.NullString{String: "x", Valid: true}).
+		Any("n", sql.NullInt64{Int64: 42, Valid: true}).
+		Any("b", sql.NullBool{Bool: true, Valid: true}).
+		Any("g", sql.Null[float64]{V: 1.5, Valid: true}).
+		Any("missing", sql.NullString{}).
+		Msg("row")
+	assert.Equal(t, "row s=x n=42 b=true g=1.5 missing=<nil>\n", buf.String())
+
+	buf.Reset()
+	l.SetEmptyRepr("NULL", "")
+	l.Info().Any("missing", sql.NullInt32{}).Msg("row")
+	assert.Equal(t, "row missing=NULL\n", buf.String())
+
+	buf.Reset()
+	l.SetOmitEmpty(true)
+	l.Info().
+		Any("s", sql.NullString{String: "x", Valid: true}).
+		Any("missing", sql.NullString{}).
+		Any("blank", sql.NullString{Valid: true}).
+		Msg("row")
+	assert.Equal(t, "row s=x\n", buf.String())
+
+	buf.Reset()
+	l.SetOmitEmpty(false)
+	l.SetOmitZero(true)
+	l.Info().
+		Any("n", sql.NullInt64{Valid: true}).
+		Any("m", sql.NullInt64{Int64: 1, Valid: true}).
+		Msg("row")
+	assert.Equal(t, "row m=1\n", buf.String())
+}
+
+func TestSubLoggerInheritsOmitSettings(t *testing.T) {
+	l := NewWriter(io.Discard)
+	l.SetOmitEmpty(true)
+	l.SetOmitZero(true)
+
+	sub := l.With().Str("k", "v").Logger()
+
+	assert.True(t, sub.omitEmpty)
+	assert.True(t, sub.omitZero)
+}
+
+func TestPackageLevelSetOmitEmpty(t *testing.T) {
+	origDefault := Default
+	defer func() { Default = origDefault }()
+
+	Default = NewWriter(io.Discard)
+	SetOmitEmpty(true)
+
+	assert.True(t, Default.omitEmpty)
+}
+
+func TestPackageLevelSetOmitZero(t *testing.T) {
+	origDefault := Default
+	defer func() { Default = origDefault }()
+
+	Default = NewWriter(io.Discard)
+	SetOmitZero(true)
+
+	assert.True(t, Default.omitZero)
+}
+
+func TestOmitQuotesDisabledByDefault(t *testing.T) {
+	l := NewWriter(io.Discard)
+	assert.Equal(t, QuoteAuto, l.quoteMode)
+}
+
+func TestQuoteChar(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetQuoteChar('\'')
+	l.Info().Str("msg", "hello world").Msg("test")
+
+	assert.Equal(t, "INF ℹ️ test msg='hello world'\n", buf.String())
+}
+
+func TestQuoteCharInStringSlice(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetQuoteChar('\'')
+	l.Info().Strs("args", []string{"hello world", "ok"}).Msg("test")
+
+	assert.Equal(t, "INF ℹ️ test args=['hello world', ok]\n", buf.String())
+}
+
+func TestQuoteCharInAnySlice(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetQuoteChar('\'')
+	l.Info().Anys("vals", []any{"hello world", 1}).Msg("test")
+
+	assert.Equal(t, "INF ℹ️ test vals=['hello world', 1]\n", buf.String())
+}
+
+func TestQuoteCharDefaultUsesStrconvQuote(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	// Default quoteChar (0) should use strconv.Quote with escaping.
+	l.Info().Str("msg", "hello world").Msg("test")
+
+	assert.Equal(t, "INF ℹ️ test msg=\"hello world\"\n", buf.String())
+}
+
+func TestPackageLevelSetQuoteChar(t *testing.T) {
+	origDefault := Default
+	defer func() { Default = origDefault }()
+
+	Default = NewWriter(io.Discard)
+	SetQuoteChar('\'')
+
+	assert.Equal(t, '\'', Default.quoteOpen)
+	assert.Equal(t, '\'', Default.quoteClose)
+}
+
+func TestQuoteChars(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetQuoteChars('[', ']')
+	l.Info().Str("msg", "hello world").Msg("test")
+
+	assert.Equal(t, "INF ℹ️ test msg=[hello world]\n", buf.String())
+}
+
+func TestQuoteCharsInStringSlice(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetQuoteChars('«', '»')
+	l.Info().Strs("args", []string{"hello world", "ok"}).Msg("test")
+
+	assert.Equal(t, "INF ℹ️ test args=[«hello world», ok]\n", buf.String())
+}
+
+func TestPackageLevelSetQuoteChars(t *testing.T) {
+	origDefault := Default
+	defer func() { Default = origDefault }()
+
+	Default = NewWriter(io.Discard)
+	SetQuoteChars('[', ']')
+
+	assert.Equal(t, '[', Default.quoteOpen)
+	assert.Equal(t, ']', Default.quoteClose)
+}
+
+func TestQuoteModeAuto(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	// QuoteAuto is the default — simple strings unquoted, spaced strings quoted.
+	l.Info().Str("simple", "timeout").Str("spaced", "hello world").Msg("test")
+
+	assert.Equal(t, "INF ℹ️ test simple=timeout spaced=\"hello world\"\n", buf.String())
+}
+
+func TestQuoteModeAlways(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetQuoteMode(QuoteAlways)
+	l.Info().Str("reason", "timeout").Msg("test")
+
+	assert.Equal(t, "INF ℹ️ test reason=\"timeout\"\n", buf.String())
+}
+
+func TestQuoteModeNever(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetQuoteMode(QuoteNever)
+	l.Info().Str("msg", "hello world").Msg("test")
+
+	assert.Equal(t, "INF ℹ️ test msg=hello world\n", buf.String())
+}
+
+func TestQuoteModeAlwaysInStringSlice(t *testing.T) {
 	var buf bytes.Buffer
 
 	l := New(TestOutput(&buf))
@@ -1371,6 +2211,64 @@ func TestSubLoggerInheritsFieldStyleLevel(t *testing.T) {
 	assert.Equal(t, TraceLevel, sub.fieldStyleLevel)
 }
 
+func TestSetAlwaysStyleFieldsAtOrAbove(t *testing.T) {
+	withTrueColor(t)
+
+	var buf bytes.Buffer
+
+	l := New(NewTestOutputColor(&buf, 80))
+	l.SetFieldStyleLevel(FatalLevel)
+	l.SetAlwaysStyleFieldsAtOrAbove(ErrorLevel)
+
+	styled := l.styles.FieldNumber.Render("1")
+
+	l.Info().Int("n", 1).Msg("info")
+	assert.NotContains(t, buf.String(), styled, "info fields stay plain")
+
+	buf.Reset()
+	l.Error().Int("n", 1).Msg("error")
+	assert.Contains(t, buf.String(), styled, "error fields are styled")
+}
+
+func TestSetAlwaysStyleFieldsAtOrAboveDefault(t *testing.T) {
+	l := NewWriter(io.Discard)
+	l.SetFieldStyleLevel(FatalLevel)
+
+	assert.Equal(t, FatalLevel+1, l.alwaysStyleFieldsLevel)
+	assert.Equal(t, FatalLevel, l.styleFieldsLevel())
+
+	sub := l.With().Logger()
+	l.SetAlwaysStyleFieldsAtOrAbove(WarnLevel)
+	assert.Equal(t, WarnLevel, l.styleFieldsLevel())
+	assert.Equal(t, FatalLevel, sub.styleFieldsLevel())
+}
+
+func TestPackageLevelSetAlwaysStyleFieldsAtOrAbove(t *testing.T) {
+	origDefault := Default
+	defer func() { Default = origDefault }()
+
+	Default = NewWriter(io.Discard)
+	SetAlwaysStyleFieldsAtOrAbove(ErrorLevel)
+
+	assert.Equal(t, ErrorLevel, Default.alwaysStyleFieldsLevel)
+}
+
+func TestSetPercentThresholds(t *testing.T) {
+	l := NewWriter(io.Discard)
+	sub := l.With().Logger()
+	bands := []PercentThreshold{{AtLeast: 90, Style: new(lipgloss.NewStyle())}}
+
+	l.SetPercentThresholds(bands)
+	bands[0].AtLeast = 0
+
+	require.Len(t, l.styles.PercentThresholds, 1)
+	assert.InDelta(t, 90, l.styles.PercentThresholds[0].AtLeast, 0)
+	assert.Empty(t, sub.styles.PercentThresholds, "other loggers keep their styles")
+
+	l.SetPercentThresholds(nil)
+	assert.Empty(t, l.styles.PercentThresholds)
+}
+
 func TestSetFieldTimeFormat(t *testing.T) {
 	l := NewWriter(io.Discard)
 
@@ -1458,6 +2356,98 @@ func TestConcurrentLogging(t *testing.T) {
 	assert.Len(t, lines, goroutines*iterations)
 }
 
+func TestRawWriterDoesNotInterleave(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	raw := l.RawWriter()
+
+	const iterations = 200
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for range iterations {
+			_, err := io.WriteString(raw, "=== banner ===\n")
+			assert.NoError(t, err)
+		}
+	})
+	wg.Go(func() {
+		for range iterations {
+			l.Info().Str("k", "v").Msg("log")
+		}
+	})
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2*iterations)
+	for _, line := range lines {
+		assert.Contains(t, []string{"=== banner ===", "INF ℹ️ log k=v"}, line)
+	}
+}
+
+func TestRawWriterFollowsSetOutput(t *testing.T) {
+	var first, second bytes.Buffer
+
+	l := New(TestOutput(&first))
+	raw := l.RawWriter()
+	_, _ = io.WriteString(raw, "\x1b[1mone\n")
+
+	l.SetOutput(TestOutput(&second))
+	_, _ = io.WriteString(raw, "two\n")
+
+	assert.Equal(t, "\x1b[1mone\n", first.String(), "bytes are written unchanged")
+	assert.Equal(t, "two\n", second.String())
+}
+
+func TestTap(t *testing.T) {
+	withTrueColor(t)
+
+	var buf, tap bytes.Buffer
+
+	l := New(NewTestOutputColor(&buf, 80))
+	l.Tap(&tap)
+	l.Info().Str("k", "v").Msg("hello")
+	l.With().Str("sub", "x").Logger().Warn().Msg("child")
+	_, _ = io.WriteString(l.RawWriter(), "raw\n")
+
+	assert.Contains(t, buf.String(), "\x1b[")
+	assert.Equal(t, buf.String(), tap.String())
+}
+
+func TestSetColorProfileANSI16(t *testing.T) {
+	withTrueColor(t)
+
+	var buf bytes.Buffer
+
+	styles := DefaultStyles()
+	styles.Messages[InfoLevel] = new(lipgloss.NewStyle().Foreground(lipgloss.Color("#50fa7b")))
+
+	l := New(NewTestOutputColor(&buf, 80))
+	l.SetParts(PartMessage)
+	l.SetStyles(styles)
+
+	l.Info().Msg("hello")
+	assert.Equal(t, "\x1b[38;2;80;250;123mhello\x1b[0m\n", buf.String())
+
+	buf.Reset()
+	l.SetColorProfile(ProfileANSI16)
+	l.Info().Msg("hello")
+	assert.Equal(t, "\x1b[92mhello\x1b[0m\n", buf.String())
+}
+
+func TestUntap(t *testing.T) {
+	var buf, tap bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Tap(&tap)
+	l.Info().Msg("one")
+	l.Untap()
+	l.Info().Msg("two")
+
+	assert.Equal(t, "INF ℹ️ one\nINF ℹ️ two\n", buf.String())
+	assert.Equal(t, "INF ℹ️ one\n", tap.String())
+}
+
 func TestDefaultLabels(t *testing.T) {
 	labels := DefaultLabels()
 
@@ -1503,11 +2493,211 @@ func TestSetTimeLocationNilDefaultsToLocal(t *testing.T) {
 	// Set to nil — should fall back to time.Local.
 	l.SetTimeLocation(nil)
 
-	l.mu.Lock()
-	got := l.timeLocation
-	l.mu.Unlock()
+	l.mu.Lock()
+	got := l.timeLocation
+	l.mu.Unlock()
+
+	assert.Equal(t, time.Local, got)
+}
+
+func TestLineByLevel(t *testing.T) {
+	withTrueColor(t)
+
+	var buf bytes.Buffer
+
+	l := New(NewOutput(&buf, ColorAlways))
+	l.SetParts(PartLevel, PartMessage, PartFields)
+
+	styles := DefaultStyles()
+	lineStyle := lipgloss.NewStyle().Background(lipgloss.Color("1"))
+	styles.LineByLevel[ErrorLevel] = new(lineStyle)
+	l.SetStyles(styles)
+
+	l.Error().Str("k", "v").Msg("boom")
+
+	open, closing, ok := strings.Cut(lineStyle.Render("\x00"), "\x00")
+	require.True(t, ok)
+	require.NotEmpty(t, open)
+
+	got := strings.TrimSuffix(buf.String(), "\n")
+	assert.True(t, strings.HasPrefix(got, open), "line should open with the line style")
+	assert.True(t, strings.HasSuffix(got, closing), "line should close with the line style")
+
+	// Every inner reset re-applies the line style so the background spans the line.
+	assert.Equal(t, strings.Count(got, ansiReset), strings.Count(got, ansiReset+open)+1)
+
+	// Other levels are untouched.
+	buf.Reset()
+	l.Info().Msg("fine")
+	assert.False(t, strings.HasPrefix(buf.String(), open))
+}
+
+func TestLineByLevelNoColor(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	styles := DefaultStyles()
+	styles.LineByLevel[ErrorLevel] = new(lipgloss.NewStyle().Background(lipgloss.Color("1")))
+	l.SetStyles(styles)
+
+	l.Error().Msg("boom")
+
+	assert.Equal(t, "ERR ❌ boom\n", buf.String())
+}
+
+func TestSetDefaultFields(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage, PartFields)
+	l.SetDefaultFields(Field{Key: "run_id", Value: "abc"})
+
+	sub := l.With().Str("component", "db").Logger()
+	sub.Info().Int("n", 1).Msg("default")
+	assert.Equal(t, "default run_id=abc component=db n=1\n", buf.String())
+
+	buf.Reset()
+	sub.Info().WithoutDefaults().Int("n", 2).Msg("skipped")
+	assert.Equal(t, "skipped component=db n=2\n", buf.String())
+
+	buf.Reset()
+	l.SetDefaultFields()
+	l.Info().Msg("cleared")
+	assert.Equal(t, "cleared\n", buf.String())
+}
+
+func TestSetDefaultFieldsSortAndOmit(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage, PartFields)
+	l.SetFieldSort(SortAscending)
+	l.SetOmitEmpty(true)
+	l.SetDefaultFields(Field{Key: "z", Value: "last"}, Field{Key: "empty", Value: ""})
+
+	l.Info().Str("a", "first").Msg("sorted")
+	assert.Equal(t, "sorted a=first z=last\n", buf.String())
+}
+
+func TestSetEmptyMessageMode(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartLevel, PartMessage, PartFields)
+
+	l.Info().Str("k", "v").Send()
+	assert.Equal(t, "INF k=v\n", buf.String())
+
+	buf.Reset()
+	l.SetEmptyMessageMode(EmptyMessageKeep)
+	l.Info().Str("k", "v").Send()
+	assert.Equal(t, "INF - k=v\n", buf.String())
+
+	buf.Reset()
+	l.SetEmptyMessagePlaceholder("(none)")
+	l.Info().Str("k", "v").Send()
+	assert.Equal(t, "INF (none) k=v\n", buf.String())
+
+	// An empty placeholder still reserves the column.
+	buf.Reset()
+	l.SetEmptyMessagePlaceholder("")
+	l.Info().Str("k", "v").Send()
+	assert.Equal(t, "INF  k=v\n", buf.String())
+
+	// Non-empty messages are unaffected.
+	buf.Reset()
+	l.Info().Str("k", "v").Msg("hello")
+	assert.Equal(t, "INF hello k=v\n", buf.String())
+}
+
+func TestSetEmptyRepr(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage, PartFields)
+
+	l.Info().Any("x", nil).Str("y", "").Msg("default")
+	assert.Equal(t, "default x=<nil> y=\n", buf.String())
+
+	buf.Reset()
+	l.SetEmptyRepr("∅", "(empty)")
+	l.Info().Any("x", nil).Str("y", "").Str("z", "set").Msg("custom")
+	assert.Equal(t, "custom x=∅ y=(empty) z=set\n", buf.String())
+
+	// Reprs are never quoted.
+	buf.Reset()
+	l.SetQuoteMode(QuoteAlways)
+	l.SetEmptyRepr("no value", "")
+	l.Info().Any("x", nil).Str("y", "").Msg("quoted")
+	assert.Equal(t, "quoted x=no value y=\"\"\n", buf.String())
+}
+
+func TestSetEmptyReprStyled(t *testing.T) {
+	styles := DefaultStyles()
+	opts := formatFieldsOpts{
+		emptyRepr: "(empty)",
+		level:     InfoLevel,
+		nilRepr:   "∅",
+		styles:    styles,
+	}
+
+	got := formatFields([]Field{{Key: "x", Value: nil}, {Key: "y", Value: ""}}, opts)
+
+	key := func(k string) string {
+		return " " + styles.KeyDefault.Render(k) + styles.Separator.Render("=")
+	}
+	want := key("x") + styles.Values[nil].Render("∅") + key("y") + styles.Values[""].Render("(empty)")
+	assert.Equal(t, want, got)
+}
+
+func TestSetEnabled(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	assert.True(t, l.Enabled())
+
+	exited := false
+	l.SetExitFunc(func(int) { exited = true })
+
+	handled := false
+	sub := l.With().Str("k", "v").Logger()
+	sub.SetHandler(HandlerFunc(func(Entry) { handled = true }))
+
+	l.SetEnabled(false)
+	assert.False(t, l.Enabled())
+
+	l.Trace().Msg("trace")
+	l.Info().Msg("info")
+	l.Error().Msg("error")
+	l.Fatal().Msg("fatal")
+
+	assert.Empty(t, buf.String())
+	assert.False(t, exited, "Fatal must not exit when disabled")
+
+	// Sub-loggers created before disabling are unaffected.
+	sub.Info().Msg("sub")
+	assert.True(t, handled)
+
+	// Sub-loggers created while disabled inherit the setting.
+	assert.False(t, l.With().Logger().Enabled())
+
+	l.SetEnabled(true)
+	l.Info().Msg("back")
+	assert.Equal(t, "INF ℹ️ back\n", buf.String())
+}
+
+func TestPackageLevelSetEnabled(t *testing.T) {
+	origDefault := Default
+	defer func() { Default = origDefault }()
+
+	var buf bytes.Buffer
+
+	Default = New(TestOutput(&buf))
+	SetEnabled(false)
+	Info().Msg("hidden")
 
-	assert.Equal(t, time.Local, got)
+	assert.Empty(t, buf.String())
+	assert.False(t, Default.Enabled())
 }
 
 func TestSetExitFuncNilDefaultsToOsExit(t *testing.T) {
@@ -1566,6 +2756,25 @@ func TestSetExitFuncNilFatalStillWorks(t *testing.T) {
 	assert.Equal(t, 1, exitCode)
 }
 
+func TestSetFatalExits(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+
+	exited := false
+	l.SetExitFunc(func(int) { exited = true })
+	l.SetFatalExits(false)
+
+	l.Fatal().Str("k", "v").Msg("boom")
+
+	assert.False(t, exited)
+	assert.Equal(t, "FTL 💥 boom k=v\n", buf.String())
+
+	l.SetFatalExits(true)
+	l.Fatal().Msg("boom")
+	assert.True(t, exited)
+}
+
 func TestAtomicLevelFastPath(t *testing.T) {
 	l := NewWriter(io.Discard)
 	l.SetLevel(WarnLevel)
@@ -1865,6 +3074,20 @@ func TestSetElapsedRound(t *testing.T) {
 	assert.Contains(t, buf.String(), "took=3s")
 }
 
+func TestSetFieldOrder(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetFieldOrder("op", "id")
+	l.With().Str("id", "42").Logger().Info().Str("x", "y").Str("op", "get").Msg("done")
+	assert.Equal(t, "INF ℹ️ done op=get id=42 x=y\n", buf.String())
+
+	buf.Reset()
+	l.SetFieldOrder()
+	l.Info().Str("id", "42").Str("op", "get").Msg("done")
+	assert.Equal(t, "INF ℹ️ done id=42 op=get\n", buf.String())
+}
+
 func TestSetFieldSort(t *testing.T) {
 	t.Run("ascending", func(t *testing.T) {
 		var buf bytes.Buffer
@@ -1891,6 +3114,19 @@ func TestSetFieldSort(t *testing.T) {
 		zooIdx := strings.Index(got, "zoo=")
 		assert.Greater(t, alphaIdx, zooIdx, "expected zoo before alpha in descending sort")
 	})
+
+	t.Run("none_then_ascending", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		l := New(TestOutput(&buf))
+		l.SetParts(PartMessage, PartFields)
+		l.SetFieldSort(SortNoneThenAscending)
+
+		sub := l.With().Str("run_id", "r1").Str("component", "db").Logger()
+		sub.Info().Int("zoo", 3).Int("alpha", 1).Int("mid", 2).Msg("test")
+
+		assert.Equal(t, "test run_id=r1 component=db alpha=1 mid=2 zoo=3\n", buf.String())
+	})
 }
 
 func TestSetPercentFormatFunc(t *testing.T) {
@@ -1929,6 +3165,72 @@ func TestSetPercentPrecision(t *testing.T) {
 	})
 }
 
+func TestSetDurationUsesQuantityStyles(t *testing.T) {
+	l := NewWriter(io.Discard)
+
+	// Default is false.
+	assert.False(t, l.durationUsesQuantityStyles)
+
+	l.SetDurationUsesQuantityStyles(true)
+	assert.True(t, l.durationUsesQuantityStyles)
+}
+
+func TestSetColumnWidth(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage, PartFields)
+	l.SetDurationColumnWidth(6)
+	l.SetQuantityColumnWidth(5)
+
+	l.Info().Duration("took", 500*time.Millisecond).Quantity("size", "5MB").Msg("a")
+	l.Info().Duration("took", 1200*time.Millisecond).Quantity("size", "120MB").Msg("b")
+
+	want := "a took= 500ms size=  5MB\n" +
+		"b took=  1.2s size=120MB\n"
+	assert.Equal(t, want, buf.String())
+
+	// Values wider than the column are left as-is.
+	buf.Reset()
+	l.Info().Duration("took", 90*time.Minute).Msg("c")
+	assert.Equal(t, "c took=1h30m0s\n", buf.String())
+}
+
+func TestSetKeyTruncate(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage, PartFields)
+	l.SetKeyTruncate("token", 4, 4)
+
+	token := "abcd" + strings.Repeat("0", 32) + "wxyz"
+	require.Len(t, token, 40)
+
+	l.Info().
+		Str("token", token).
+		Str("other", token).
+		Int("n", 1234567890).
+		Msg("auth")
+	assert.Equal(t, "auth token=abcd…wxyz other="+token+" n=1234567890\n", buf.String())
+
+	// Short values are untouched.
+	buf.Reset()
+	l.Info().Str("token", "short").Msg("auth")
+	assert.Equal(t, "auth token=short\n", buf.String())
+
+	// Numbers are untouched.
+	buf.Reset()
+	l.SetKeyTruncate("n", 1, 1)
+	l.Info().Int("n", 1234567890).Msg("auth")
+	assert.Equal(t, "auth n=1234567890\n", buf.String())
+
+	// Zero head and tail removes the rule.
+	buf.Reset()
+	l.SetKeyTruncate("token", 0, 0)
+	l.Info().Str("token", token).Msg("auth")
+	assert.Equal(t, "auth token="+token+"\n", buf.String())
+}
+
 func TestSetQuantityUnitsIgnoreCase(t *testing.T) {
 	l := NewWriter(io.Discard)
 
@@ -2105,6 +3407,18 @@ func TestIsTerminal(t *testing.T) {
 	assert.False(t, IsTerminal())
 }
 
+func TestTerminalSize(t *testing.T) {
+	origDefault := Default
+	defer func() { Default = origDefault }()
+
+	var buf bytes.Buffer
+
+	Default = New(TestOutput(&buf))
+
+	assert.Equal(t, 0, TerminalWidth())
+	assert.Equal(t, 0, TerminalHeight())
+}
+
 func TestColorModeStringBoundary(t *testing.T) {
 	// Valid values.
 	assert.Equal(t, "auto", ColorAuto.String())
@@ -2188,3 +3502,445 @@ func TestWithContextAndCtx(t *testing.T) {
 		assert.Same(t, Default, got)
 	})
 }
+
+func TestSetTimestampPreset(t *testing.T) {
+	now := time.Date(2024, 3, 5, 14, 7, 9, 123456789, time.FixedZone("", 2*60*60))
+
+	tests := []struct {
+		preset TimestampPreset
+		want   string
+	}{
+		{TimestampTimeOnly, "14:07:09.123"},
+		{TimestampDateTime, "2024-03-05 14:07:09.123"},
+		{TimestampRFC3339, "2024-03-05T14:07:09+02:00"},
+		{TimestampRFC3339Nano, "2024-03-05T14:07:09.123456789+02:00"},
+		{TimestampUnix, "1709640429"},
+		{TimestampUnixMilli, "1709640429123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			l := New(TestOutput(&buf))
+			l.nowFunc = func() time.Time { return now }
+			l.SetParts(PartTimestamp, PartMessage)
+			l.SetReportTimestamp(true)
+			l.SetTimeLocation(now.Location())
+			l.SetTimestampPreset(tt.preset)
+			l.Info().Msg("msg")
+
+			assert.Equal(t, tt.want+" msg\n", buf.String())
+		})
+	}
+}
+
+func TestSetBoolWords(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartFields)
+	l.SetBoolWords("yes", "no")
+	l.Info().Bool("ok", true).Bool("dry", false).Bools("flags", []bool{true, false}).Send()
+
+	assert.Equal(t, "ok=yes dry=no flags=[yes, no]\n", buf.String())
+
+	buf.Reset()
+	l.SetBoolWords("", "")
+	l.Info().Bool("ok", true).Bools("flags", []bool{false}).Send()
+
+	assert.Equal(t, "ok=true flags=[false]\n", buf.String())
+}
+
+func TestSetBoolWordsDiffAndAnys(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartFields)
+	l.SetBoolWords("yes", "no")
+	l.Info().Diff("tls", false, true).Anys("mixed", []any{true, 1}).Send()
+
+	assert.Equal(t, "tls=no → yes mixed=[yes, 1]\n", buf.String())
+}
+
+func TestSetBoolWordsStyled(t *testing.T) {
+	withTrueColor(t)
+
+	styles := DefaultStyles()
+	opts := formatFieldsOpts{
+		boolWords: boolWords{yes: "on", no: "off"},
+		level:     InfoLevel,
+		styles:    styles,
+	}
+
+	got := formatFields([]Field{{Key: "tls", Value: true}}, opts)
+	assert.Equal(t, " "+styles.KeyDefault.Render("tls")+styles.Separator.Render("=")+
+		styles.Values[true].Render("on"), got)
+
+	got = formatFields([]Field{{Key: "flags", Value: []bool{true, false}}}, opts)
+	assert.Equal(t, " "+styles.KeyDefault.Render("flags")+styles.Separator.Render("=")+
+		"["+styles.Values[true].Render("on")+", "+styles.Values[false].Render("off")+"]", got)
+
+	got = formatFields([]Field{{Key: "mixed", Value: []any{true}}}, opts)
+	assert.Equal(t, " "+styles.KeyDefault.Render("mixed")+styles.Separator.Render("=")+
+		"["+styles.Values[true].Render("on")+"]", got)
+
+	got = formatFields([]Field{{Key: "tls", Value: diff{before: false, after: true}}}, opts)
+	assert.Contains(t, got, styles.DiffOld.Render("off"))
+	assert.Contains(t, got, styles.DiffNew.Render("on"))
+}
+
+func TestSetScrapeToken(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetLevel(DebugLevel)
+	l.SetScrapeToken(func(e Entry) string { return "clog:" + levelNames[e.Level] + ":" })
+	l.Info().Msg("started")
+	l.Debug().Str("k", "v").Msg("detail")
+	l.Warn().Msg("slow")
+
+	assert.Equal(
+		t,
+		"clog:info: INF ℹ️ started\nclog:debug: DBG 🐞 detail k=v\nclog:warn: WRN ⚠️ slow\n",
+		buf.String(),
+	)
+}
+
+func TestSetScrapeTokenColorAlways(t *testing.T) {
+	withTrueColor(t)
+
+	var buf bytes.Buffer
+
+	l := New(NewOutput(&buf, ColorAlways))
+	l.SetScrapeToken(func(Entry) string { return "clog:info:" })
+	l.Info().Msg("started")
+
+	assert.True(t, strings.HasPrefix(buf.String(), "clog:info: \x1b["), "token should be unstyled: %q", buf.String())
+}
+
+func TestSetScrapeTokenEmpty(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage)
+	l.SetScrapeToken(func(e Entry) string {
+		if e.Level == ErrorLevel {
+			return "ALERT"
+		}
+		return ""
+	})
+	l.Info().Msg("ok")
+	l.Error().Msg("failed")
+	l.SetScrapeToken(nil)
+	l.Error().Msg("untokened")
+
+	assert.Equal(t, "ok\nALERT failed\nuntokened\n", buf.String())
+}
+
+func TestSetDryRun(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetDryRun(true)
+	l.Info().Str("path", "/tmp/x").Msg("Deleting")
+	l.Error().Msg("Failed")
+	l.Warn().Prefix("!!").Msg("Custom")
+
+	assert.Equal(
+		t,
+		"INF 🚧 Deleting path=/tmp/x dry_run=true\n"+
+			"ERR 🚧 Failed dry_run=true\n"+
+			"WRN !! Custom dry_run=true\n",
+		buf.String(),
+	)
+
+	buf.Reset()
+	l.SetDryRun(false)
+	l.Info().Msg("Deleting")
+	assert.Equal(t, "INF ℹ️ Deleting\n", buf.String())
+}
+
+func TestSetDryRunMessageStyle(t *testing.T) {
+	withTrueColor(t)
+
+	var buf bytes.Buffer
+
+	dry := new(lipgloss.NewStyle().Italic(true))
+	styles := DefaultStyles()
+	styles.Messages[DryLevel] = dry
+
+	l := New(NewOutput(&buf, ColorAlways))
+	l.SetStyles(styles)
+	l.SetParts(PartMessage)
+	l.SetDryRun(true)
+	l.Info().Msg("Deleting")
+
+	assert.Contains(t, buf.String(), dry.Render("Deleting"))
+}
+
+func TestSetDryRunHandler(t *testing.T) {
+	var got []Entry
+
+	l := NewWriter(io.Discard)
+	l.SetHandler(HandlerFunc(func(e Entry) { got = append(got, e) }))
+	l.SetDryRun(true)
+	l.Info().Msg("Deleting")
+	l.SetDryRun(false)
+	l.Info().Msg("Deleted")
+
+	require.Len(t, got, 2)
+	assert.True(t, got[0].DryRun)
+	assert.Equal(t, []Field{{Key: DryRunKey, Value: true}}, got[0].Fields)
+	assert.False(t, got[1].DryRun)
+	assert.Empty(t, got[1].Fields)
+}
+
+func TestSetDryRunSubLogger(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage, PartFields)
+	l.SetDryRun(true)
+	sub := l.With().Str("component", "db").Logger()
+	sub.Info().Msg("Migrating")
+
+	assert.Equal(t, "Migrating component=db dry_run=true\n", buf.String())
+}
+
+func TestSetCollapseRepeatTimestamp(t *testing.T) {
+	var buf bytes.Buffer
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	l := New(TestOutput(&buf))
+	l.nowFunc = func() time.Time { return now }
+	l.SetParts(PartTimestamp, PartMessage)
+	l.SetReportTimestamp(true)
+	l.SetTimeLocation(time.UTC)
+	l.SetCollapseRepeatTimestamp(true)
+
+	l.Info().Msg("first")
+	l.Info().Msg("second")
+	now = now.Add(time.Second)
+	l.Info().Msg("third")
+
+	assert.Equal(
+		t,
+		"12:00:00.000 first\n"+
+			"             second\n"+
+			"12:00:01.000 third\n",
+		buf.String(),
+	)
+}
+
+func TestSetCollapseRepeatTimestampDisabled(t *testing.T) {
+	var buf bytes.Buffer
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	l := New(TestOutput(&buf))
+	l.nowFunc = func() time.Time { return now }
+	l.SetParts(PartTimestamp, PartMessage)
+	l.SetReportTimestamp(true)
+	l.SetTimeLocation(time.UTC)
+
+	l.Info().Msg("first")
+	l.Info().Msg("second")
+
+	assert.Equal(t, "12:00:00.000 first\n12:00:00.000 second\n", buf.String())
+}
+
+func TestSetTimestampPresetUnknown(t *testing.T) {
+	l := NewWriter(io.Discard)
+	l.SetTimeFormat(time.Kitchen)
+	l.SetTimestampPreset(TimestampPreset(99))
+
+	assert.Equal(t, time.Kitchen, l.timeFormat)
+}
+
+func TestLevelContext(t *testing.T) {
+	t.Run("debug_context_enables_debug", func(t *testing.T) {
+		origDefault := Default
+		defer func() { Default = origDefault }()
+
+		var buf bytes.Buffer
+		Default = New(TestOutput(&buf))
+
+		ctx := DebugContext(context.Background())
+		Ctx(ctx).Debug().Msg("verbose")
+		Default.Debug().Msg("hidden")
+		Ctx(context.Background()).Debug().Msg("hidden")
+
+		assert.Equal(t, "DBG 🐞 verbose\n", buf.String())
+		assert.Equal(t, InfoLevel, Default.Level())
+	})
+
+	t.Run("trace_context", func(t *testing.T) {
+		l := NewWriter(io.Discard)
+		ctx := TraceContext(l.WithContext(context.Background()))
+
+		assert.Equal(t, TraceLevel, Ctx(ctx).Level())
+		assert.Equal(t, InfoLevel, l.Level())
+	})
+
+	t.Run("keeps_fields_and_shares_output", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		l := New(TestOutput(&buf)).With().Str("op", "sync").Logger()
+		ctx := DebugContext(l.WithContext(context.Background()))
+		Ctx(ctx).Debug().Msg("step")
+
+		assert.Equal(t, "DBG 🐞 step op=sync\n", buf.String())
+	})
+
+	t.Run("never_raises_level", func(t *testing.T) {
+		l := NewWriter(io.Discard)
+		l.SetLevel(TraceLevel)
+		ctx := DebugContext(l.WithContext(context.Background()))
+
+		assert.Equal(t, TraceLevel, Ctx(ctx).Level())
+	})
+}
+
+func TestSetTimestampGradient(t *testing.T) {
+	withTrueColor(t)
+
+	l := New(NewOutput(io.Discard, ColorAlways))
+	l.SetParts(PartTimestamp)
+
+	first := colorful.Color{R: 0.3, G: 0.3, B: 0.3}
+	last := colorful.Color{R: 1, G: 1, B: 1}
+	l.SetTimestampGradient([]ColorStop{{Position: 0, Color: first}, {Position: 1, Color: last}}, time.Minute)
+
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	l.timestampGradientStart = start
+
+	render := func(c colorful.Color, ts time.Time) string {
+		return l.styles.Timestamp.Foreground(lipgloss.Color(c.Clamped().Hex())).
+			Render(ts.Format(l.timeFormat))
+	}
+
+	assert.Equal(t, render(first, start), l.Render(Entry{Time: start}))
+
+	end := start.Add(time.Minute)
+	assert.Equal(t, render(last, end), l.Render(Entry{Time: end}))
+
+	// Past the window, the last stop is kept.
+	later := start.Add(time.Hour)
+	assert.Equal(t, render(last, later), l.Render(Entry{Time: later}))
+}
+
+func TestSetTimestampGradientNoColor(t *testing.T) {
+	l := New(TestOutput(io.Discard))
+	l.SetParts(PartTimestamp)
+	l.SetTimestampGradient([]ColorStop{{Position: 0, Color: colorful.Color{R: 1}}}, time.Minute)
+
+	ts := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.Equal(t, ts.Format(l.timeFormat), l.Render(Entry{Time: ts}))
+}
+
+func TestErrorFieldCount(t *testing.T) {
+	l := NewWriter(io.Discard)
+
+	l.Warn().Err(errors.New("one")).Msg("retrying")
+	l.Warn().Msg("plain")
+	l.Warn().Err(errors.New("two")).Send()
+	l.Warn().Err(nil).Msg("nil error")
+	l.Debug().Err(errors.New("filtered")).Msg("below level")
+
+	assert.Equal(t, uint64(2), l.ErrorFieldCount())
+
+	l.ResetErrorFieldCount()
+	assert.Zero(t, l.ErrorFieldCount())
+}
+
+func TestErrorFieldCountSkipsFieldFiltered(t *testing.T) {
+	l := NewWriter(io.Discard)
+	l.SetLevelForField("component", "db", DebugLevel)
+
+	// Debug events are created for the override, but only db ones are logged.
+	l.Debug().Str("component", "api").Err(errors.New("dropped")).Msg("x")
+	l.Debug().Str("component", "db").Err(errors.New("logged")).Msg("x")
+
+	assert.Equal(t, uint64(1), l.ErrorFieldCount())
+}
+
+func TestErrorFieldCountSharedWithSubLoggers(t *testing.T) {
+	l := NewWriter(io.Discard)
+	sub := l.With().Str("component", "db").Logger()
+
+	sub.Info().Err(errors.New("x")).Msg("info with error")
+	l.Error().Err(errors.New("y")).Send()
+
+	assert.Equal(t, uint64(2), l.ErrorFieldCount())
+	assert.Equal(t, uint64(2), sub.ErrorFieldCount())
+}
+
+func TestWrapSlices(t *testing.T) {
+	var buf bytes.Buffer
+
+	files := []string{
+		"alpha.go", "beta.go", "gamma.go", "delta.go", "epsilon.go",
+		"zeta.go", "eta.go", "theta.go", "iota.go", "kappa.go",
+	}
+
+	l := New(NewTestOutputColor(&buf, 40))
+	l.SetWrapSlices(true)
+	l.Info().Strs("files", files).Int("n", 10).Msg("found")
+
+	// Continuation lines align under the first element.
+	want := "INF ℹ️ found files=[alpha.go, beta.go,\n" +
+		"                    gamma.go, delta.go,\n" +
+		"                    epsilon.go, zeta.go,\n" +
+		"                    eta.go, theta.go,\n" +
+		"                    iota.go, kappa.go] n=10\n"
+	assert.Equal(t, want, buf.String())
+}
+
+func TestWrapSlicesFits(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(NewTestOutputColor(&buf, 40))
+	l.SetWrapSlices(true)
+	l.Info().Strs("files", []string{"a.go", "b.go"}).Msg("found")
+
+	assert.Equal(t, "INF ℹ️ found files=[a.go, b.go]\n", buf.String())
+}
+
+func TestWrapSlicesDisabled(t *testing.T) {
+	files := []string{"alpha.go", "beta.go", "gamma.go", "delta.go", "epsilon.go"}
+
+	t.Run("default", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		l := New(NewTestOutputColor(&buf, 20))
+		l.Info().Strs("files", files).Msg("found")
+
+		assert.NotContains(t, buf.String(), "\n ")
+	})
+
+	t.Run("no_width", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		l := New(TestOutput(&buf))
+		l.SetWrapSlices(true)
+		l.Info().Strs("files", files).Msg("found")
+
+		assert.NotContains(t, buf.String(), "\n ")
+	})
+}
+
+func TestWrapSlicesStyled(t *testing.T) {
+	withTrueColor(t)
+
+	var buf bytes.Buffer
+
+	l := New(NewTestOutputColor(&buf, 30))
+	l.SetParts(PartMessage, PartFields)
+	l.SetWrapSlices(true)
+	l.Info().Ints("ids", []int{1000, 2000, 3000, 4000, 5000, 6000}).Msg("ok")
+
+	assert.Contains(t, buf.String(), "\x1b[")
+	assert.Equal(t, "ok ids=[1000, 2000, 3000,\n        4000, 5000, 6000]\n", StripANSI(buf.String()))
+}
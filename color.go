@@ -2,9 +2,14 @@ package clog
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strconv"
 	"strings"
 	"sync/atomic"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
 )
 
 // noColorEnvSet is loaded eagerly during package var init (before Default)
@@ -41,3 +46,101 @@ func (m *ColorMode) UnmarshalText(text []byte) error {
 func ColorsDisabled() bool {
 	return Default.Output().ColorsDisabled()
 }
+
+// StripANSI removes ANSI escape sequences from s, including SGR colours and
+// OSC 8 hyperlinks, leaving only the visible text. Use it in custom
+// [Handler] implementations to serialise values created by [Event.Link],
+// [Event.Path] and friends under [ColorAlways].
+func StripANSI(s string) string {
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+	return ansi.Strip(s)
+}
+
+// profileWriter converts the colours in SGR escape sequences written to w
+// to profile (see [Output.SetColorProfile]).
+type profileWriter struct {
+	w       io.Writer
+	profile termenv.Profile
+}
+
+func (pw profileWriter) Write(p []byte) (int, error) {
+	s := string(p)
+	if !strings.Contains(s, "\x1b[") {
+		return pw.w.Write(p)
+	}
+	if _, err := io.WriteString(pw.w, downsampleANSI(s, pw.profile)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// downsampleANSI rewrites the truecolour and 256-colour parameters of SGR
+// sequences in s to the nearest colours in profile. Other sequences and
+// parameters are left unchanged.
+func downsampleANSI(s string, profile termenv.Profile) string {
+	var b strings.Builder
+	for {
+		i := strings.Index(s, "\x1b[")
+		if i < 0 {
+			b.WriteString(s)
+			return b.String()
+		}
+		b.WriteString(s[:i+2])
+		s = s[i+2:]
+
+		j := strings.IndexFunc(s, func(r rune) bool { return r != ';' && (r < '0' || r > '9') })
+		if j < 0 || s[j] != 'm' {
+			continue
+		}
+		b.WriteString(downsampleSGR(s[:j], profile))
+		b.WriteByte('m')
+		s = s[j+1:]
+	}
+}
+
+// downsampleSGR converts the "38;2;r;g;b", "38;5;n" and matching
+// background colours in the ;-separated SGR params to profile.
+func downsampleSGR(params string, profile termenv.Profile) string {
+	ps := strings.Split(params, ";")
+	out := make([]string, 0, len(ps))
+	for i := 0; i < len(ps); i++ {
+		if (ps[i] == "38" || ps[i] == "48") && i+1 < len(ps) {
+			var c termenv.Color
+			n := 0
+			switch {
+			case ps[i+1] == "2" && i+4 < len(ps):
+				if rgb, ok := parseColorComponents(ps[i+2 : i+5]); ok {
+					c = termenv.RGBColor(fmt.Sprintf("#%02x%02x%02x", rgb[0], rgb[1], rgb[2]))
+					n = 4
+				}
+			case ps[i+1] == "5" && i+2 < len(ps):
+				if idx, ok := parseColorComponents(ps[i+2 : i+3]); ok {
+					c = termenv.ANSI256Color(idx[0])
+					n = 2
+				}
+			}
+			if c != nil {
+				out = append(out, profile.Convert(c).Sequence(ps[i] == "48"))
+				i += n
+				continue
+			}
+		}
+		out = append(out, ps[i])
+	}
+	return strings.Join(out, ";")
+}
+
+// parseColorComponents parses each of ss as a colour component in the range 0–255.
+func parseColorComponents(ss []string) ([]int, bool) {
+	ns := make([]int, len(ss))
+	for i, s := range ss {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 || n > 255 {
+			return nil, false
+		}
+		ns[i] = n
+	}
+	return ns, true
+}
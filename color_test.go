@@ -4,6 +4,7 @@ import (
 	"io"
 	"testing"
 
+	"github.com/muesli/termenv"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -94,3 +95,47 @@ func TestColorsDisabledPackageLevel(t *testing.T) {
 	Default = New(NewOutput(io.Discard, ColorNever))
 	assert.True(t, ColorsDisabled())
 }
+
+func TestStripANSI(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain", "hello", "hello"},
+		{"sgr", "\x1b[31mred\x1b[0m", "red"},
+		{"osc8_st", "\x1b]8;;https://example.com\x1b\\docs\x1b]8;;\x1b\\", "docs"},
+		{"osc8_bel", "\x1b]8;;https://example.com\adocs\x1b]8;;\a", "docs"},
+		{"mixed", "\x1b[1m\x1b]8;;file:///tmp\x1b\\/tmp\x1b]8;;\x1b\\\x1b[0m ok", "/tmp ok"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, StripANSI(tt.input))
+		})
+	}
+}
+
+func TestDownsampleANSI(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile termenv.Profile
+		input   string
+		want    string
+	}{
+		{"plain", termenv.ANSI, "hello", "hello"},
+		{"truecolor_to_ansi", termenv.ANSI, "\x1b[38;2;80;250;123mok\x1b[0m", "\x1b[92mok\x1b[0m"},
+		{"truecolor_to_256", termenv.ANSI256, "\x1b[38;2;80;250;123mok", "\x1b[38;5;84mok"},
+		{"background", termenv.ANSI, "\x1b[48;2;255;0;0mok", "\x1b[101mok"},
+		{"256_to_ansi", termenv.ANSI, "\x1b[38;5;196mok", "\x1b[91mok"},
+		{"keeps_attributes", termenv.ANSI, "\x1b[1;38;2;80;250;123;4mok", "\x1b[1;92;4mok"},
+		{"non_sgr", termenv.ANSI, "\x1b[2K\rok", "\x1b[2K\rok"},
+		{"out_of_range", termenv.ANSI, "\x1b[38;2;300;0;0mok", "\x1b[38;2;300;0;0mok"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, downsampleANSI(tt.input, tt.profile))
+		})
+	}
+}
@@ -7,8 +7,10 @@ import "sync"
 type Context struct {
 	fieldBuilder[Context]
 
-	logger *Logger
-	prefix *string // nil = inherit from parent logger
+	level           *Level // nil = inherit from parent logger
+	logger          *Logger
+	prefix          *string // nil = inherit from parent logger
+	reportTimestamp *bool   // nil = inherit from parent logger
 }
 
 // Column adds a file path field with a line and column number as a clickable terminal hyperlink.
@@ -29,8 +31,9 @@ func (c *Context) Column(key, path string, line, column int) *Context {
 	return c
 }
 
-// Dict adds a group of fields under a key prefix using dot notation.
-// Build the nested fields using [Dict] to create a field-only Event:
+// Dict adds a group of fields under a key prefix. Keys are joined with the
+// logger's dict separator (see [Logger.SetDictSeparator]). Build the nested
+// fields using [Dict] to create a field-only Event:
 //
 //	logger := clog.With().Dict("db", clog.Dict().
 //	    Str("host", "localhost").
@@ -41,9 +44,14 @@ func (c *Context) Dict(key string, dict *Event) *Context {
 		return c
 	}
 
-	for _, f := range dict.fields {
-		c.fields = append(c.fields, Field{Key: key + "." + f.Key, Value: f.Value})
-	}
+	c.fields = appendDict(c.fields, key, dict.fields, c.logger.dictSep())
+	return c
+}
+
+// Level sets the minimum log level for the sub-logger.
+// The parent logger's level is unaffected.
+func (c *Context) Level(level Level) *Context {
+	c.level = new(level)
 	return c
 }
 
@@ -77,10 +85,16 @@ func (c *Context) Logger() *Logger {
 	c.logger.mu.Lock()
 	defer c.logger.mu.Unlock()
 	l := c.logger.clone()
-	l.mu = c.logger.mu                  // share mutex
-	l.fields = c.fields                 // override with context fields
-	l.prefix = c.prefix                 // override with context prefix
-	l.atomicLevel.Store(int32(l.level)) //nolint:gosec // Level values are small constants (0-6)
+	l.mu = c.logger.mu  // share mutex
+	l.fields = c.fields // override with context fields
+	l.prefix = c.prefix // override with context prefix
+	if c.level != nil {
+		l.level = *c.level
+	}
+	if c.reportTimestamp != nil {
+		l.reportTimestamp = *c.reportTimestamp
+	}
+	l.atomicLevel.Store(int32(l.gateLevel())) //nolint:gosec // Level values are small constants (0-6)
 	return l
 }
 
@@ -100,6 +114,13 @@ func (c *Context) Prefix(prefix string) *Context {
 	return c
 }
 
+// ReportTimestamp enables or disables timestamps for the sub-logger.
+// The parent logger's setting is unaffected.
+func (c *Context) ReportTimestamp(report bool) *Context {
+	c.reportTimestamp = new(report)
+	return c
+}
+
 // URL adds a field as a clickable terminal hyperlink where the URL is also the display text.
 // Respects the logger's [ColorMode] setting.
 func (c *Context) URL(key, url string) *Context {
@@ -113,41 +134,95 @@ func (c *Context) URL(key, url string) *Context {
 // clone returns a shallow copy of the Logger with all fields duplicated.
 // The caller must hold l.mu. The returned Logger has its own mutex;
 // callers that want to share the parent mutex should reassign l.mu after cloning.
+// Fields added here must also be added to [Logger.restore].
 func (l *Logger) clone() *Logger {
-	return &Logger{
+	c := &Logger{
 		mu: &sync.Mutex{}, // placeholder; callers typically override
 
-		elapsedFormatFunc:       l.elapsedFormatFunc,
-		elapsedMinimum:          l.elapsedMinimum,
-		elapsedPrecision:        l.elapsedPrecision,
-		elapsedRound:            l.elapsedRound,
-		exitFunc:                l.exitFunc,
-		fieldSort:               l.fieldSort,
-		fieldStyleLevel:         l.fieldStyleLevel,
-		fieldTimeFormat:         l.fieldTimeFormat,
-		fields:                  l.fields,
-		handler:                 l.handler,
-		labelWidth:              l.labelWidth,
-		labels:                  l.labels,
-		labelsPadded:            l.labelsPadded,
-		level:                   l.level,
-		levelAlign:              l.levelAlign,
-		omitEmpty:               l.omitEmpty,
-		omitZero:                l.omitZero,
-		output:                  l.output,
-		parts:                   l.parts,
-		percentFormatFunc:       l.percentFormatFunc,
-		percentPrecision:        l.percentPrecision,
-		prefix:                  l.prefix,
-		prefixes:                l.prefixes,
-		quantityUnitsIgnoreCase: l.quantityUnitsIgnoreCase,
-		quoteOpen:               l.quoteOpen,
-		quoteClose:              l.quoteClose,
-		quoteMode:               l.quoteMode,
-		reportTimestamp:         l.reportTimestamp,
-		separatorText:           l.separatorText,
-		styles:                  l.styles,
-		timeFormat:              l.timeFormat,
-		timeLocation:            l.timeLocation,
+		alwaysStyleFieldsLevel:     l.alwaysStyleFieldsLevel,
+		autoColorKeys:              l.autoColorKeys,
+		batch:                      l.batch,
+		boolWords:                  l.boolWords,
+		collapseRepeatTimestamp:    l.collapseRepeatTimestamp,
+		confirmDefault:             l.confirmDefault,
+		defaultFields:              l.defaultFields,
+		dictSeparator:              l.dictSeparator,
+		dryRun:                     l.dryRun,
+		durationColumnWidth:        l.durationColumnWidth,
+		durationUsesQuantityStyles: l.durationUsesQuantityStyles,
+		elapsedFormatFunc:          l.elapsedFormatFunc,
+		elapsedMinimum:             l.elapsedMinimum,
+		elapsedPrecision:           l.elapsedPrecision,
+		elapsedRound:               l.elapsedRound,
+		emptyMessageMode:           l.emptyMessageMode,
+		emptyMessagePlaceholder:    l.emptyMessagePlaceholder,
+		emptyRepr:                  l.emptyRepr,
+		errorFields:                l.errorFields,
+		exitFunc:                   l.exitFunc,
+		fatalExits:                 l.fatalExits,
+		fieldKeyAlign:              l.fieldKeyAlign,
+		fieldLevels:                l.fieldLevels,
+		fieldOrder:                 l.fieldOrder,
+		fieldPriorities:            l.fieldPriorities,
+		fieldSort:                  l.fieldSort,
+		fieldStyleLevel:            l.fieldStyleLevel,
+		fieldTimeFormat:            l.fieldTimeFormat,
+		fields:                     l.fields,
+		handler:                    l.handler,
+		highlightMessageJSON:       l.highlightMessageJSON,
+		indent:                     l.indent,
+		input:                      l.input,
+		keyTruncate:                l.keyTruncate,
+		labelWidth:                 l.labelWidth,
+		labels:                     l.labels,
+		labelsPadded:               l.labelsPadded,
+		leadingTagField:            l.leadingTagField,
+		level:                      l.level,
+		levelAlign:                 l.levelAlign,
+		levelBadge:                 l.levelBadge,
+		levelRules:                 l.levelRules,
+		maxLineLen:                 l.maxLineLen,
+		measurePrecision:           l.measurePrecision,
+		messageTruncateLen:         l.messageTruncateLen,
+		messageTruncateMode:        l.messageTruncateMode,
+		nilRepr:                    l.nilRepr,
+		nowFunc:                    l.nowFunc,
+		numberGrouping:             l.numberGrouping,
+		omitEmpty:                  l.omitEmpty,
+		omitPredicate:              l.omitPredicate,
+		omitZero:                   l.omitZero,
+		onceKeys:                   l.onceKeys,
+		output:                     l.output,
+		parts:                      l.parts,
+		percentFormatFunc:          l.percentFormatFunc,
+		percentPrecision:           l.percentPrecision,
+		prefix:                     l.prefix,
+		prefixPlaceholderWhenEmpty: l.prefixPlaceholderWhenEmpty,
+		prefixes:                   l.prefixes,
+		quantityColumnWidth:        l.quantityColumnWidth,
+		quantityThousandsSep:       l.quantityThousandsSep,
+		quantityUnitsIgnoreCase:    l.quantityUnitsIgnoreCase,
+		quoteOpen:                  l.quoteOpen,
+		quoteClose:                 l.quoteClose,
+		quoteMode:                  l.quoteMode,
+		reportGoroutine:            l.reportGoroutine,
+		reportTimestamp:            l.reportTimestamp,
+		sanitizeControl:            l.sanitizeControl,
+		scrapeToken:                l.scrapeToken,
+		sectionRule:                l.sectionRule,
+		separatorText:              l.separatorText,
+		statsPrecision:             l.statsPrecision,
+		styles:                     l.styles,
+		tap:                        l.tap,
+		timeFormat:                 l.timeFormat,
+		timeLocation:               l.timeLocation,
+		timestampGradient:          l.timestampGradient,
+		timestampGradientStart:     l.timestampGradientStart,
+		timestampGradientWindow:    l.timestampGradientWindow,
+		treeIndent:                 l.treeIndent,
+		worker:                     l.worker,
+		wrapSlices:                 l.wrapSlices,
 	}
+	c.disabled.Store(l.disabled.Load())
+	return c
 }
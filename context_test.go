@@ -120,6 +120,16 @@ func TestContextDict(t *testing.T) {
 	assert.Equal(t, 5432, ctx.fields[1].Value)
 }
 
+func TestContextDictSeparator(t *testing.T) {
+	l := NewWriter(io.Discard)
+	l.SetDictSeparator(":")
+
+	ctx := l.With().Dict("db", Dict().Dict("primary", Dict().Str("host", "localhost")))
+
+	require.Len(t, ctx.fields, 1)
+	assert.Equal(t, "db:primary:host", ctx.fields[0].Key)
+}
+
 func TestContextErr(t *testing.T) {
 	ctx := NewWriter(io.Discard).With().Err(errors.New("boom"))
 
@@ -250,6 +260,36 @@ func TestContextPrefix(t *testing.T) {
 	assert.Equal(t, "CTX", got.Prefix)
 }
 
+func TestContextLevel(t *testing.T) {
+	l := NewWriter(io.Discard)
+
+	sub := l.With().Str("c", "db").Level(DebugLevel).Logger()
+
+	assert.Equal(t, DebugLevel, sub.Level())
+	assert.NotNil(t, sub.Debug(), "Debug should be enabled on the sub-logger")
+
+	// Parent is unchanged.
+	assert.Equal(t, InfoLevel, l.Level())
+	assert.Nil(t, l.Debug(), "Debug should remain disabled on the parent")
+}
+
+func TestContextReportTimestamp(t *testing.T) {
+	l := NewWriter(io.Discard)
+
+	var got Entry
+
+	l.SetHandler(HandlerFunc(func(e Entry) {
+		got = e
+	}))
+
+	sub := l.With().ReportTimestamp(true).Logger()
+	sub.Info().Msg("sub")
+	assert.False(t, got.Time.IsZero())
+
+	l.Info().Msg("parent")
+	assert.True(t, got.Time.IsZero())
+}
+
 func TestContextLoggerInheritsAtomicLevel(t *testing.T) {
 	l := NewWriter(io.Discard)
 	l.SetLevel(WarnLevel)
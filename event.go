@@ -1,11 +1,15 @@
 package clog
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"os/exec"
 	"reflect"
+	"runtime"
+	"slices"
 	"time"
 )
 
@@ -15,11 +19,17 @@ import (
 type Event struct {
 	logger *Logger
 
-	err       error // set by Err(); used as message by Send(), or as error= field by Msg()
-	fields    []Field
-	level     Level
-	prefix    *string   // nil = use logger/default prefix
-	timestamp time.Time // if non-zero, overrides time.Now() in Logger.log()
+	elapsedKeys  []string  // keys added by Elapsed(); resolved in Msg()
+	elapsedStart time.Time // set by the first Elapsed() call
+	err          error     // set by Err(); used as message by Send(), or as error= field by Msg()
+	errAsMsg     bool      // set by Send() when err is used as the message
+	fields       []Field
+	hiddenParts  []Part // set by HidePart(); removed from the rendered parts
+	level        Level
+	noDefaults   bool      // set by WithoutDefaults(); skips the logger's default fields
+	onlyParts    []Part    // set by OnlyParts(); nil = use the logger's parts
+	prefix       *string   // nil = use logger/default prefix
+	timestamp    time.Time // if non-zero, overrides time.Now() in Logger.log()
 }
 
 // Any adds a field with an arbitrary value.
@@ -32,6 +42,20 @@ func (e *Event) Any(key string, val any) *Event {
 	return e
 }
 
+// AnyAtLevel is like [Event.Any] but adds the field only when the logger's
+// level is minLevel or more verbose, so one statement can carry extra
+// detail that only shows in verbose runs:
+//
+//	clog.Info().AnyAtLevel("query", q, clog.DebugLevel).Msg("Query done")
+func (e *Event) AnyAtLevel(key string, val any, minLevel Level) *Event {
+	if e == nil || !e.verboseEnough(minLevel) {
+		return e
+	}
+
+	e.fields = append(e.fields, Field{Key: key, Value: val})
+	return e
+}
+
 // Anys adds a slice of arbitrary values. Individual elements are
 // highlighted using reflection to determine their type.
 func (e *Event) Anys(key string, vals []any) *Event {
@@ -88,6 +112,90 @@ func (e *Event) Bools(key string, vals []bool) *Event {
 	return e
 }
 
+// Caller adds a [CallerKey] field with the file and line of the code that
+// called Caller, shortened to the package directory and file name (e.g.
+// "server/handler.go:42"). When hyperlinks are enabled, the value links to
+// the full path.
+func (e *Event) Caller() *Event {
+	if e == nil {
+		return e
+	}
+	return e.addCaller(0)
+}
+
+// CallerAt adds a [CallerKey] field for an explicit source location, such
+// as the original location of generated code or of an entry bridged from
+// another language. file is shown as given. A non-empty fn is rendered as a
+// note after the location (see [Event.StrNote]); unlike other notes, it is
+// kept in [NewJSONHandler] output.
+func (e *Event) CallerAt(file string, line int, fn string) *Event {
+	if e == nil {
+		return e
+	}
+
+	output := Default.Output()
+	if e.logger != nil {
+		output = e.logger.Output()
+	}
+
+	location := output.callerLink(file, file, line)
+	var value any = location
+	if fn != "" {
+		value = callerSite{location: location, function: fn}
+	}
+
+	e.fields = append(e.fields, Field{Key: CallerKey, Value: value})
+	return e
+}
+
+// CallerSkip is like [Event.Caller] but skips skip additional stack frames,
+// so that logging helpers report their caller's location rather than their
+// own:
+//
+//	func logFailure(err error) {
+//	    clog.Error().CallerSkip(1).Err(err).Msg("Failed")
+//	}
+func (e *Event) CallerSkip(skip int) *Event {
+	if e == nil {
+		return e
+	}
+	return e.addCaller(max(skip, 0))
+}
+
+// addCaller adds the [CallerKey] field for the frame skip levels above the
+// caller of [Event.Caller] or [Event.CallerSkip]. No-op if the frame cannot
+// be resolved.
+func (e *Event) addCaller(skip int) *Event {
+	_, file, line, ok := runtime.Caller(skip + 2) //nolint:mnd // addCaller and its exported caller
+	if !ok {
+		return e
+	}
+
+	output := Default.Output()
+	if e.logger != nil {
+		output = e.logger.Output()
+	}
+
+	e.fields = append(
+		e.fields,
+		Field{Key: CallerKey, Value: output.callerLink(shortCallerPath(file), file, line)},
+	)
+	return e
+}
+
+// Cmd adds a command line field built from name and args. Each word is
+// quoted using POSIX shell rules so the value can be pasted into a shell,
+// e.g. git commit -m 'my message'. The value is styled with
+// [Styles.FieldCmd], falling back to [Styles.FieldString].
+func (e *Event) Cmd(key, name string, args ...string) *Event {
+	if e == nil {
+		return e
+	}
+
+	e.fields = append(e.fields, Field{Key: key, Value: command(shellJoin(name, args))})
+	return e
+}
+
 // Column adds a file path field with a line and column number as a clickable terminal hyperlink.
 // Respects the logger's [ColorMode] setting.
 func (e *Event) Column(key, path string, line, column int) *Event {
@@ -115,25 +223,91 @@ func (e *Event) Column(key, path string, line, column int) *Event {
 	return e
 }
 
-// Dict adds a group of fields under a key prefix using dot notation.
-// Build the nested fields using [Dict] to create a field-only Event:
+// Deadline adds a [time.Duration] field with the time left until ctx's
+// deadline, rounded to the millisecond (e.g. deadline=4.5s), to help
+// diagnose timeouts. A deadline that has already passed renders as a
+// negative duration. The field is omitted when ctx has no deadline. The
+// remaining time is measured when Deadline is called, using the logger's
+// clock.
+func (e *Event) Deadline(key string, ctx context.Context) *Event { //nolint:revive // key first, like other field methods
+	if e == nil {
+		return e
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return e
+	}
+
+	now := time.Now()
+	if e.logger != nil {
+		now = e.logger.now()
+	}
+
+	e.fields = append(e.fields, Field{Key: key, Value: deadline.Sub(now).Round(time.Millisecond)})
+	return e
+}
+
+// Dict adds a group of fields under a key prefix using dot notation (or
+// the separator set with [Logger.SetDictSeparator]). Build the nested
+// fields using [Dict] to create a field-only Event:
 //
 //	clog.Info().Dict("request", clog.Dict().
 //	    Str("method", "GET").
 //	    Int("status", 200),
 //	).Msg("handled")
 //	// Output: INF ℹ️ handled request.method=GET request.status=200
+//
+// Dicts may be nested to any depth.
 func (e *Event) Dict(key string, dict *Event) *Event {
 	if e == nil || dict == nil {
 		return e
 	}
 
-	for _, f := range dict.fields {
-		e.fields = append(e.fields, Field{Key: key + "." + f.Key, Value: f.Value})
+	// Detached events have no logger to supply the separator, so nested
+	// dicts are kept whole and flattened by the outermost attached event.
+	if e.logger == nil {
+		e.fields = append(e.fields, Field{Key: key, Value: dictFields(dict.fields)})
+		return e
+	}
+
+	e.fields = appendDict(e.fields, key, dict.fields, e.logger.dictSep())
+	return e
+}
+
+// Diff adds a field showing a change from oldVal to newVal, rendered as
+// "old → new" with [Styles.DiffOld] and [Styles.DiffNew]. When both values
+// are equal the value is rendered once, and the field is treated as empty
+// by [Logger.SetOmitEmpty].
+func (e *Event) Diff(key string, oldVal, newVal any) *Event {
+	if e == nil {
+		return e
+	}
+
+	e.fields = append(e.fields, Field{Key: key, Value: diff{before: oldVal, after: newVal}})
+	return e
+}
+
+// DryChange adds a field describing a change a dry run would make, rendered
+// as "key: old → new" in the colour of the [DryLevel] style:
+//
+//	clog.Dry().DryChange("replicas", 3, 5).Msg("Would scale")
+//	// Output: DRY 🚧 Would scale replicas: 3 → 5
+//
+// Like [Event.Diff], equal values are rendered once and the field is
+// treated as empty by [Logger.SetOmitEmpty].
+func (e *Event) DryChange(key string, oldVal, newVal any) *Event {
+	if e == nil {
+		return e
 	}
+
+	e.fields = append(e.fields, Field{Key: key, Value: diff{before: oldVal, after: newVal, dry: true}})
 	return e
 }
 
+// Dur is an alias for [Event.Duration], for familiarity with zerolog.
+func (e *Event) Dur(key string, val time.Duration) *Event { return e.Duration(key, val) }
+
 // Duration adds a [time.Duration] field.
 func (e *Event) Duration(key string, val time.Duration) *Event {
 	if e == nil {
@@ -154,6 +328,64 @@ func (e *Event) Durations(key string, vals []time.Duration) *Event {
 	return e
 }
 
+// Durs is an alias for [Event.Durations], for familiarity with zerolog.
+func (e *Event) Durs(key string, vals []time.Duration) *Event { return e.Durations(key, vals) }
+
+// Elapsed adds a field showing how long the event took to build, measured
+// from the first call to Elapsed until [Event.Msg] (or [Event.Send]). Call
+// it immediately after creating the event to time the work in between:
+//
+//	e := clog.Info().Elapsed("took")
+//	runMigrations()
+//	e.Msg("Migrated")
+//	// Output: INF ℹ️ Migrated took=3s
+//
+// The value is rendered like [AnimationBuilder.Elapsed] fields and honours
+// [Logger.SetElapsedMinimum], [Logger.SetElapsedRound],
+// [Logger.SetElapsedPrecision] and [Logger.SetElapsedFormatFunc].
+func (e *Event) Elapsed(key string) *Event {
+	if e == nil {
+		return e
+	}
+
+	if e.elapsedStart.IsZero() {
+		e.elapsedStart = e.now()
+	}
+
+	e.elapsedKeys = append(e.elapsedKeys, key)
+	e.fields = append(e.fields, Field{Key: key, Value: elapsed(0)})
+	return e
+}
+
+// ElapsedP is like [Event.Elapsed] but renders the value with precision
+// decimal places (e.g. 2 = "3.21s"), overriding [Logger.SetElapsedPrecision]
+// for this field. [Logger.SetElapsedRound] does not apply to it.
+func (e *Event) ElapsedP(key string, precision int) *Event {
+	if e == nil {
+		return e
+	}
+
+	e.Elapsed(key)
+	e.fields[len(e.fields)-1].Value = precise{value: elapsed(0), precision: max(precision, 0)}
+	return e
+}
+
+// Enums adds a slice field of enum values rendered with their String
+// methods, like [Event.Stringers]. The original values are kept, so each
+// element can be styled by a [Styles.Values] entry keyed by the enum
+// constant itself, as well as by its string:
+//
+//	styles.Values[StatusFailed] = new(lipgloss.NewStyle().Foreground(lipgloss.Color("1")))
+//	clog.Info().Enums("states", []fmt.Stringer{StatusActive, StatusFailed}).Msg("Checked")
+func (e *Event) Enums(key string, vals []fmt.Stringer) *Event {
+	if e == nil {
+		return e
+	}
+
+	e.fields = append(e.fields, Field{Key: key, Value: enums(slices.Clone(vals))})
+	return e
+}
+
 // Errs adds an error slice field. Each error is converted to its message
 // string; nil errors are rendered as [Nil] ("<nil>").
 func (e *Event) Errs(key string, vals []error) *Event {
@@ -179,6 +411,29 @@ func (e *Event) Err(err error) *Event {
 	return e
 }
 
+// ErrKey adds an error field under the given key, styled with
+// [Styles.FieldError]. No-op if err is nil. Unlike [Event.Err], the error
+// is always stored as a field, so several errors can share one entry.
+func (e *Event) ErrKey(key string, err error) *Event {
+	if e == nil || err == nil {
+		return e
+	}
+
+	e.fields = append(e.fields, Field{Key: key, Value: err})
+	return e
+}
+
+// ExecCmd adds a command line field for c, using c.Path and c.Args.
+// See [Event.Cmd]. No-op if c is nil.
+func (e *Event) ExecCmd(key string, c *exec.Cmd) *Event {
+	if e == nil || c == nil {
+		return e
+	}
+
+	e.fields = append(e.fields, Field{Key: key, Value: command(execCmdLine(c))})
+	return e
+}
+
 // Func executes fn with the event if the event is enabled (non-nil).
 // This is useful for computing expensive fields lazily — the callback
 // is skipped entirely when the log level is disabled.
@@ -200,6 +455,17 @@ func (e *Event) Float64(key string, val float64) *Event {
 	return e
 }
 
+// Float64Note adds a float64 field followed by a parenthetical note. See
+// [Event.StrNote].
+func (e *Event) Float64Note(key string, val float64, note string) *Event {
+	if e == nil {
+		return e
+	}
+
+	e.fields = append(e.fields, Field{Key: key, Value: withNote(val, note)})
+	return e
+}
+
 // Floats64 adds a float64 slice field.
 func (e *Event) Floats64(key string, vals []float64) *Event {
 	if e == nil {
@@ -220,6 +486,20 @@ func (e *Event) Hex(key string, val []byte) *Event {
 	return e
 }
 
+// HidePart hides p from this entry's line without changing the logger's
+// parts (see [Logger.SetParts]). It can be called more than once, and is
+// applied after [Event.OnlyParts]:
+//
+//	clog.Info().HidePart(clog.PartLevel).HidePart(clog.PartPrefix).Msg("Summary")
+func (e *Event) HidePart(p Part) *Event {
+	if e == nil {
+		return e
+	}
+
+	e.hiddenParts = append(e.hiddenParts, p)
+	return e
+}
+
 // Int adds an int field.
 func (e *Event) Int(key string, val int) *Event {
 	if e == nil {
@@ -230,6 +510,17 @@ func (e *Event) Int(key string, val int) *Event {
 	return e
 }
 
+// IntNote adds an integer field followed by a parenthetical note. See
+// [Event.StrNote].
+func (e *Event) IntNote(key string, val int, note string) *Event {
+	if e == nil {
+		return e
+	}
+
+	e.fields = append(e.fields, Field{Key: key, Value: withNote(val, note)})
+	return e
+}
+
 // Ints adds an int slice field.
 func (e *Event) Ints(key string, vals []int) *Event {
 	if e == nil {
@@ -283,6 +574,19 @@ func (e *Event) Line(key, path string, line int) *Event {
 	return e
 }
 
+// Lines adds a multi-line text field, such as a command's captured output.
+// The first line follows "key=" and each further line is indented to start
+// in the same column. Trailing empty lines are dropped; text without
+// newlines is added like [Event.Str].
+func (e *Event) Lines(key, text string) *Event {
+	if e == nil {
+		return e
+	}
+
+	e.fields = append(e.fields, Field{Key: key, Value: splitLines(text)})
+	return e
+}
+
 // Link adds a field as a clickable terminal hyperlink with custom URL and display text.
 // Respects the logger's [ColorMode] setting.
 func (e *Event) Link(key, url, text string) *Event {
@@ -302,6 +606,31 @@ func (e *Event) Link(key, url, text string) *Event {
 	return e
 }
 
+// Measure adds a quantity field built from a numeric value and unit, e.g.
+// Measure("distance", 5.1, "km") renders "5.1km" and Measure("size", 5,
+// "MB") renders "5MB". The value is rounded to [Logger.SetMeasurePrecision]
+// decimal places with trailing zeros dropped; see [Event.MeasureP] for a
+// fixed precision. Styled like [Event.Quantity].
+func (e *Event) Measure(key string, val float64, unit string) *Event {
+	if e == nil {
+		return e
+	}
+
+	e.fields = append(e.fields, Field{Key: key, Value: measure(val, -1, unit)})
+	return e
+}
+
+// MeasureP is like [Event.Measure] but renders the value with precision
+// decimal places, overriding [Logger.SetMeasurePrecision] for this field.
+func (e *Event) MeasureP(key string, val float64, precision int, unit string) *Event {
+	if e == nil {
+		return e
+	}
+
+	e.fields = append(e.fields, Field{Key: key, Value: measure(val, max(precision, 0), unit)})
+	return e
+}
+
 // Msg finalises the event and writes the log entry.
 // If [Event.Err] was called, the error is included as an "error" field.
 // For [FatalLevel] events, Msg calls [os.Exit](1) after writing.
@@ -314,10 +643,12 @@ func (e *Event) Msg(msg string) {
 		panic("clog: Msg/Msgf/Send called on a Dict() event -- pass it to Event.Dict() instead")
 	}
 
-	if e.err != nil {
+	if e.err != nil && !e.errAsMsg {
 		e.fields = append(e.fields, Field{Key: ErrorKey, Value: e.err})
 	}
 
+	e.resolveElapsed()
+
 	e.logger.log(e, msg)
 
 	if e.level == FatalLevel {
@@ -334,6 +665,36 @@ func (e *Event) Msgf(format string, args ...any) {
 	e.Msg(fmt.Sprintf(format, args...))
 }
 
+// Once makes the event log only the first time key is seen by the logger,
+// for the lifetime of the process: later events with the same key are
+// discarded, e.g. to print a deprecation notice once per run. The key is
+// recorded when Once is called, and the set of seen keys is shared with
+// sub-loggers. See [Logger.ResetOnce].
+//
+//	clog.Warn().Once("deprecated-flag").Msg("--legacy is deprecated")
+func (e *Event) Once(key string) *Event {
+	if e == nil || e.logger == nil {
+		return e
+	}
+
+	if _, seen := e.logger.onceKeys.LoadOrStore(key, struct{}{}); seen {
+		return nil
+	}
+	return e
+}
+
+// OnlyParts renders this entry with parts in the given order instead of
+// the logger's (see [Logger.SetParts]). The logger itself is unchanged.
+// With no parts, the logger's parts are used.
+func (e *Event) OnlyParts(parts ...Part) *Event {
+	if e == nil {
+		return e
+	}
+
+	e.onlyParts = slices.Clone(parts)
+	return e
+}
+
 // Percent adds a percentage field (0–100) with gradient color styling.
 // Values are clamped to the 0–100 range. The color is interpolated from
 // the [Styles.PercentGradient] stops (default: red → yellow → green).
@@ -346,6 +707,20 @@ func (e *Event) Percent(key string, val float64) *Event {
 	return e
 }
 
+// PercentP is like [Event.Percent] but renders the value with precision
+// decimal places, overriding [Logger.SetPercentPrecision] for this field.
+func (e *Event) PercentP(key string, val float64, precision int) *Event {
+	if e == nil {
+		return e
+	}
+
+	e.fields = append(e.fields, Field{Key: key, Value: precise{
+		value:     percent(clampPercent(val)),
+		precision: max(precision, 0),
+	}})
+	return e
+}
+
 // Path adds a file path field as a clickable terminal hyperlink.
 // Respects the logger's [ColorMode] setting.
 func (e *Event) Path(key, path string) *Event {
@@ -393,6 +768,24 @@ func (e *Event) JSON(key string, val any) *Event {
 	return e
 }
 
+// JSONFields parses data as a JSON object and adds each leaf value as an
+// individual field, with nested keys joined to prefix using dot notation:
+//
+//	clog.Error().JSONFields("error", body).Msg("request failed")
+//	// Output: ERR ❌ request failed error.status=422 error.detail=invalid
+//
+// Unlike [Event.RawJSON], the resulting fields sort and style like normal
+// fields. Arrays are kept intact as highlighted JSON. Invalid JSON falls
+// back to a single string field under prefix.
+func (e *Event) JSONFields(prefix string, data []byte) *Event {
+	if e == nil {
+		return e
+	}
+
+	e.fields = append(e.fields, jsonFields(prefix, data)...)
+	return e
+}
+
 // Prefix overrides the default emoji prefix for this entry.
 func (e *Event) Prefix(prefix string) *Event {
 	if e == nil {
@@ -430,6 +823,34 @@ func (e *Event) Quantity(key, val string) *Event {
 	return e
 }
 
+// Rate adds a throughput field of count items per second over the duration
+// per, humanized with SI suffixes (e.g. 5000 over 2s renders "2.5k/s"). It
+// is stored as a quantity, so it is styled and matched against thresholds
+// like [Event.Quantity] with unit "k/s", "/s", etc. A non-positive per
+// renders "∞/s".
+func (e *Event) Rate(key string, count int64, per time.Duration) *Event {
+	if e == nil {
+		return e
+	}
+
+	e.fields = append(e.fields, Field{Key: key, Value: quantity(formatRate(count, per))})
+	return e
+}
+
+// Retry adds the fields of a retry loop: "attempt" as a fraction of
+// maxAttempts and "backoff" as a duration, e.g. attempt=2/5 backoff=4s. The
+// attempt is styled with [Styles.FieldNumber], or [Styles.FieldRetryExhausted]
+// once attempt reaches maxAttempts. A zero backoff, as after the final
+// attempt, is omitted under [Logger.SetOmitEmpty].
+func (e *Event) Retry(attempt, maxAttempts int, backoff time.Duration) *Event {
+	if e == nil {
+		return e
+	}
+
+	e.fields = append(e.fields, retryFields(attempt, maxAttempts, backoff)...)
+	return e
+}
+
 // Send finalises the event. If [Event.Err] was called, the error message is
 // used as the log message (no "error" field is added). Any other fields on the
 // event are preserved. If [Event.Err] was not called, the message is empty.
@@ -439,15 +860,42 @@ func (e *Event) Send() {
 	}
 
 	if e.err != nil {
-		msg := e.err.Error()
-		e.err = nil // prevent Msg from also adding it as a field
-		e.Msg(msg)
+		e.errAsMsg = true // prevent Msg from also adding it as a field
+		e.Msg(e.err.Error())
 		return
 	}
 
 	e.Msg("")
 }
 
+// Sparkline adds a field rendering vals as a row of block characters
+// scaled to their range, e.g. latency=▁▃▆█. When colours are enabled each
+// block is coloured from the [Styles.PercentGradient] stops by its relative
+// magnitude. An empty slice renders as nothing and counts as empty for
+// [Logger.SetOmitEmpty].
+func (e *Event) Sparkline(key string, vals []float64) *Event {
+	if e == nil {
+		return e
+	}
+
+	e.fields = append(e.fields, Field{Key: key, Value: sparkline(slices.Clone(vals))})
+	return e
+}
+
+// Stats adds a field summarising vals with their count, minimum, mean and
+// maximum, e.g. latency=[n=100 min=1.20 avg=3.40 max=9.90]. The stats are
+// computed once, when the field is added; see [Logger.SetStatsPrecision]. An
+// empty slice renders as [n=0] and counts as empty for
+// [Logger.SetOmitEmpty].
+func (e *Event) Stats(key string, vals []float64) *Event {
+	if e == nil {
+		return e
+	}
+
+	e.fields = append(e.fields, Field{Key: key, Value: newStats(vals)})
+	return e
+}
+
 // Str adds a string field.
 func (e *Event) Str(key, val string) *Event {
 	if e == nil {
@@ -458,6 +906,41 @@ func (e *Event) Str(key, val string) *Event {
 	return e
 }
 
+// StrAtLevel is like [Event.Str] but adds the field only when the logger's
+// level is minLevel or more verbose. See [Event.AnyAtLevel].
+func (e *Event) StrAtLevel(key, val string, minLevel Level) *Event {
+	if e == nil || !e.verboseEnough(minLevel) {
+		return e
+	}
+
+	e.fields = append(e.fields, Field{Key: key, Value: val})
+	return e
+}
+
+// StrNote adds a string field followed by a parenthetical note, rendered as
+// "key=val (note)" with the note styled by [Styles.FieldNote]. The note is
+// omitted when empty.
+func (e *Event) StrNote(key, val, note string) *Event {
+	if e == nil {
+		return e
+	}
+
+	e.fields = append(e.fields, Field{Key: key, Value: withNote(val, note)})
+	return e
+}
+
+// StrSep adds a string field rendered with sep between the key and value
+// instead of the logger's separator (see [Logger.SetSeparatorText]), e.g.
+// "url: https://example.com". An empty sep uses the logger's separator.
+func (e *Event) StrSep(key, sep, val string) *Event {
+	if e == nil {
+		return e
+	}
+
+	e.fields = append(e.fields, Field{Key: key, Value: withSep(val, sep)})
+	return e
+}
+
 // Stringer adds a field by calling the value's String method. No-op if val is nil.
 func (e *Event) Stringer(key string, val fmt.Stringer) *Event {
 	if e == nil || isNilStringer(val) {
@@ -517,6 +1000,21 @@ func (e *Event) Times(key string, vals []time.Time) *Event {
 	return e
 }
 
+// Tristate adds a tri-state bool field. A nil val renders as "unknown",
+// styled with the [Styles.Values] entry for nil; true and false render like
+// [Event.Bool].
+func (e *Event) Tristate(key string, val *bool) *Event {
+	if e == nil {
+		return e
+	}
+
+	e.fields = append(e.fields, Field{Key: key, Value: tristateValue(val)})
+	return e
+}
+
+// Ts is an alias for [Event.Time], for familiarity with zerolog.
+func (e *Event) Ts(key string, val time.Time) *Event { return e.Time(key, val) }
+
 // Uint adds a uint field.
 func (e *Event) Uint(key string, val uint) *Event {
 	if e == nil {
@@ -557,6 +1055,19 @@ func (e *Event) Uints64(key string, vals []uint64) *Event {
 	return e
 }
 
+// ValidationErrors adds a field listing validation errors keyed by field
+// path, rendered as "key=[age: too large, name: required]". Paths are sorted
+// and styled with [Styles.KeyDefault]; messages use [Styles.FieldError].
+// An empty map is omitted under [Logger.SetOmitEmpty].
+func (e *Event) ValidationErrors(key string, errs map[string]string) *Event {
+	if e == nil {
+		return e
+	}
+
+	e.fields = append(e.fields, Field{Key: key, Value: newValidationErrors(errs)})
+	return e
+}
+
 // URL adds a field as a clickable terminal hyperlink where the URL is also the display text.
 // Respects the logger's [ColorMode] setting.
 func (e *Event) URL(key, url string) *Event {
@@ -576,6 +1087,78 @@ func (e *Event) URL(key, url string) *Event {
 	return e
 }
 
+// WithoutDefaults skips the logger's default fields (see
+// [Logger.SetDefaultFields]) for this entry. Context fields added with
+// [Logger.With] are still included.
+func (e *Event) WithoutDefaults() *Event {
+	if e == nil {
+		return e
+	}
+
+	e.noDefaults = true
+	return e
+}
+
+// appendDict appends fields to dst with keys prefixed by key and sep,
+// flattening nested [dictFields] recursively.
+func appendDict(dst []Field, key string, fields []Field, sep string) []Field {
+	for _, f := range fields {
+		if nested, ok := f.Value.(dictFields); ok {
+			dst = appendDict(dst, key+sep+f.Key, nested, sep)
+			continue
+		}
+		dst = append(dst, Field{Key: key + sep + f.Key, Value: f.Value})
+	}
+	return dst
+}
+
+// now returns the current time from the clock of the event's logger, or
+// [time.Now] for events not created by a logger (such as Dict).
+func (e *Event) now() time.Time {
+	if e.logger == nil {
+		return time.Now()
+	}
+
+	e.logger.mu.Lock()
+	defer e.logger.mu.Unlock()
+	return e.logger.now()
+}
+
+// resolveElapsed replaces the placeholder values of fields added by
+// [Event.Elapsed] with the time since the first Elapsed call.
+func (e *Event) resolveElapsed() {
+	if len(e.elapsedKeys) == 0 {
+		return
+	}
+
+	d := elapsed(e.now().Sub(e.elapsedStart))
+	for i := range e.fields {
+		if !slices.Contains(e.elapsedKeys, e.fields[i].Key) {
+			continue
+		}
+
+		switch v := e.fields[i].Value.(type) {
+		case elapsed:
+			e.fields[i].Value = d
+		case precise:
+			if _, ok := v.value.(elapsed); ok {
+				v.value = d
+				e.fields[i].Value = v
+			}
+		}
+	}
+}
+
+// verboseEnough reports whether the event's logger (or [Default], for a
+// detached [Dict] event) logs at minLevel or below.
+func (e *Event) verboseEnough(minLevel Level) bool {
+	l := e.logger
+	if l == nil {
+		l = Default
+	}
+	return l.Level() <= minLevel
+}
+
 // withFields appends pre-existing fields to the event (used internally).
 func (e *Event) withFields(fields []Field) *Event {
 	if e == nil {
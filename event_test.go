@@ -2,10 +2,15 @@ package clog
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"math"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -202,6 +207,107 @@ func TestEventAnys(t *testing.T) {
 	assertSliceField(t, e.fields, vals)
 }
 
+func TestEventElapsed(t *testing.T) {
+	e := NewWriter(io.Discard).Info()
+	e.Elapsed("took")
+
+	require.Len(t, e.fields, 1)
+	assert.Equal(t, "took", e.fields[0].Key)
+	assert.Equal(t, elapsed(0), e.fields[0].Value)
+	assert.False(t, e.elapsedStart.IsZero())
+}
+
+func TestEventElapsedOutput(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	e := l.Info().Str("k", "v").Elapsed("took")
+	e.elapsedStart = time.Now().Add(-2 * time.Second)
+	e.Msg("done")
+
+	assert.Equal(t, "INF ℹ️ done k=v took=2s\n", buf.String())
+}
+
+func TestEventElapsedUsesLoggerClock(t *testing.T) {
+	var buf bytes.Buffer
+
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := New(TestOutput(&buf))
+	l.nowFunc = func() time.Time { return clock }
+
+	e := l.Info().Elapsed("took")
+	clock = clock.Add(3 * time.Second)
+	e.Msg("done")
+
+	assert.Equal(t, "INF ℹ️ done took=3s\n", buf.String())
+}
+
+func TestEventElapsedHonoursMinimum(t *testing.T) {
+	tests := []struct {
+		name    string
+		minimum time.Duration
+		want    string
+	}{
+		{"hidden_below_minimum", 5 * time.Second, "INF ℹ️ done\n"},
+		{"shown_at_minimum", 2 * time.Second, "INF ℹ️ done took=2s\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			l := New(TestOutput(&buf))
+			l.SetElapsedMinimum(tt.minimum)
+
+			e := l.Info().Elapsed("took")
+			e.elapsedStart = time.Now().Add(-2 * time.Second)
+			e.Msg("done")
+
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestEventElapsedP(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	e := l.Info().ElapsedP("coarse", 0).ElapsedP("fine", 2).Elapsed("default")
+	e.elapsedStart = time.Now().Add(-3214 * time.Millisecond)
+	e.Msg("done")
+
+	assert.Equal(t, "INF ℹ️ done coarse=3s fine=3.21s default=3s\n", buf.String())
+}
+
+func TestEventElapsedPOverridesGlobalPrecision(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetElapsedPrecision(1)
+	l.SetElapsedRound(0)
+
+	e := l.Info().ElapsedP("a", 0).Elapsed("b")
+	e.elapsedStart = time.Now().Add(-3214 * time.Millisecond)
+	e.Msg("done")
+
+	assert.Equal(t, "INF ℹ️ done a=3s b=3.2s\n", buf.String())
+}
+
+func TestEventElapsedMultipleKeys(t *testing.T) {
+	var got Entry
+
+	l := NewWriter(io.Discard)
+	l.SetHandler(HandlerFunc(func(e Entry) { got = e }))
+
+	e := l.Info().Elapsed("a").Elapsed("b")
+	first := e.elapsedStart
+	e.Msg("done")
+
+	require.Len(t, got.Fields, 2)
+	assert.Equal(t, got.Fields[0].Value, got.Fields[1].Value)
+	assert.Equal(t, first, e.elapsedStart, "start time is fixed by the first call")
+}
+
 func TestEventErrs(t *testing.T) {
 	e := NewWriter(io.Discard).Info()
 	errs := []error{errors.New("a"), nil, errors.New("c")}
@@ -269,6 +375,38 @@ func TestEventDictOutput(t *testing.T) {
 	assert.Equal(t, "INF ℹ️ handled req.method=GET req.status=200\n", buf.String())
 }
 
+func TestEventDictNested(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage, PartFields)
+	l.Info().Dict("req", Dict().
+		Str("method", "GET").
+		Dict("header", Dict().Str("host", "example.com").Dict("x", Dict().Int("n", 1))),
+	).Msg("handled")
+
+	assert.Equal(t, "handled req.method=GET req.header.host=example.com req.header.x.n=1\n", buf.String())
+}
+
+func TestEventDictSeparator(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage, PartFields)
+	l.SetDictSeparator("/")
+	l.Info().Dict("req", Dict().
+		Str("method", "GET").
+		Dict("header", Dict().Str("content.type", "json")),
+	).Msg("handled")
+
+	assert.Equal(t, "handled req/method=GET req/header/content.type=json\n", buf.String())
+
+	buf.Reset()
+	l.SetDictSeparator("")
+	l.Info().Dict("req", Dict().Str("method", "GET")).Msg("reset")
+	assert.Equal(t, "reset req.method=GET\n", buf.String())
+}
+
 func TestEventErr(t *testing.T) {
 	e := NewWriter(io.Discard).Info()
 	err := errors.New("boom")
@@ -287,6 +425,29 @@ func TestEventErrNil(t *testing.T) {
 	assert.Empty(t, e.fields)
 }
 
+func TestEventErrKey(t *testing.T) {
+	e := NewWriter(io.Discard).Info()
+	cause := errors.New("connection reset")
+	e.ErrKey("cause", cause).ErrKey("other", nil)
+
+	require.NoError(t, e.err)
+	assertSingleField(t, e.fields, "cause", cause)
+}
+
+func TestEventErrKeyStyledLikeErr(t *testing.T) {
+	styles := DefaultStyles()
+	opts := formatFieldsOpts{level: InfoLevel, styles: styles}
+
+	var viaErr, viaKey Entry
+	l := NewWriter(io.Discard)
+	l.SetHandler(HandlerFunc(func(e Entry) { viaErr = e }))
+	l.Info().Err(errors.New("a b")).Msg("m")
+	l.SetHandler(HandlerFunc(func(e Entry) { viaKey = e }))
+	l.Info().ErrKey(ErrorKey, errors.New("a b")).Msg("m")
+
+	assert.Equal(t, formatFields(viaErr.Fields, opts), formatFields(viaKey.Fields, opts))
+}
+
 func TestEventErrSendUsesErrorAsMessage(t *testing.T) {
 	var buf bytes.Buffer
 	l := NewWriter(&buf)
@@ -360,6 +521,45 @@ func TestEventJSONMarshalError(t *testing.T) {
 	assert.True(t, isStr, "expected error string value")
 }
 
+func TestEventJSONFields(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Error().
+		JSONFields("error", []byte(`{"status":422,"detail":"bad input","source":{"field":"name"}}`)).
+		Msg("request failed")
+
+	assert.Equal(
+		t,
+		"ERR ❌ request failed error.status=422 error.detail=\"bad input\" error.source.field=name\n",
+		buf.String(),
+	)
+}
+
+func TestEventJSONFieldsStyled(t *testing.T) {
+	styles := DefaultStyles()
+	opts := formatFieldsOpts{
+		level:  InfoLevel,
+		styles: styles,
+	}
+
+	e := Dict().JSONFields("resp", []byte(`{"code":200,"body":{"ok":true}}`))
+	got := formatFields(e.fields, opts)
+
+	key := func(k string) string {
+		return " " + styles.KeyDefault.Render(k) + styles.Separator.Render("=")
+	}
+	want := key("resp.code") + styles.FieldNumber.Render("200") +
+		key("resp.body.ok") + styles.Values[true].Render("true")
+	assert.Equal(t, want, got)
+}
+
+func TestEventJSONFieldsInvalid(t *testing.T) {
+	e := NewWriter(io.Discard).Info()
+	e.JSONFields("body", []byte("not json"))
+	assertSingleField(t, e.fields, "body", "not json")
+}
+
 func TestEventRawJSON(t *testing.T) {
 	e := NewWriter(io.Discard).Info()
 	data := []byte(`{"status":"unprocessable_entity","detail":"something went wrong"}`)
@@ -911,40 +1111,76 @@ func TestEventNilReceiverSafety(t *testing.T) {
 
 	// All field methods should return nil without panic.
 	assert.Nil(t, e.Any("k", "v"))
+	assert.Nil(t, e.AnyAtLevel("k", "v", DebugLevel))
 	assert.Nil(t, e.Anys("k", []any{"v"}))
 	assert.Nil(t, e.Base64("k", []byte("v")))
 	assert.Nil(t, e.Bool("k", true))
 	assert.Nil(t, e.Bools("k", []bool{true}))
 	assert.Nil(t, e.Bytes("k", []byte("v")))
+	assert.Nil(t, e.Cmd("k", "ls"))
 	assert.Nil(t, e.Column("k", "file.go", 1, 1))
 	assert.Nil(t, e.Dict("k", Dict().Str("a", "b")))
+	assert.Nil(t, e.Diff("k", 1, 2))
+	assert.Nil(t, e.DryChange("k", 1, 2))
 	assert.Nil(t, e.Duration("k", time.Second))
+	assert.Nil(t, e.Dur("k", time.Second))
+	assert.Nil(t, e.Durs("k", []time.Duration{time.Second}))
+	assert.Nil(t, e.ErrKey("k", errors.New("x")))
 	assert.Nil(t, e.Durations("k", []time.Duration{time.Second}))
 	assert.Nil(t, e.Err(errors.New("x")))
+	assert.Nil(t, e.Elapsed("k"))
+	assert.Nil(t, e.Enums("k", []fmt.Stringer{testStringer{s: "x"}}))
+	assert.Nil(t, e.ElapsedP("k", 2))
 	assert.Nil(t, e.Errs("k", []error{errors.New("x")}))
+	assert.Nil(t, e.ExecCmd("k", exec.Command("ls")))
 	assert.Nil(t, e.Func(func(*Event) {}))
 	assert.Nil(t, e.Float64("k", 1.0))
 	assert.Nil(t, e.Floats64("k", []float64{1.0}))
 	assert.Nil(t, e.Hex("k", []byte{0xab}))
+	assert.Nil(t, e.HidePart(PartLevel))
 	assert.Nil(t, e.Int("k", 1))
+	assert.Nil(t, e.JSONFields("k", []byte(`{"a":1}`)))
 	assert.Nil(t, e.Int64("k", 1))
 	assert.Nil(t, e.Ints("k", []int{1}))
 	assert.Nil(t, e.Line("k", "file.go", 1))
+	assert.Nil(t, e.Lines("k", "a\nb"))
 	assert.Nil(t, e.Link("k", "https://example.com", "text"))
+	assert.Nil(t, e.Measure("k", 1, "km"))
+	assert.Nil(t, e.MeasureP("k", 1, 1, "km"))
+	assert.Nil(t, e.OnlyParts(PartMessage))
 	assert.Nil(t, e.Path("k", "file.go"))
+	assert.Nil(t, e.Retry(1, 3, time.Second))
 	assert.Nil(t, e.Percent("k", 50))
+	assert.Nil(t, e.PercentP("k", 50, 1))
 	assert.Nil(t, e.Prefix("p"))
 	assert.Nil(t, e.Quantities("k", []string{"10GB"}))
 	assert.Nil(t, e.Quantity("k", "10GB"))
+	assert.Nil(t, e.Rate("k", 1, time.Second))
+	assert.Nil(t, e.Sparkline("k", []float64{1}))
+	assert.Nil(t, e.Stats("k", []float64{1}))
 	assert.Nil(t, e.Str("k", "v"))
+	assert.Nil(t, e.StrAtLevel("k", "v", DebugLevel))
+	assert.Nil(t, e.StrNote("k", "v", "n"))
+	assert.Nil(t, e.StrSep("k", ": ", "v"))
+	assert.Nil(t, e.Caller())
+	assert.Nil(t, e.CallerAt("f.go", 1, "fn"))
+	assert.Nil(t, e.CallerSkip(1))
+	assert.Nil(t, e.Once("k"))
+	assert.Nil(t, e.Deadline("k", t.Context()))
+	assert.Nil(t, e.IntNote("k", 1, "n"))
+	assert.Nil(t, e.Float64Note("k", 1, "n"))
 	assert.Nil(t, e.Stringer("k", testStringer{s: "x"}))
 	assert.Nil(t, e.Stringers("k", []fmt.Stringer{testStringer{s: "x"}}))
 	assert.Nil(t, e.Strs("k", []string{"v"}))
 	assert.Nil(t, e.Time("k", time.Now()))
+	assert.Nil(t, e.Tristate("k", nil))
+	assert.Nil(t, e.Ts("k", time.Now()))
 	assert.Nil(t, e.Uint("k", 1))
 	assert.Nil(t, e.Uint64("k", 1))
 	assert.Nil(t, e.Uints64("k", []uint64{1}))
 	assert.Nil(t, e.URL("k", "https://example.com"))
+	assert.Nil(t, e.ValidationErrors("k", map[string]string{"a": "b"}))
+	assert.Nil(t, e.WithoutDefaults())
 	assert.Nil(t, e.withFields([]Field{{Key: "k", Value: "v"}}))
 	assert.Nil(t, e.withPrefix("p"))
 
@@ -1057,6 +1293,22 @@ func TestEventDurations(t *testing.T) {
 	assertSliceField(t, e.fields, vals)
 }
 
+func TestEventZerologAliases(t *testing.T) {
+	now := time.Now()
+	vals := []time.Duration{time.Second}
+
+	want := NewWriter(io.Discard).Info().
+		Duration("d", time.Second).
+		Durations("ds", vals).
+		Time("t", now)
+	got := NewWriter(io.Discard).Info().
+		Dur("d", time.Second).
+		Durs("ds", vals).
+		Ts("t", now)
+
+	assert.Equal(t, want.fields, got.fields)
+}
+
 func TestEventDurationsOutput(t *testing.T) {
 	var buf bytes.Buffer
 
@@ -1103,172 +1355,1203 @@ func TestEventPercentOutput(t *testing.T) {
 	assert.Equal(t, "INF ℹ️ done progress=75%\n", buf.String())
 }
 
-func TestEventQuantity(t *testing.T) {
-	e := NewWriter(io.Discard).Info()
-	e.Quantity("size", "10GB")
+func TestEventPercentP(t *testing.T) {
+	var buf bytes.Buffer
 
-	require.Len(t, e.fields, 1)
-	assert.Equal(t, "size", e.fields[0].Key)
+	l := New(TestOutput(&buf))
+	l.SetPercentPrecision(1)
+	l.Info().PercentP("cpu", 12.345, 2).Percent("done", 75).PercentP("mem", 150, 0).Msg("stats")
+
+	assert.Equal(t, "INF ℹ️ stats cpu=12.35% done=75.0% mem=100%\n", buf.String())
 }
 
-func TestEventQuantityOutput(t *testing.T) {
+func TestEventPercentPJSON(t *testing.T) {
 	var buf bytes.Buffer
 
-	l := New(TestOutput(&buf))
-	l.Info().Quantity("size", "10GB").Msg("done")
+	l := NewWriter(io.Discard)
+	l.SetHandler(NewJSONHandler(&buf, nil))
+	l.Info().PercentP("cpu", 12.5, 2).Msg("stats")
 
-	assert.Equal(t, "INF ℹ️ done size=10GB\n", buf.String())
+	assert.JSONEq(t, `{"level":"info","prefix":"ℹ️","msg":"stats","cpu":12.5}`, buf.String())
 }
 
-func TestEventQuantities(t *testing.T) {
-	e := NewWriter(io.Discard).Info()
-	e.Quantities("sizes", []string{"10GB", "5MB"})
+func TestEventValidationErrors(t *testing.T) {
+	var buf bytes.Buffer
 
-	require.Len(t, e.fields, 1)
-	assert.Equal(t, "sizes", e.fields[0].Key)
+	l := New(TestOutput(&buf))
+	l.Warn().
+		ValidationErrors("errors", map[string]string{"name": "required", "age": "too large"}).
+		Msg("invalid")
+
+	assert.Equal(t, "WRN ⚠️ invalid errors=[age: too large, name: required]\n", buf.String())
 }
 
-func TestEventQuantitiesOutput(t *testing.T) {
+func TestEventValidationErrorsOmitEmpty(t *testing.T) {
 	var buf bytes.Buffer
 
 	l := New(TestOutput(&buf))
-	l.Info().Quantities("sizes", []string{"10GB", "5MB"}).Msg("test")
+	l.SetOmitEmpty(true)
+	l.Info().ValidationErrors("errors", nil).Msg("ok")
 
-	assert.Equal(t, "INF ℹ️ test sizes=[10GB, 5MB]\n", buf.String())
+	assert.Equal(t, "INF ℹ️ ok\n", buf.String())
 }
 
-func TestEventDictPanicOnMsg(t *testing.T) {
-	assert.PanicsWithValue(t,
-		"clog: Msg/Msgf/Send called on a Dict() event -- pass it to Event.Dict() instead",
-		func() {
-			Dict().Str("k", "v").Msg("boom")
-		},
+func TestEventValidationErrorsStyled(t *testing.T) {
+	withTrueColor(t)
+
+	styles := DefaultStyles()
+	styles.KeyDefault = new(lipgloss.NewStyle().Foreground(lipgloss.Color("4")))
+	styles.FieldError = new(lipgloss.NewStyle().Foreground(lipgloss.Color("1")))
+
+	got := formatValidationErrors(
+		newValidationErrors(map[string]string{"name": "required"}),
+		styles,
 	)
-}
 
-func TestEventDictPanicOnMsgf(t *testing.T) {
-	assert.PanicsWithValue(t,
-		"clog: Msg/Msgf/Send called on a Dict() event -- pass it to Event.Dict() instead",
-		func() {
-			Dict().Str("k", "v").Msgf("boom %s", "arg")
-		},
+	assert.Equal(
+		t,
+		"["+styles.KeyDefault.Render("name")+": "+styles.FieldError.Render("required")+"]",
+		got,
 	)
 }
 
-func TestEventDictPanicOnSend(t *testing.T) {
-	assert.PanicsWithValue(t,
-		"clog: Msg/Msgf/Send called on a Dict() event -- pass it to Event.Dict() instead",
-		func() {
-			Dict().Str("k", "v").Send()
-		},
+func TestEventValidationErrorsJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := NewWriter(io.Discard)
+	l.SetHandler(NewJSONHandler(&buf, nil))
+	l.Warn().ValidationErrors("errors", map[string]string{"age": "too large"}).Msg("invalid")
+
+	assert.JSONEq(
+		t,
+		`{"level":"warn","prefix":"⚠️","msg":"invalid","errors":{"age":"too large"}}`,
+		buf.String(),
 	)
 }
 
-func TestEventDictNilParam(t *testing.T) {
+func TestEventQuantity(t *testing.T) {
 	e := NewWriter(io.Discard).Info()
-	e.Str("before", "x")
-
-	result := e.Dict("group", nil)
+	e.Quantity("size", "10GB")
 
-	assert.Same(t, e, result, "expected same event returned")
-	require.Len(t, e.fields, 1, "nil dict should not add fields")
-	assert.Equal(t, "before", e.fields[0].Key)
+	require.Len(t, e.fields, 1)
+	assert.Equal(t, "size", e.fields[0].Key)
 }
 
-func TestEventStringerTypedNil(t *testing.T) {
-	e := NewWriter(io.Discard).Info()
-	var buf *bytes.Buffer // typed nil that implements fmt.Stringer
+func TestEventQuantityOutput(t *testing.T) {
+	var buf bytes.Buffer
 
-	result := e.Stringer("key", buf)
+	l := New(TestOutput(&buf))
+	l.Info().Quantity("size", "10GB").Msg("done")
 
-	assert.Same(t, e, result, "expected same event returned")
-	assert.Empty(t, e.fields, "typed nil stringer should not add a field")
+	assert.Equal(t, "INF ℹ️ done size=10GB\n", buf.String())
 }
 
-func TestEventEmptyFieldKey(t *testing.T) {
+func TestEventLines(t *testing.T) {
 	var buf bytes.Buffer
 
 	l := New(TestOutput(&buf))
-	l.Info().Str("", "value").Msg("test")
+	l.Info().
+		Int("code", 1).
+		Lines("stdout", "first line\nsecond\nthird\n\n").
+		Msg("done")
+
+	assert.Equal(
+		t,
+		"INF ℹ️ done code=1 stdout=first line\n"+
+			"                          second\n"+
+			"                          third\n",
+		buf.String(),
+	)
+}
 
-	assert.Contains(t, buf.String(), "=value")
+func TestEventLinesRawNewlines(t *testing.T) {
+	var buf bytes.Buffer
+
+	// Only Lines continuations are indented; newlines inside other values
+	// are left alone so that, e.g., a Cmd stays pasteable.
+	l := New(TestOutput(&buf))
+	l.Info().
+		Cmd("cmd", "echo", "a\nb").
+		Lines("out", "x\ny").
+		Msg("done")
+
+	assert.Equal(
+		t,
+		"INF ℹ️ done cmd=echo 'a\nb' out=x\n"+
+			"       y\n",
+		buf.String(),
+	)
 }
 
-func TestEventMsgFatalCallsExit(t *testing.T) {
-	var exitCode int
+func TestEventLinesSingleLine(t *testing.T) {
+	var buf bytes.Buffer
 
-	l := NewWriter(io.Discard)
-	l.SetExitFunc(func(code int) {
-		exitCode = code
-	})
-	l.Fatal().Msg("fatal error")
+	l := New(TestOutput(&buf))
+	l.Info().Lines("out", "hello world\n").Str("s", "hello world").Msg("done")
 
-	assert.Equal(t, 1, exitCode)
+	assert.Equal(t, `INF ℹ️ done out="hello world" s="hello world"`+"\n", buf.String())
 }
 
-func TestEventJSONValid(t *testing.T) {
-	e := NewWriter(io.Discard).Info()
-	e.JSON("key", map[string]int{"a": 1})
+func TestEventLinesWithTreeIndent(t *testing.T) {
+	var buf bytes.Buffer
 
-	require.Len(t, e.fields, 1)
-	assert.Equal(t, "key", e.fields[0].Key)
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage, PartFields)
+	l.indent = "  "
+	l.Info().Lines("out", "a\nb").Msg("run")
 
-	_, ok := e.fields[0].Value.(rawJSON)
-	require.True(t, ok, "expected rawJSON value")
+	assert.Equal(t, "  run out=a\n          b\n", buf.String())
 }
 
-func TestEventJSONNilReceiver(t *testing.T) {
-	var e *Event
-	got := e.JSON("key", map[string]int{"a": 1})
-	assert.Nil(t, got)
+func TestEventLinesStyled(t *testing.T) {
+	withTrueColor(t)
+
+	styles := DefaultStyles()
+	styles.FieldString = new(lipgloss.NewStyle().Bold(true))
+	opts := formatFieldsOpts{
+		level:  InfoLevel,
+		styles: styles,
+	}
+
+	e := NewWriter(io.Discard).Info().Lines("out", "a\nbb")
+	got := formatFields(e.fields, opts)
+
+	want := " " + styles.KeyDefault.Render("out") + styles.Separator.Render("=") +
+		styles.FieldString.Render("a") + "\n    " + styles.FieldString.Render("bb")
+	assert.Equal(t, want, got)
 }
 
-func TestEventJSONMarshalErrorInf(t *testing.T) {
-	e := NewWriter(io.Discard).Info()
-	e.JSON("bad", math.Inf(1))
+func TestEventLinesJSON(t *testing.T) {
+	var buf bytes.Buffer
 
-	require.Len(t, e.fields, 1)
-	assert.Equal(t, "bad", e.fields[0].Key)
+	l := NewWriter(io.Discard)
+	l.SetHandler(NewJSONHandler(&buf, nil))
+	l.Info().Lines("out", "a\nb\n").Msg("done")
 
-	_, isRaw := e.fields[0].Value.(rawJSON)
-	assert.False(t, isRaw, "marshal error should not produce rawJSON")
+	assert.JSONEq(t, `{"level":"info","prefix":"ℹ️","msg":"done","out":"a\nb"}`, buf.String())
+}
 
-	val, isStr := e.fields[0].Value.(string)
-	require.True(t, isStr, "expected error string value")
-	assert.Contains(t, val, "unsupported value")
+func TestEventMeasure(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Info().
+		Measure("distance", 5, "km").
+		Measure("ratio", 5.1, "km").
+		MeasureP("latency", 12.345, 1, "ms").
+		MeasureP("size", 3, 2, "MB").
+		Msg("done")
+
+	assert.Equal(
+		t,
+		"INF ℹ️ done distance=5km ratio=5.1km latency=12.3ms size=3.00MB\n",
+		buf.String(),
+	)
 }
 
-func TestEventRawJSONNilReceiver(t *testing.T) {
-	var e *Event
-	got := e.RawJSON("k", []byte("{}"))
-	assert.Nil(t, got)
+func TestEventMeasurePrecision(t *testing.T) {
+	var buf bytes.Buffer
+
+	a, b := 0.1, 0.2 // variables, as constant arithmetic is exact
+
+	l := New(TestOutput(&buf))
+	l.Info().Measure("d", a+b, "km").Measure("t", -1e-9, "s").Msg("a")
+
+	l.SetMeasurePrecision(1)
+	l.Info().Measure("d", 2.26, "km").MeasureP("p", 2.26, 2, "km").Msg("b")
+
+	l.SetMeasurePrecision(-1)
+	l.Info().Measure("d", a+b, "km").Msg("c")
+
+	assert.Equal(
+		t,
+		"INF ℹ️ a d=0.3km t=0s\n"+
+			"INF ℹ️ b d=2.3km p=2.26km\n"+
+			"INF ℹ️ c d=0.30000000000000004km\n",
+		buf.String(),
+	)
+	assert.Equal(t, "0.3km", jsonHandlerValue(measure(a+b, -1, "km"), JSONOptions{}))
 }
 
-func TestEventInts64(t *testing.T) {
-	e := NewWriter(io.Discard).Info()
-	e.Ints64("nums", []int64{1, 2, 3})
+func TestEventMeasureStyled(t *testing.T) {
+	withTrueColor(t)
 
-	require.Len(t, e.fields, 1)
-	assert.Equal(t, "nums", e.fields[0].Key)
-	assertSliceField(t, e.fields, []int64{1, 2, 3})
+	styles := DefaultStyles()
+	opts := formatFieldsOpts{
+		level:            InfoLevel,
+		measurePrecision: defaultMeasurePrecision,
+		styles:           styles,
+	}
+
+	measured := NewWriter(io.Discard).Info().Measure("d", 5.1, "km")
+	manual := NewWriter(io.Discard).Info().Quantity("d", "5.1km")
+
+	assert.Equal(t, formatFields(manual.fields, opts), formatFields(measured.fields, opts))
 }
 
-func TestEventTimes(t *testing.T) {
-	t1 := time.Date(2025, 6, 15, 10, 0, 0, 0, time.UTC)
-	t2 := time.Date(2025, 6, 16, 12, 0, 0, 0, time.UTC)
-	e := NewWriter(io.Discard).Info()
-	e.Times("timestamps", []time.Time{t1, t2})
+func TestEventRate(t *testing.T) {
+	var buf bytes.Buffer
 
-	require.Len(t, e.fields, 1)
-	assert.Equal(t, "timestamps", e.fields[0].Key)
-	assertSliceField(t, e.fields, []time.Time{t1, t2})
+	l := New(TestOutput(&buf))
+	l.Info().Rate("throughput", 5000, 2*time.Second).Msg("done")
+
+	assert.Equal(t, "INF ℹ️ done throughput=2.5k/s\n", buf.String())
 }
 
-func TestEventUints(t *testing.T) {
-	e := NewWriter(io.Discard).Info()
-	e.Uints("counts", []uint{10, 20, 30})
+func TestEventRateStyled(t *testing.T) {
+	withTrueColor(t)
+
+	styles := DefaultStyles()
+	opts := formatFieldsOpts{
+		level:  InfoLevel,
+		styles: styles,
+	}
+
+	e := NewWriter(io.Discard).Info().Rate("throughput", 5000, 2*time.Second)
+	got := formatFields(e.fields, opts)
+
+	want := " " + styles.KeyDefault.Render("throughput") + styles.Separator.Render("=") +
+		styles.FieldQuantityNumber.Render("2.5") + styles.FieldQuantityUnit.Render("k/s")
+	assert.Equal(t, want, got)
+}
+
+func TestEventStrAtLevel(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Info().
+		StrAtLevel("query", "SELECT 1", DebugLevel).
+		AnyAtLevel("rows", 1, DebugLevel).
+		Int("ms", 3).
+		Msg("query done")
+	assert.Equal(t, "INF ℹ️ query done ms=3\n", buf.String())
+
+	buf.Reset()
+	l.SetLevel(DebugLevel)
+	l.Info().
+		StrAtLevel("query", "SELECT 1", DebugLevel).
+		AnyAtLevel("rows", 1, DebugLevel).
+		Int("ms", 3).
+		Msg("query done")
+	assert.Equal(t, `INF ℹ️ query done query="SELECT 1" rows=1 ms=3`+"\n", buf.String())
+}
+
+func TestEventStrAtLevelTrace(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetLevel(DebugLevel)
+	l.Info().StrAtLevel("dump", "x", TraceLevel).Msg("msg")
+
+	assert.Equal(t, "INF ℹ️ msg\n", buf.String())
+}
+
+func TestEventStrNote(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Info().
+		StrNote("port", "8080", "default").
+		IntNote("workers", 4, "auto").
+		Float64Note("ratio", 0.5, "").
+		StrNote("name", "my app", "from env").
+		Msg("listening")
+
+	assert.Equal(
+		t,
+		`INF ℹ️ listening port=8080 (default) workers=4 (auto) ratio=0.5 name="my app" (from env)`+"\n",
+		buf.String(),
+	)
+}
+
+func TestEventStrNoteStyled(t *testing.T) {
+	withTrueColor(t)
+
+	styles := DefaultStyles()
+	opts := formatFieldsOpts{
+		level:  InfoLevel,
+		styles: styles,
+	}
+
+	e := NewWriter(io.Discard).Info().StrNote("port", "8080", "default")
+	got := formatFields(e.fields, opts)
+
+	want := " " + styles.KeyDefault.Render("port") + styles.Separator.Render("=") +
+		styles.FieldString.Render("8080") + " " + styles.FieldNote.Render("(default)")
+	assert.Equal(t, want, got)
+	assert.Contains(t, styles.FieldNote.Render("x"), "\x1b[2m")
+
+	// Below the field style level, neither the value nor the note is styled.
+	opts.fieldStyleLevel = WarnLevel
+	got = formatFields(e.fields, opts)
+	assert.Equal(t, " "+styles.KeyDefault.Render("port")+styles.Separator.Render("=")+"8080 (default)", got)
+}
+
+func TestEventStrNoteJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := NewWriter(io.Discard)
+	l.SetHandler(NewJSONHandler(&buf, nil))
+	l.Info().IntNote("port", 8080, "default").Msg("listening")
+
+	assert.JSONEq(t, `{"level":"info","prefix":"ℹ️","msg":"listening","port":8080}`, buf.String())
+}
+
+func TestEventStrSep(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Info().
+		Str("method", "GET").
+		StrSep("url", ": ", "https://example.com").
+		StrSep("empty", "", "default").
+		Int("status", 200).
+		Msg("request")
+
+	assert.Equal(
+		t,
+		"INF ℹ️ request method=GET url: https://example.com empty=default status=200\n",
+		buf.String(),
+	)
+}
+
+func TestEventStrSepStyled(t *testing.T) {
+	withTrueColor(t)
+
+	styles := DefaultStyles()
+	opts := formatFieldsOpts{
+		level:  InfoLevel,
+		styles: styles,
+	}
+
+	e := NewWriter(io.Discard).Info().StrSep("url", ": ", "x")
+	got := formatFields(e.fields, opts)
+
+	want := " " + styles.KeyDefault.Render("url") + styles.Separator.Render(": ") +
+		styles.FieldString.Render("x")
+	assert.Equal(t, want, got)
+}
+
+func TestEventStrSepJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := NewWriter(io.Discard)
+	l.SetHandler(NewJSONHandler(&buf, nil))
+	l.Info().StrSep("url", ": ", "https://example.com").Msg("request")
+
+	assert.JSONEq(
+		t,
+		`{"level":"info","prefix":"ℹ️","msg":"request","url":"https://example.com"}`,
+		buf.String(),
+	)
+}
+
+// logViaWrapper is a one-level logging helper for TestEventCallerSkip.
+func logViaWrapper(l *Logger, msg string) {
+	l.Info().CallerSkip(1).Msg(msg)
+}
+
+func TestEventCaller(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartFields)
+
+	_, file, line, _ := runtime.Caller(0)
+	l.Info().Caller().Send()
+
+	assert.Equal(t, "caller="+shortCallerPath(file)+":"+strconv.Itoa(line+1)+"\n", buf.String())
+	assert.Equal(t, "module/event_test.go", shortCallerPath("/src/module/event_test.go"))
+	assert.Equal(t, "event_test.go", shortCallerPath("event_test.go"))
+}
+
+func TestEventCallerSkip(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage, PartFields)
+
+	_, file, line, _ := runtime.Caller(0)
+	logViaWrapper(l, "wrapped")
+
+	// The wrapper's caller is reported, not the wrapper itself.
+	assert.Equal(t, "wrapped caller="+shortCallerPath(file)+":"+strconv.Itoa(line+1)+"\n", buf.String())
+}
+
+func TestEventCallerAt(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartFields)
+	l.Info().CallerAt("gen/schema.proto", 12, "Users.Get").Send()
+	l.Info().CallerAt("bridge.py", 7, "").Send()
+
+	assert.Equal(t, "caller=gen/schema.proto:12 (Users.Get)\ncaller=bridge.py:7\n", buf.String())
+}
+
+func TestEventCallerJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := NewWriter(io.Discard)
+	l.SetHandler(NewJSONHandler(&buf, nil))
+	l.Info().CallerAt("main.go", 3, "main.main").Msg("hi")
+	l.Info().CallerAt("main.go", 4, "").Msg("hi")
+	l.Info().StrNote(CallerKey, "user", "note").Msg("hi")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.JSONEq(t, `{"level":"info","prefix":"ℹ️","msg":"hi","caller":"main.go:3 (main.main)"}`, lines[0])
+	assert.JSONEq(t, `{"level":"info","prefix":"ℹ️","msg":"hi","caller":"main.go:4"}`, lines[1])
+	// Other notes are dropped, whatever the key.
+	assert.JSONEq(t, `{"level":"info","prefix":"ℹ️","msg":"hi","caller":"user"}`, lines[2])
+}
+
+func TestEventOnce(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage)
+	l.Warn().Once("legacy").Msg("first")
+	l.Warn().Once("legacy").Msg("second")
+	l.Warn().Once("other").Msg("other")
+	l.Warn().Msg("plain")
+
+	assert.Equal(t, "first\nother\nplain\n", buf.String())
+}
+
+func TestEventOnceSharedWithSubLoggers(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage)
+	sub := l.With().Str("component", "db").Logger()
+
+	sub.Warn().Once("legacy").Msg("sub")
+	l.Warn().Once("legacy").Msg("parent")
+
+	assert.Equal(t, "sub\n", buf.String())
+}
+
+func TestResetOnce(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage)
+	l.Warn().Once("legacy").Msg("one")
+	l.ResetOnce()
+	l.Warn().Once("legacy").Msg("two")
+
+	assert.Equal(t, "one\ntwo\n", buf.String())
+}
+
+func TestEventSparkline(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Info().Sparkline("latency", []float64{1, 2, 3, 4}).Msg("done")
+
+	assert.Equal(t, "INF ℹ️ done latency=▁▃▆█\n", buf.String())
+}
+
+func TestEventSparklineDegenerate(t *testing.T) {
+	assert.Equal(t, "▅", formatSparkline(sparkline{7}, nil))
+	assert.Equal(t, "▅▅▅", formatSparkline(sparkline{2, 2, 2}, nil))
+	assert.Equal(t, "▁ █", formatSparkline(sparkline{1, math.NaN(), 5}, nil))
+	assert.Equal(t, "▁ █", formatSparkline(sparkline{1, math.Inf(1), 5}, nil))
+	assert.Equal(t, "▁ █", formatSparkline(sparkline{1, math.Inf(-1), 5}, nil))
+	assert.Equal(t, "  ", formatSparkline(sparkline{math.Inf(1), math.Inf(-1)}, nil))
+
+	// The range of finite extremes overflows float64.
+	assert.Equal(t, "▁▅█", formatSparkline(sparkline{-math.MaxFloat64, 0, math.MaxFloat64}, nil))
+}
+
+func TestEventSparklineEmpty(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Info().Sparkline("latency", nil).Msg("done")
+	assert.Equal(t, "INF ℹ️ done latency=\n", buf.String())
+
+	buf.Reset()
+	l.SetOmitEmpty(true)
+	l.Info().Sparkline("latency", []float64{}).Int("runs", 0).Msg("done")
+	assert.Equal(t, "INF ℹ️ done runs=0\n", buf.String())
+}
+
+func TestEventSparklineStyled(t *testing.T) {
+	withTrueColor(t)
+
+	styles := DefaultStyles()
+	got := formatSparkline(sparkline{1, 2}, styles)
+
+	low := interpolateGradient(0, styles.PercentGradient).Clamped().Hex()
+	high := interpolateGradient(1, styles.PercentGradient).Clamped().Hex()
+	want := lipgloss.NewStyle().Foreground(lipgloss.Color(low)).Render("▁") +
+		lipgloss.NewStyle().Foreground(lipgloss.Color(high)).Render("█")
+	assert.Equal(t, want, got)
+	assert.Empty(t, formatSparkline(nil, styles))
+}
+
+func TestEventSparklineJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := NewWriter(io.Discard)
+	l.SetHandler(NewJSONHandler(&buf, nil))
+	l.Info().Sparkline("ms", []float64{1, 3}).Msg("done")
+
+	assert.JSONEq(
+		t,
+		`{"level":"info","prefix":"ℹ️","msg":"done","ms":[1,3]}`,
+		buf.String(),
+	)
+}
+
+func TestEventStats(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetStatsPrecision(1)
+	l.Info().Stats("latency", []float64{3.4, 1.2, 9.9, 2.1, 0.4}).Msg("done")
+
+	assert.Equal(t, "INF ℹ️ done latency=[n=5 min=0.4 avg=3.4 max=9.9]\n", buf.String())
+}
+
+func TestEventStatsDefaultPrecision(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Info().Stats("ms", []float64{1, 2, 2}).Msg("done")
+
+	assert.Equal(t, "INF ℹ️ done ms=[n=3 min=1.00 avg=1.67 max=2.00]\n", buf.String())
+}
+
+func TestEventStatsEmpty(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Info().Stats("latency", nil).Msg("done")
+	assert.Equal(t, "INF ℹ️ done latency=[n=0]\n", buf.String())
+
+	buf.Reset()
+	l.SetOmitEmpty(true)
+	l.Info().Stats("latency", []float64{}).Int("runs", 0).Msg("done")
+	assert.Equal(t, "INF ℹ️ done runs=0\n", buf.String())
+}
+
+func TestEventStatsStyled(t *testing.T) {
+	withTrueColor(t)
+
+	styles := DefaultStyles()
+	opts := formatFieldsOpts{
+		level:          InfoLevel,
+		statsPrecision: 1,
+		styles:         styles,
+	}
+
+	e := NewWriter(io.Discard).Info().Stats("ms", []float64{1, 3})
+	got := formatFields(e.fields, opts)
+
+	stat := func(name, val string) string {
+		return styles.KeyDefault.Render(name) + styles.Separator.Render("=") +
+			styles.FieldNumber.Render(val)
+	}
+	want := " " + styles.KeyDefault.Render("ms") + styles.Separator.Render("=") +
+		"[" + stat("n", "2") + " " + stat("min", "1.0") + " " + stat("avg", "2.0") +
+		" " + stat("max", "3.0") + "]"
+	assert.Equal(t, want, got)
+}
+
+func TestEventStatsJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := NewWriter(io.Discard)
+	l.SetHandler(NewJSONHandler(&buf, nil))
+	l.Info().Stats("ms", []float64{1, 3}).Msg("done")
+
+	assert.JSONEq(
+		t,
+		`{"level":"info","prefix":"ℹ️","msg":"done","ms":{"n":2,"min":1,"avg":2,"max":3}}`,
+		buf.String(),
+	)
+}
+
+func TestEventTristate(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Info().
+		Tristate("db", new(true)).
+		Tristate("cache", new(false)).
+		Tristate("queue", nil).
+		Msg("health")
+
+	assert.Equal(t, "INF ℹ️ health db=true cache=false queue=unknown\n", buf.String())
+}
+
+func TestEventTristateStyled(t *testing.T) {
+	withTrueColor(t)
+
+	styles := DefaultStyles()
+	opts := formatFieldsOpts{
+		level:  InfoLevel,
+		styles: styles,
+	}
+
+	tests := []struct {
+		name string
+		val  *bool
+		want string
+	}{
+		{name: "true", val: new(true), want: styles.Values[true].Render("true")},
+		{name: "false", val: new(false), want: styles.Values[false].Render("false")},
+		{name: "unknown", val: nil, want: styles.Values[nil].Render("unknown")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewWriter(io.Discard).Info().Tristate("ok", tt.val)
+			got := formatFields(e.fields, opts)
+
+			want := " " + styles.KeyDefault.Render("ok") + styles.Separator.Render("=") + tt.want
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestEventTristateJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := NewWriter(io.Discard)
+	l.SetHandler(NewJSONHandler(&buf, nil))
+	l.Info().Tristate("db", new(true)).Tristate("queue", nil).Msg("health")
+
+	assert.JSONEq(
+		t,
+		`{"level":"info","prefix":"ℹ️","msg":"health","db":true,"queue":null}`,
+		buf.String(),
+	)
+}
+
+func TestEventQuantities(t *testing.T) {
+	e := NewWriter(io.Discard).Info()
+	e.Quantities("sizes", []string{"10GB", "5MB"})
+
+	require.Len(t, e.fields, 1)
+	assert.Equal(t, "sizes", e.fields[0].Key)
+}
+
+func TestEventQuantitiesOutput(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Info().Quantities("sizes", []string{"10GB", "5MB"}).Msg("test")
+
+	assert.Equal(t, "INF ℹ️ test sizes=[10GB, 5MB]\n", buf.String())
+}
+
+func TestEventDeadline(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartFields)
+	l.nowFunc = func() time.Time { return testLoggerTime }
+
+	ctx, cancel := context.WithDeadline(t.Context(), testLoggerTime.Add(5*time.Second))
+	defer cancel()
+
+	l.Info().Deadline("deadline", ctx).Send()
+	assert.Equal(t, "deadline=5s\n", buf.String())
+
+	buf.Reset()
+	l.nowFunc = func() time.Time { return testLoggerTime.Add(500 * time.Millisecond) }
+	l.Info().Deadline("deadline", ctx).Send()
+	assert.Equal(t, "deadline=4.5s\n", buf.String())
+
+	buf.Reset()
+	l.nowFunc = func() time.Time { return testLoggerTime.Add(6 * time.Second) }
+	l.Info().Deadline("deadline", ctx).Send()
+	assert.Equal(t, "deadline=-1s\n", buf.String())
+}
+
+func TestEventDeadlineNone(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage, PartFields)
+	l.Info().Deadline("deadline", t.Context()).Int("n", 1).Msg("msg")
+
+	assert.Equal(t, "msg n=1\n", buf.String())
+}
+
+func TestEventDeadlineRealClock(t *testing.T) {
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	e := NewWriter(io.Discard).Info().Deadline("deadline", ctx)
+	require.Len(t, e.fields, 1)
+
+	remaining, ok := e.fields[0].Value.(time.Duration)
+	require.True(t, ok)
+	assert.InDelta(t, float64(5*time.Second), float64(remaining), float64(time.Second))
+}
+
+func TestEventDiff(t *testing.T) {
+	e := NewWriter(io.Discard).Info()
+	e.Diff("replicas", 2, 3)
+
+	require.Len(t, e.fields, 1)
+	assert.Equal(t, "replicas", e.fields[0].Key)
+
+	d, ok := e.fields[0].Value.(diff)
+	require.True(t, ok, "expected diff value")
+	assert.Equal(t, 2, d.before)
+	assert.Equal(t, 3, d.after)
+}
+
+func TestEventDiffOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		oldVal any
+		newVal any
+		want   string
+	}{
+		{"string", "v1.2.0", "v1.3.0", "INF ℹ️ changed version=v1.2.0 → v1.3.0\n"},
+		{"numeric", 2, 3, "INF ℹ️ changed version=2 → 3\n"},
+		{"quoted", "old value", "new", "INF ℹ️ changed version=\"old value\" → new\n"},
+		{"equal", "v1.2.0", "v1.2.0", "INF ℹ️ changed version=v1.2.0\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			l := New(TestOutput(&buf))
+			l.Info().Diff("version", tt.oldVal, tt.newVal).Msg("changed")
+
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestEventDiffOmitEmptyUnchanged(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetOmitEmpty(true)
+	l.Info().Diff("same", 1, 1).Diff("changed", 1, 2).Msg("plan")
+
+	assert.Equal(t, "INF ℹ️ plan changed=1 → 2\n", buf.String())
+}
+
+func TestEventDiffStyled(t *testing.T) {
+	styles := DefaultStyles()
+	opts := formatFieldsOpts{
+		level:  InfoLevel,
+		styles: styles,
+	}
+
+	got := formatFields([]Field{{Key: "k", Value: diff{before: "a", after: "b"}}}, opts)
+
+	want := " " + styles.KeyDefault.Render("k") + styles.Separator.Render("=") +
+		styles.DiffOld.Render("a") + diffArrow + styles.DiffNew.Render("b")
+	assert.Equal(t, want, got)
+}
+
+func TestEventDryChange(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Dry().DryChange("replicas", 3, 5).DryChange("image", "app:v1", "app:v1").Msg("Would scale")
+
+	assert.Equal(t, "DRY 🚧 Would scale replicas: 3 → 5 image: app:v1\n", buf.String())
+}
+
+func TestEventDryChangeStyled(t *testing.T) {
+	withTrueColor(t)
+
+	var buf bytes.Buffer
+
+	styles := DefaultStyles()
+	styles.Messages[DryLevel] = new(lipgloss.NewStyle().Italic(true))
+
+	l := New(NewOutput(&buf, ColorAlways))
+	l.SetStyles(styles)
+	l.SetParts(PartMessage, PartFields)
+	l.Dry().DryChange("replicas", 3, 5).Msg("Would scale")
+
+	dry := lipgloss.NewStyle().Foreground(styles.Levels[DryLevel].GetForeground())
+	want := styles.Messages[DryLevel].Render("Would scale") + " " +
+		styles.KeyDefault.Render("replicas") + styles.Separator.Render(": ") +
+		dry.Render("3"+diffArrow+"5") + "\n"
+	assert.Equal(t, want, buf.String())
+}
+
+func TestEventDictPanicOnMsg(t *testing.T) {
+	assert.PanicsWithValue(t,
+		"clog: Msg/Msgf/Send called on a Dict() event -- pass it to Event.Dict() instead",
+		func() {
+			Dict().Str("k", "v").Msg("boom")
+		},
+	)
+}
+
+func TestEventDictPanicOnMsgf(t *testing.T) {
+	assert.PanicsWithValue(t,
+		"clog: Msg/Msgf/Send called on a Dict() event -- pass it to Event.Dict() instead",
+		func() {
+			Dict().Str("k", "v").Msgf("boom %s", "arg")
+		},
+	)
+}
+
+func TestEventDictPanicOnSend(t *testing.T) {
+	assert.PanicsWithValue(t,
+		"clog: Msg/Msgf/Send called on a Dict() event -- pass it to Event.Dict() instead",
+		func() {
+			Dict().Str("k", "v").Send()
+		},
+	)
+}
+
+func TestEventDictNilParam(t *testing.T) {
+	e := NewWriter(io.Discard).Info()
+	e.Str("before", "x")
+
+	result := e.Dict("group", nil)
+
+	assert.Same(t, e, result, "expected same event returned")
+	require.Len(t, e.fields, 1, "nil dict should not add fields")
+	assert.Equal(t, "before", e.fields[0].Key)
+}
+
+func TestEventStringerTypedNil(t *testing.T) {
+	e := NewWriter(io.Discard).Info()
+	var buf *bytes.Buffer // typed nil that implements fmt.Stringer
+
+	result := e.Stringer("key", buf)
+
+	assert.Same(t, e, result, "expected same event returned")
+	assert.Empty(t, e.fields, "typed nil stringer should not add a field")
+}
+
+func TestEventEmptyFieldKey(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Info().Str("", "value").Msg("test")
+
+	assert.Contains(t, buf.String(), "=value")
+}
+
+func TestEventMsgFatalCallsExit(t *testing.T) {
+	var exitCode int
+
+	l := NewWriter(io.Discard)
+	l.SetExitFunc(func(code int) {
+		exitCode = code
+	})
+	l.Fatal().Msg("fatal error")
+
+	assert.Equal(t, 1, exitCode)
+}
+
+func TestEventJSONValid(t *testing.T) {
+	e := NewWriter(io.Discard).Info()
+	e.JSON("key", map[string]int{"a": 1})
+
+	require.Len(t, e.fields, 1)
+	assert.Equal(t, "key", e.fields[0].Key)
+
+	_, ok := e.fields[0].Value.(rawJSON)
+	require.True(t, ok, "expected rawJSON value")
+}
+
+func TestEventJSONNilReceiver(t *testing.T) {
+	var e *Event
+	got := e.JSON("key", map[string]int{"a": 1})
+	assert.Nil(t, got)
+}
+
+func TestEventJSONMarshalErrorInf(t *testing.T) {
+	e := NewWriter(io.Discard).Info()
+	e.JSON("bad", math.Inf(1))
+
+	require.Len(t, e.fields, 1)
+	assert.Equal(t, "bad", e.fields[0].Key)
+
+	_, isRaw := e.fields[0].Value.(rawJSON)
+	assert.False(t, isRaw, "marshal error should not produce rawJSON")
+
+	val, isStr := e.fields[0].Value.(string)
+	require.True(t, isStr, "expected error string value")
+	assert.Contains(t, val, "unsupported value")
+}
+
+func TestEventRawJSONNilReceiver(t *testing.T) {
+	var e *Event
+	got := e.RawJSON("k", []byte("{}"))
+	assert.Nil(t, got)
+}
+
+func TestEventInts64(t *testing.T) {
+	e := NewWriter(io.Discard).Info()
+	e.Ints64("nums", []int64{1, 2, 3})
+
+	require.Len(t, e.fields, 1)
+	assert.Equal(t, "nums", e.fields[0].Key)
+	assertSliceField(t, e.fields, []int64{1, 2, 3})
+}
+
+func TestEventTimes(t *testing.T) {
+	t1 := time.Date(2025, 6, 15, 10, 0, 0, 0, time.UTC)
+	t2 := time.Date(2025, 6, 16, 12, 0, 0, 0, time.UTC)
+	e := NewWriter(io.Discard).Info()
+	e.Times("timestamps", []time.Time{t1, t2})
+
+	require.Len(t, e.fields, 1)
+	assert.Equal(t, "timestamps", e.fields[0].Key)
+	assertSliceField(t, e.fields, []time.Time{t1, t2})
+}
+
+func TestEventUints(t *testing.T) {
+	e := NewWriter(io.Discard).Info()
+	e.Uints("counts", []uint{10, 20, 30})
 
 	require.Len(t, e.fields, 1)
 	assert.Equal(t, "counts", e.fields[0].Key)
 	assertSliceField(t, e.fields, []uint{10, 20, 30})
 }
+
+func TestEventCmd(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage, PartFields)
+	l.Info().Cmd("cmd", "git", "commit", "-m", "my message", "--author=it's me").Msg("running")
+
+	assert.Equal(t, "running cmd=git commit -m 'my message' '--author=it'\\''s me'\n", buf.String())
+}
+
+func TestEventCmdSpecialChars(t *testing.T) {
+	e := NewWriter(io.Discard).Info()
+	e.Cmd("cmd", "sh", "-c", `echo "$HOME" && ls *.go`, "")
+
+	assertSingleField(t, e.fields, "cmd", command(`sh -c 'echo "$HOME" && ls *.go' ''`))
+}
+
+func TestEventExecCmd(t *testing.T) {
+	c := &exec.Cmd{Path: "/usr/bin/git", Args: []string{"git", "log", "--format=%h %s"}}
+
+	e := NewWriter(io.Discard).Info()
+	e.ExecCmd("cmd", c)
+
+	assertSingleField(t, e.fields, "cmd", command("/usr/bin/git log '--format=%h %s'"))
+}
+
+func TestEventExecCmdNil(t *testing.T) {
+	e := NewWriter(io.Discard).Info()
+	e.ExecCmd("cmd", nil)
+
+	assert.Empty(t, e.fields)
+}
+
+func TestEventCmdStyled(t *testing.T) {
+	styles := DefaultStyles()
+	opts := formatFieldsOpts{
+		level:  InfoLevel,
+		styles: styles,
+	}
+
+	fields := []Field{{Key: "cmd", Value: command("ls -la")}}
+
+	// Falls back to FieldString when FieldCmd is nil.
+	got := formatFields(fields, opts)
+	want := " " + styles.KeyDefault.Render("cmd") + styles.Separator.Render("=") +
+		styles.FieldString.Render("ls -la")
+	assert.Equal(t, want, got)
+
+	styles.FieldCmd = new(lipgloss.NewStyle().Foreground(lipgloss.Color("3")))
+	got = formatFields(fields, opts)
+	want = " " + styles.KeyDefault.Render("cmd") + styles.Separator.Render("=") +
+		styles.FieldCmd.Render("ls -la")
+	assert.Equal(t, want, got)
+}
+
+func TestEventHidePart(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Info().HidePart(PartLevel).HidePart(PartPrefix).Str("k", "v").Msg("summary")
+	l.Info().Str("k", "v").Msg("normal")
+
+	assert.Equal(t, "summary k=v\nINF ℹ️ normal k=v\n", buf.String())
+	assert.Equal(t, DefaultParts(), l.parts)
+}
+
+func TestEventOnlyParts(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Info().OnlyParts(PartFields, PartMessage).Str("k", "v").Msg("summary")
+	l.Info().Msg("normal")
+
+	assert.Equal(t, "k=v summary\nINF ℹ️ normal\n", buf.String())
+}
+
+func TestEventOnlyPartsThenHidePart(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Info().OnlyParts(PartLevel, PartMessage, PartFields).HidePart(PartFields).Str("k", "v").Msg("hello")
+
+	assert.Equal(t, "INF hello\n", buf.String())
+}
+
+func TestEventOnlyPartsEmptyUsesLogger(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Info().OnlyParts().Msg("hello")
+
+	assert.Equal(t, "INF ℹ️ hello\n", buf.String())
+}
+
+func TestEventRetry(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Warn().Retry(2, 5, 4*time.Second).Msg("request failed")
+
+	assert.Equal(t, "WRN ⚠️ request failed attempt=2/5 backoff=4s\n", buf.String())
+}
+
+func TestEventRetryExhausted(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetOmitEmpty(true)
+	l.Error().Retry(5, 5, 0).Msg("giving up")
+
+	assert.Equal(t, "ERR ❌ giving up attempt=5/5\n", buf.String())
+}
+
+func TestEventRetryZeroBackoff(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Error().Retry(5, 5, 0).Msg("giving up")
+
+	assert.Equal(t, "ERR ❌ giving up attempt=5/5 backoff=0s\n", buf.String())
+}
+
+func TestEventRetryStyles(t *testing.T) {
+	withTrueColor(t)
+
+	styles := DefaultStyles()
+	opts := formatFieldsOpts{
+		level:  InfoLevel,
+		styles: styles,
+	}
+	key := " " + styles.KeyDefault.Render("attempt") + styles.Separator.Render("=")
+
+	e := NewWriter(io.Discard).Info().Retry(2, 5, 0)
+	assert.Equal(t, key+styles.FieldNumber.Render("2/5"), formatFields(e.fields[:1], opts))
+
+	// The exhausted attempt falls back to FieldError.
+	e = NewWriter(io.Discard).Info().Retry(5, 5, 0)
+	assert.Equal(t, key+styles.FieldError.Render("5/5"), formatFields(e.fields[:1], opts))
+	assert.NotEqual(t, styles.FieldNumber.Render("5/5"), styles.FieldError.Render("5/5"))
+
+	styles.FieldRetryExhausted = new(lipgloss.NewStyle().Bold(true))
+	assert.Equal(t, key+styles.FieldRetryExhausted.Render("5/5"), formatFields(e.fields[:1], opts))
+}
+
+func TestEventRetryJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := NewWriter(io.Discard)
+	l.SetHandler(NewJSONHandler(&buf, nil))
+	l.Info().Retry(2, 5, 4*time.Second).Msg("retrying")
+
+	assert.JSONEq(t, `{"level":"info","prefix":"ℹ️","msg":"retrying","attempt":"2/5","backoff":"4s"}`, buf.String())
+}
+
+type testStatus int
+
+const (
+	testStatusActive testStatus = iota
+	testStatusFailed
+)
+
+func (s testStatus) String() string {
+	if s == testStatusFailed {
+		return "FAILED"
+	}
+	return "ACTIVE"
+}
+
+func TestEventStringersValueStyles(t *testing.T) {
+	withTrueColor(t)
+
+	styles := DefaultStyles()
+	styles.Values["FAILED"] = new(lipgloss.NewStyle().Foreground(lipgloss.Color("1")))
+	opts := formatFieldsOpts{
+		level:  InfoLevel,
+		styles: styles,
+	}
+
+	e := NewWriter(io.Discard).Info().
+		Stringers("states", []fmt.Stringer{testStatusActive, testStatusFailed})
+	got := formatFields(e.fields, opts)
+
+	want := " " + styles.KeyDefault.Render("states") + styles.Separator.Render("=") +
+		"[" + styles.FieldString.Render("ACTIVE") + ", " + styles.Values["FAILED"].Render("FAILED") + "]"
+	assert.Equal(t, want, got)
+}
+
+func TestEventEnums(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Info().Enums("states", []fmt.Stringer{testStatusActive, testStatusFailed, nil}).Msg("checked")
+
+	assert.Equal(t, "INF ℹ️ checked states=[ACTIVE, FAILED, <nil>]\n", buf.String())
+}
+
+func TestEventEnumsValueStyles(t *testing.T) {
+	withTrueColor(t)
+
+	red := new(lipgloss.NewStyle().Foreground(lipgloss.Color("1")))
+	green := new(lipgloss.NewStyle().Foreground(lipgloss.Color("2")))
+
+	styles := DefaultStyles()
+	styles.Values[testStatusFailed] = red // keyed by the constant
+	styles.Values["ACTIVE"] = green       // keyed by the string
+	opts := formatFieldsOpts{
+		level:  InfoLevel,
+		styles: styles,
+	}
+
+	vals := []fmt.Stringer{testStatusActive, testStatusFailed, testStringer{s: "other"}}
+	e := NewWriter(io.Discard).Info().Enums("states", vals)
+	got := formatFields(e.fields, opts)
+
+	want := " " + styles.KeyDefault.Render("states") + styles.Separator.Render("=") +
+		"[" + green.Render("ACTIVE") + ", " + red.Render("FAILED") + ", " +
+		styles.FieldString.Render("other") + "]"
+	assert.Equal(t, want, got)
+
+	// The builder's slice is copied.
+	vals[0] = testStatusFailed
+	assert.Equal(t, got, formatFields(e.fields, opts))
+}
+
+func TestEventEnumsJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := NewWriter(io.Discard)
+	l.SetHandler(NewJSONHandler(&buf, nil))
+	l.Info().Enums("states", []fmt.Stringer{testStatusActive, testStatusFailed}).Msg("checked")
+
+	assert.JSONEq(t, `{"level":"info","prefix":"ℹ️","msg":"checked","states":["ACTIVE","FAILED"]}`, buf.String())
+}
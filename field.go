@@ -5,6 +5,10 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"os/exec"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -57,6 +61,25 @@ func (fb *fieldBuilder[T]) Bytes(key string, val []byte) *T {
 	return fb.self
 }
 
+// Cmd adds a command line field built from name and args, quoted using
+// POSIX shell rules. See [Event.Cmd].
+func (fb *fieldBuilder[T]) Cmd(key, name string, args ...string) *T {
+	fb.fields = append(fb.fields, Field{Key: key, Value: command(shellJoin(name, args))})
+	return fb.self
+}
+
+// Diff adds a field showing a change from oldVal to newVal, rendered as
+// "old → new" with [Styles.DiffOld] and [Styles.DiffNew]. When both values
+// are equal the value is rendered once, and the field is treated as empty
+// by [Logger.SetOmitEmpty].
+func (fb *fieldBuilder[T]) Diff(key string, oldVal, newVal any) *T {
+	fb.fields = append(fb.fields, Field{Key: key, Value: diff{before: oldVal, after: newVal}})
+	return fb.self
+}
+
+// Dur is an alias for Duration, for familiarity with zerolog.
+func (fb *fieldBuilder[T]) Dur(key string, val time.Duration) *T { return fb.Duration(key, val) }
+
 // Duration adds a [time.Duration] field.
 func (fb *fieldBuilder[T]) Duration(key string, val time.Duration) *T {
 	fb.fields = append(fb.fields, Field{Key: key, Value: val})
@@ -69,6 +92,17 @@ func (fb *fieldBuilder[T]) Durations(key string, vals []time.Duration) *T {
 	return fb.self
 }
 
+// Durs is an alias for Durations, for familiarity with zerolog.
+func (fb *fieldBuilder[T]) Durs(key string, vals []time.Duration) *T {
+	return fb.Durations(key, vals)
+}
+
+// Enums adds a slice field of enum values. See [Event.Enums].
+func (fb *fieldBuilder[T]) Enums(key string, vals []fmt.Stringer) *T {
+	fb.fields = append(fb.fields, Field{Key: key, Value: enums(slices.Clone(vals))})
+	return fb.self
+}
+
 // Err adds an error field with key "error". No-op if err is nil.
 //
 // Unlike [Event.Err], context errors are always stored as a field because
@@ -81,6 +115,15 @@ func (fb *fieldBuilder[T]) Err(err error) *T {
 	return fb.self
 }
 
+// ErrKey adds an error field under the given key. No-op if err is nil.
+func (fb *fieldBuilder[T]) ErrKey(key string, err error) *T {
+	if err == nil {
+		return fb.self
+	}
+	fb.fields = append(fb.fields, Field{Key: key, Value: err})
+	return fb.self
+}
+
 // Errs adds an error slice field. Each error is converted to its message
 // string; nil errors are rendered as [Nil] ("<nil>").
 func (fb *fieldBuilder[T]) Errs(key string, vals []error) *T {
@@ -88,12 +131,28 @@ func (fb *fieldBuilder[T]) Errs(key string, vals []error) *T {
 	return fb.self
 }
 
+// ExecCmd adds a command line field for c, using c.Path and c.Args.
+// See [Event.Cmd]. No-op if c is nil.
+func (fb *fieldBuilder[T]) ExecCmd(key string, c *exec.Cmd) *T {
+	if c == nil {
+		return fb.self
+	}
+	fb.fields = append(fb.fields, Field{Key: key, Value: command(execCmdLine(c))})
+	return fb.self
+}
+
 // Float64 adds a float64 field.
 func (fb *fieldBuilder[T]) Float64(key string, val float64) *T {
 	fb.fields = append(fb.fields, Field{Key: key, Value: val})
 	return fb.self
 }
 
+// Float64Note adds a float64 field followed by a parenthetical note. See [Event.StrNote].
+func (fb *fieldBuilder[T]) Float64Note(key string, val float64, note string) *T {
+	fb.fields = append(fb.fields, Field{Key: key, Value: withNote(val, note)})
+	return fb.self
+}
+
 // Floats64 adds a float64 slice field.
 func (fb *fieldBuilder[T]) Floats64(key string, vals []float64) *T {
 	fb.fields = append(fb.fields, Field{Key: key, Value: vals})
@@ -112,6 +171,12 @@ func (fb *fieldBuilder[T]) Int(key string, val int) *T {
 	return fb.self
 }
 
+// IntNote adds an integer field followed by a parenthetical note. See [Event.StrNote].
+func (fb *fieldBuilder[T]) IntNote(key string, val int, note string) *T {
+	fb.fields = append(fb.fields, Field{Key: key, Value: withNote(val, note)})
+	return fb.self
+}
+
 // Int64 adds an int64 field.
 func (fb *fieldBuilder[T]) Int64(key string, val int64) *T {
 	fb.fields = append(fb.fields, Field{Key: key, Value: val})
@@ -142,6 +207,34 @@ func (fb *fieldBuilder[T]) JSON(key string, val any) *T {
 	return fb.self
 }
 
+// JSONFields parses data as a JSON object and adds each leaf value as an
+// individual field, with nested keys joined to prefix using dot notation.
+// Arrays are kept intact as highlighted JSON. Invalid JSON falls back to a
+// single string field under prefix.
+func (fb *fieldBuilder[T]) JSONFields(prefix string, data []byte) *T {
+	fb.fields = append(fb.fields, jsonFields(prefix, data)...)
+	return fb.self
+}
+
+// Lines adds a multi-line text field; see [Event.Lines].
+func (fb *fieldBuilder[T]) Lines(key, text string) *T {
+	fb.fields = append(fb.fields, Field{Key: key, Value: splitLines(text)})
+	return fb.self
+}
+
+// Measure adds a quantity field built from a numeric value and unit.
+func (fb *fieldBuilder[T]) Measure(key string, val float64, unit string) *T {
+	fb.fields = append(fb.fields, Field{Key: key, Value: measure(val, -1, unit)})
+	return fb.self
+}
+
+// MeasureP is like Measure but renders the value with precision decimal
+// places.
+func (fb *fieldBuilder[T]) MeasureP(key string, val float64, precision int, unit string) *T {
+	fb.fields = append(fb.fields, Field{Key: key, Value: measure(val, max(precision, 0), unit)})
+	return fb.self
+}
+
 // Percent adds a percentage field (0–100) with gradient color styling.
 // Values are clamped to the 0–100 range. The color is interpolated from
 // the [Styles.PercentGradient] stops (default: red → yellow → green).
@@ -150,6 +243,16 @@ func (fb *fieldBuilder[T]) Percent(key string, val float64) *T {
 	return fb.self
 }
 
+// PercentP is like Percent but renders the value with precision decimal
+// places, overriding [Logger.SetPercentPrecision] for this field.
+func (fb *fieldBuilder[T]) PercentP(key string, val float64, precision int) *T {
+	fb.fields = append(fb.fields, Field{Key: key, Value: precise{
+		value:     percent(clampPercent(val)),
+		precision: max(precision, 0),
+	}})
+	return fb.self
+}
+
 // Quantities adds a quantity string slice field. Each element is styled
 // with [Styles.FieldQuantityNumber] and [Styles.FieldQuantityUnit].
 func (fb *fieldBuilder[T]) Quantities(key string, vals []string) *T {
@@ -169,6 +272,13 @@ func (fb *fieldBuilder[T]) Quantity(key, val string) *T {
 	return fb.self
 }
 
+// Rate adds a throughput field of count items per second over the duration
+// per (e.g. "2.5k/s"). See [Event.Rate].
+func (fb *fieldBuilder[T]) Rate(key string, count int64, per time.Duration) *T {
+	fb.fields = append(fb.fields, Field{Key: key, Value: quantity(formatRate(count, per))})
+	return fb.self
+}
+
 // RawJSON adds a field with pre-serialized JSON bytes, emitted verbatim
 // without quoting or escaping. The bytes must be valid JSON.
 func (fb *fieldBuilder[T]) RawJSON(key string, val []byte) *T {
@@ -176,12 +286,44 @@ func (fb *fieldBuilder[T]) RawJSON(key string, val []byte) *T {
 	return fb.self
 }
 
+// Retry adds "attempt" and "backoff" fields for a retry loop. See
+// [Event.Retry].
+func (fb *fieldBuilder[T]) Retry(attempt, maxAttempts int, backoff time.Duration) *T {
+	fb.fields = append(fb.fields, retryFields(attempt, maxAttempts, backoff)...)
+	return fb.self
+}
+
+// Sparkline adds a field rendering vals as a row of block characters
+// scaled to their range, e.g. "▁▃▆█".
+func (fb *fieldBuilder[T]) Sparkline(key string, vals []float64) *T {
+	fb.fields = append(fb.fields, Field{Key: key, Value: sparkline(slices.Clone(vals))})
+	return fb.self
+}
+
+// Stats adds a field summarising vals as "[n=3 min=1 avg=2 max=3]".
+func (fb *fieldBuilder[T]) Stats(key string, vals []float64) *T {
+	fb.fields = append(fb.fields, Field{Key: key, Value: newStats(vals)})
+	return fb.self
+}
+
 // Str adds a string field.
 func (fb *fieldBuilder[T]) Str(key, val string) *T {
 	fb.fields = append(fb.fields, Field{Key: key, Value: val})
 	return fb.self
 }
 
+// StrNote adds a string field followed by a parenthetical note. See [Event.StrNote].
+func (fb *fieldBuilder[T]) StrNote(key, val, note string) *T {
+	fb.fields = append(fb.fields, Field{Key: key, Value: withNote(val, note)})
+	return fb.self
+}
+
+// StrSep adds a string field with a custom key/value separator. See [Event.StrSep].
+func (fb *fieldBuilder[T]) StrSep(key, sep, val string) *T {
+	fb.fields = append(fb.fields, Field{Key: key, Value: withSep(val, sep)})
+	return fb.self
+}
+
 // Stringer adds a field by calling the value's String method. No-op if val is nil.
 func (fb *fieldBuilder[T]) Stringer(key string, val fmt.Stringer) *T {
 	if isNilStringer(val) {
@@ -223,6 +365,15 @@ func (fb *fieldBuilder[T]) Times(key string, vals []time.Time) *T {
 	return fb.self
 }
 
+// Tristate adds a tri-state bool field; nil renders as "unknown".
+func (fb *fieldBuilder[T]) Tristate(key string, val *bool) *T {
+	fb.fields = append(fb.fields, Field{Key: key, Value: tristateValue(val)})
+	return fb.self
+}
+
+// Ts is an alias for Time, for familiarity with zerolog.
+func (fb *fieldBuilder[T]) Ts(key string, val time.Time) *T { return fb.Time(key, val) }
+
 // Uint adds a uint field.
 func (fb *fieldBuilder[T]) Uint(key string, val uint) *T {
 	fb.fields = append(fb.fields, Field{Key: key, Value: val})
@@ -247,8 +398,67 @@ func (fb *fieldBuilder[T]) Uints64(key string, vals []uint64) *T {
 	return fb.self
 }
 
+// ValidationErrors adds a field listing validation errors keyed by field
+// path. Paths are sorted; see [Event.ValidationErrors].
+func (fb *fieldBuilder[T]) ValidationErrors(key string, errs map[string]string) *T {
+	fb.fields = append(fb.fields, Field{Key: key, Value: newValidationErrors(errs)})
+	return fb.self
+}
+
 func (fb *fieldBuilder[T]) initSelf(s *T) { fb.self = s }
 
+// newValidationErrors converts a path → message map into sorted
+// [validationErrors].
+func newValidationErrors(errs map[string]string) validationErrors {
+	v := make(validationErrors, 0, len(errs))
+	for _, path := range slices.Sorted(maps.Keys(errs)) {
+		v = append(v, Field{Key: path, Value: errs[path]})
+	}
+	return v
+}
+
+// splitLines returns text as [textLines] when, after trimming trailing
+// newlines, it spans more than one line, else as a plain string.
+func splitLines(text string) any {
+	text = strings.TrimRight(text, "\n")
+	if !strings.Contains(text, "\n") {
+		return text
+	}
+	return textLines(strings.Split(text, "\n"))
+}
+
+// measure builds the [measurement] for val in unit, with a fixed precision
+// in decimal places (negative = the logger's [Logger.SetMeasurePrecision]).
+func measure(val float64, precision int, unit string) measurement {
+	return measurement{value: val, unit: unit, precision: precision}
+}
+
+// retryFields builds the fields of [Event.Retry].
+func retryFields(current, total int, delay time.Duration) []Field {
+	return []Field{
+		{Key: retryAttemptKey, Value: attempt{current: current, total: total}},
+		{Key: retryBackoffKey, Value: backoff(delay)},
+	}
+}
+
+// newStats computes the count, minimum, mean and maximum of vals.
+func newStats(vals []float64) stats {
+	if len(vals) == 0 {
+		return stats{}
+	}
+
+	var sum float64
+	for _, v := range vals {
+		sum += v
+	}
+	return stats{
+		count:   len(vals),
+		minimum: slices.Min(vals),
+		mean:    sum / float64(len(vals)),
+		maximum: slices.Max(vals),
+	}
+}
+
 // errSliceToStrings converts a slice of errors to a slice of strings.
 // Nil errors are rendered as [Nil] ("<nil>").
 func errSliceToStrings(errs []error) []string {
@@ -33,6 +33,17 @@ func TestFieldBuilderInt64(t *testing.T) {
 	assertSingleField(t, b.fields, "count", int64(42))
 }
 
+func TestFieldBuilderZerologAliases(t *testing.T) {
+	now := time.Now()
+
+	b := Spinner("test").Dur("d", time.Second).Durs("ds", []time.Duration{time.Second}).Ts("t", now)
+	assert.Equal(t, []Field{
+		{Key: "d", Value: time.Second},
+		{Key: "ds", Value: []time.Duration{time.Second}},
+		{Key: "t", Value: now},
+	}, b.fields)
+}
+
 func TestFieldBuilderUint(t *testing.T) {
 	b := Spinner("test").Uint("size", 100)
 	assertSingleField(t, b.fields, "size", uint(100))
@@ -68,6 +79,19 @@ func TestFieldBuilderErrs(t *testing.T) {
 	assert.Equal(t, []string{"a", "<nil>", "c"}, vals)
 }
 
+func TestFieldBuilderErrKey(t *testing.T) {
+	err := errors.New("boom")
+	b := Spinner("test").ErrKey("cause", err).ErrKey("skipped", nil)
+
+	assertSingleField(t, b.fields, "cause", error(err))
+}
+
+func TestFieldBuilderPercentP(t *testing.T) {
+	b := Spinner("test").PercentP("pct", 33.333, 1)
+
+	assertSingleField(t, b.fields, "pct", any(precise{value: percent(33.333), precision: 1}))
+}
+
 func TestFieldBuilderPercent(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1,7 +1,11 @@
 package clog
 
 import (
+	"cmp"
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"math"
 	"reflect"
 	"slices"
@@ -9,47 +13,284 @@ import (
 	"strings"
 	"time"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/lucasb-eyer/go-colorful"
 )
 
+// command wraps a shell-quoted command line so [formatValue] can identify
+// it for [Styles.FieldCmd] styling and exempt it from field quoting.
+type command string
+
+// dictFields holds the fields of a [Dict] nested inside another detached
+// dict. They are flattened by [appendDict] once the separator is known.
+type dictFields []Field
+
+// diff wraps a before/after value pair so [formatValue] can identify it
+// for diff styling with [Styles.DiffOld] and [Styles.DiffNew]. A dry diff,
+// from [Event.DryChange], is a planned change and is rendered in the
+// [DryLevel] colour instead.
+type diff struct {
+	before any
+	after  any
+	dry    bool
+}
+
+// MarshalJSON encodes the diff as {"old":...,"new":...} so custom handlers
+// that serialise [Entry.Fields] retain both values.
+func (d diff) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Old any `json:"old"`
+		New any `json:"new"`
+	}{d.before, d.after})
+}
+
+// unchanged reports whether the before and after values are deeply equal.
+func (d diff) unchanged() bool {
+	return reflect.DeepEqual(d.before, d.after)
+}
+
 // elapsed wraps a [time.Duration] so [formatValue] can identify it
 // for elapsed-time styling with [Styles.FieldElapsedNumber] and
 // [Styles.FieldElapsedUnit].
 type elapsed time.Duration
 
+// backoff wraps the [time.Duration] of [Event.Retry] so that a zero
+// backoff, as after the final attempt, counts as empty under
+// [Logger.SetOmitEmpty]. It is otherwise rendered like any duration.
+type backoff time.Duration
+
+func (b backoff) String() string { return time.Duration(b).String() }
+
 // percent wraps a float64 value (0–100) so [formatValue] can identify it
 // for percentage styling with gradient colors.
 type percent float64
 
+// precise pairs an [elapsed] or [percent] value with a per-field number of
+// decimal places that overrides the logger-wide precision.
+type precise struct {
+	value     any
+	precision int
+}
+
+// unwrapPrecise returns the value inside a [precise] wrapper and its
+// precision, or v and -1 when v is not wrapped.
+func unwrapPrecise(v any) (any, int) {
+	if p, ok := v.(precise); ok {
+		return p.value, p.precision
+	}
+	return v, -1
+}
+
+// noted pairs a field value with a parenthetical note rendered after it
+// (e.g. "port=8080 (default)"), and/or a key/value separator that overrides
+// the logger's for that field. See [Event.StrNote] and [Event.StrSep].
+type noted struct {
+	value any
+	note  string
+	sep   string // "" = the logger's separator
+}
+
+// unwrapNoted returns the value inside a [noted] wrapper and its note, or v
+// and "" when v is not wrapped.
+func unwrapNoted(v any) (any, string) {
+	if n, ok := v.(noted); ok {
+		return n.value, n.note
+	}
+	return v, ""
+}
+
+// withNote wraps v with note, or returns v unchanged when note is empty.
+func withNote(v any, note string) any {
+	if note == "" {
+		return v
+	}
+	return noted{value: v, note: note}
+}
+
+// withSep wraps v with a separator override, or returns v unchanged when
+// sep is empty.
+func withSep(v any, sep string) any {
+	if sep == "" {
+		return v
+	}
+	return noted{value: v, sep: sep}
+}
+
+// callerSite is the value of an [Event.CallerAt] field with a function:
+// the rendered location and the function at it. The pretty formatter shows
+// the function as a note; other handlers keep it as "location (function)".
+type callerSite struct {
+	location string
+	function string
+}
+
+func (c callerSite) String() string { return StripANSI(c.location) + " (" + c.function + ")" }
+
+// validationErrors holds path → message pairs from [Event.ValidationErrors],
+// sorted by path. Each Field's Value is the message string.
+type validationErrors []Field
+
 // quantity wraps a string value with numeric and unit segments (e.g. "5m",
 // "5.1km", "100MB") so [formatValue] can identify it for quantity styling.
 type quantity string
 
+// defaultMeasurePrecision is the default [Logger.SetMeasurePrecision].
+const defaultMeasurePrecision = 6
+
+// measurement is a numeric value and unit from [Event.Measure]. It is
+// formatted as a [quantity] when rendered, so the logger's measure
+// precision applies. A non-negative precision, from [Event.MeasureP], is a
+// fixed number of decimal places that overrides the logger's.
+type measurement struct {
+	value     float64
+	unit      string
+	precision int
+}
+
+// format returns m as a quantity with at most maxPrecision decimal places
+// and trailing zeros trimmed (negative = fewest digits that represent the
+// value exactly), or with m's fixed precision when set.
+func (m measurement) format(maxPrecision int) quantity {
+	if m.precision >= 0 {
+		return quantity(strconv.FormatFloat(m.value, 'f', m.precision, 64) + m.unit)
+	}
+
+	s := strconv.FormatFloat(m.value, 'f', maxPrecision, 64)
+	if maxPrecision > 0 {
+		s = strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+	}
+	if s == "-0" {
+		s = "0"
+	}
+	return quantity(s + m.unit)
+}
+
+// String formats m with the default precision, for handlers other than
+// the pretty formatter.
+func (m measurement) String() string { return string(m.format(defaultMeasurePrecision)) }
+
 // rawJSON wraps pre-serialized JSON bytes so [formatValue] can emit them
 // verbatim without quoting or escaping.
 type rawJSON []byte
 
+// stats holds the aggregate of an [Event.Stats] slice, computed once when
+// the field is added. It renders as "[n=3 min=1 avg=2 max=3]".
+type stats struct {
+	count   int
+	minimum float64
+	mean    float64
+	maximum float64
+}
+
+// boolWords holds the words set by [Logger.SetBoolWords]. An empty word
+// falls back to "true" or "false".
+type boolWords struct {
+	yes, no string
+}
+
+// format returns the word for v.
+func (w boolWords) format(v bool) string {
+	if v {
+		return cmp.Or(w.yes, "true")
+	}
+	return cmp.Or(w.no, "false")
+}
+
+// sparkline holds the values of an [Event.Sparkline] field, rendered as a
+// row of block characters scaled to the range of the values.
+type sparkline []float64
+
+// enums holds the values of an [Event.Enums] field. Unlike the []string of
+// [Event.Stringers], the original values are kept so [Styles.Values] can be
+// keyed by the enum constants themselves.
+type enums []fmt.Stringer
+
+// strings returns the String of each value, or [Nil] for nil values.
+func (vals enums) strings() []string {
+	strs := make([]string, len(vals))
+	for i, v := range vals {
+		if isNilStringer(v) {
+			strs[i] = Nil
+		} else {
+			strs[i] = v.String()
+		}
+	}
+	return strs
+}
+
+// attempt is the counter of an [Event.Retry] field. It renders as "2/5";
+// the final attempt is styled with [Styles.FieldRetryExhausted].
+type attempt struct {
+	current int
+	total   int
+}
+
+func (a attempt) String() string { return formatStep(a.current, a.total) }
+
+// MarshalText implements [encoding.TextMarshaler], so handlers encode the
+// attempt as "2/5".
+func (a attempt) MarshalText() ([]byte, error) { return []byte(a.String()), nil }
+
+// exhausted reports whether a is the final attempt.
+func (a attempt) exhausted() bool { return a.current >= a.total }
+
+// textLines holds the lines of an [Event.Lines] field. Continuation lines
+// are indented to the column of the first, which starts after "key=".
+type textLines []string
+
+// unknownBool is the value of a [Event.Tristate] field whose state is not
+// known. It renders as "unknown" with the [Styles.Values] style for nil.
+type unknownBool struct{}
+
+func (unknownBool) String() string { return "unknown" }
+
+// tristateValue returns the field value for a tri-state bool: the bool
+// itself, or [unknownBool] when v is nil.
+func tristateValue(v *bool) any {
+	if v == nil {
+		return unknownBool{}
+	}
+	return *v
+}
+
 // formatFieldsOpts configures field formatting behaviour.
 type formatFieldsOpts struct {
-	elapsedFormatFunc       func(time.Duration) string
-	elapsedMinimum          time.Duration
-	elapsedPrecision        int
-	elapsedRound            time.Duration
-	fieldSort               Sort
-	fieldStyleLevel         Level
-	level                   Level
-	noColor                 bool
-	percentFormatFunc       func(float64) string
-	percentPrecision        int
-	quantityUnitsIgnoreCase bool
-	quoteOpen               rune // 0 means default ('"' via strconv.Quote)
-	quoteClose              rune // 0 means same as quoteOpen (or default)
-	quoteMode               QuoteMode
-	separatorText           string
-	styles                  *Styles
-	timeFormat              string
+	autoColorKeys              map[string]bool
+	boolWords                  boolWords
+	durationColumnWidth        int
+	durationUsesQuantityStyles bool
+	elapsedFormatFunc          func(time.Duration) string
+	elapsedMinimum             time.Duration
+	elapsedPrecision           int
+	elapsedRound               time.Duration
+	emptyRepr                  string
+	fieldKeyAlign              bool
+	fieldOrder                 []string
+	fieldSort                  Sort
+	fieldStyleLevel            Level
+	indent                     int // column at which the fields start; continuation lines are padded past it
+	keyTruncate                map[string]truncateSpec
+	level                      Level
+	measurePrecision           int
+	nilRepr                    string
+	noColor                    bool
+	numberGrouping             rune
+	percentFormatFunc          func(float64) string
+	percentPrecision           int
+	quantityColumnWidth        int
+	quantityThousandsSep       rune
+	quantityUnitsIgnoreCase    bool
+	quoteOpen                  rune // 0 means default ('"' via strconv.Quote)
+	quoteClose                 rune // 0 means same as quoteOpen (or default)
+	quoteMode                  QuoteMode
+	separatorText              string
+	statsPrecision             int
+	styles                     *Styles
+	timeFormat                 string
+	wrapWidth                  int // columns available to the fields; 0 = no slice wrapping
 }
 
 // valueKind classifies a formatted value for type-based styling.
@@ -58,6 +299,8 @@ type valueKind int
 const (
 	kindDefault valueKind = iota
 	kindBool
+	kindCmd
+	kindDiff
 	kindDuration
 	kindElapsed
 	kindError
@@ -70,7 +313,78 @@ const (
 	kindTime
 )
 
+// ValueKind is the type category clog assigns to a field value when
+// choosing its style. See [ResolveValueStyle].
+type ValueKind int
+
+// Value kinds reported by [ResolveValueStyle]. The values are stable and
+// independent of clog's internal ordering.
 const (
+	ValueKindDefault  ValueKind = 0  // no type-based style
+	ValueKindBool     ValueKind = 1  // bool
+	ValueKindCmd      ValueKind = 2  // command line from Cmd/ExecCmd
+	ValueKindDiff     ValueKind = 3  // before/after value from Diff
+	ValueKindDuration ValueKind = 4  // time.Duration
+	ValueKindElapsed  ValueKind = 5  // elapsed time from Elapsed
+	ValueKindError    ValueKind = 6  // error
+	ValueKindJSON     ValueKind = 7  // JSON from JSON/RawJSON
+	ValueKindNumber   ValueKind = 8  // integer or float
+	ValueKindPercent  ValueKind = 9  // percentage from Percent
+	ValueKindQuantity ValueKind = 10 // quantity from Quantity
+	ValueKindSlice    ValueKind = 11 // slice (elements are styled individually)
+	ValueKindString   ValueKind = 12 // string
+	ValueKindTime     ValueKind = 13 // time.Time
+)
+
+// publicValueKinds maps each internal [valueKind] to its [ValueKind].
+var publicValueKinds = [...]ValueKind{
+	kindDefault:  ValueKindDefault,
+	kindBool:     ValueKindBool,
+	kindCmd:      ValueKindCmd,
+	kindDiff:     ValueKindDiff,
+	kindDuration: ValueKindDuration,
+	kindElapsed:  ValueKindElapsed,
+	kindError:    ValueKindError,
+	kindJSON:     ValueKindJSON,
+	kindNumber:   ValueKindNumber,
+	kindPercent:  ValueKindPercent,
+	kindQuantity: ValueKindQuantity,
+	kindSlice:    ValueKindSlice,
+	kindString:   ValueKindString,
+	kindTime:     ValueKindTime,
+}
+
+// ResolveValueStyle returns the style clog would apply to a field value,
+// and the value's kind, using the same priority as the pretty formatter:
+// [Styles.Keys], then [Styles.Values] and [Styles.ValuePatterns], then the
+// type-based field style. This lets custom handlers colour values
+// consistently with clog.
+//
+// The field style level (see [Logger.SetFieldStyleLevel]) is not applied;
+// handlers that honour it should skip styling entries below their level.
+// The style is nil when no style applies. Kinds styled per segment or by
+// gradient (durations, elapsed times, quantities, percentages and JSON) and
+// slices, which are styled per element, resolve to nil unless a key or
+// value style matches.
+func ResolveValueStyle(key string, value any, styles *Styles) (Style, ValueKind) {
+	value, _ = unwrapNoted(value)
+	value = unwrapSQLNull(value)
+	value, _ = unwrapPrecise(value)
+
+	_, kind := formatValue(value, QuoteNever, 0, 0, "", 0, 0)
+	if styles == nil {
+		return nil, publicValueKinds[kind]
+	}
+	return valueStyle(value, key, kind, styles), publicValueKinds[kind]
+}
+
+const (
+	diffArrow = " → "
+
+	// dryChangeSep separates the key from the value of [Event.DryChange]
+	// fields, so that planned changes read "replicas: 3 → 5".
+	dryChangeSep = ": "
+
 	percentMax = 100.0
 
 	sliceOpen  = '['
@@ -78,6 +392,62 @@ const (
 	sliceSep   = ", "
 )
 
+// truncateSpec holds the number of leading and trailing runes kept by
+// [Logger.SetKeyTruncate].
+type truncateSpec struct {
+	head, tail int
+}
+
+// truncateMiddle shortens s to its first head and last tail runes joined by
+// an ellipsis. Returns s unchanged when it is no longer than the result.
+func truncateMiddle(s string, head, tail int) string {
+	runes := []rune(s)
+	if len(runes) <= head+tail+1 {
+		return s
+	}
+	return string(runes[:head]) + "…" + string(runes[len(runes)-tail:])
+}
+
+// truncateText shortens s to maxLen columns, including the "…" marking the
+// cut, keeping its start ([TruncateEnd]) or both ends ([TruncateMiddle]).
+// Widths ignore ANSI escapes. Returns s unchanged when maxLen is 0 or s
+// already fits.
+func truncateText(s string, maxLen int, mode TruncateMode) string {
+	width := ansi.StringWidth(s)
+	if maxLen <= 0 || width <= maxLen {
+		return s
+	}
+
+	if mode != TruncateMiddle {
+		return ansi.Truncate(s, maxLen, "…")
+	}
+
+	keep := maxLen - 1
+	head, tail := (keep+1)/2, keep/2
+	return ansi.Truncate(s, head, "") + "…" + ansi.TruncateLeft(s, width-tail, "")
+}
+
+// orderFields returns fields with the keys in order moved to the front, in
+// that order. Other fields keep their relative order. fields is cloned
+// rather than reordered in place.
+func orderFields(fields []Field, order []string) []Field {
+	rank := func(f Field) int {
+		if i := slices.Index(order, f.Key); i >= 0 {
+			return i
+		}
+		return len(order)
+	}
+	if !slices.ContainsFunc(fields, func(f Field) bool { return rank(f) < len(order) }) {
+		return fields
+	}
+
+	fields = slices.Clone(fields)
+	slices.SortStableFunc(fields, func(a, b Field) int {
+		return cmp.Compare(rank(a), rank(b))
+	})
+	return fields
+}
+
 // formatFields formats fields for display.
 // Returns an empty string if fields is empty.
 func formatFields(fields []Field, opts formatFieldsOpts) string {
@@ -85,7 +455,9 @@ func formatFields(fields []Field, opts formatFieldsOpts) string {
 		return ""
 	}
 
-	if opts.fieldSort != SortNone {
+	// SortNoneThenAscending is applied in [Logger.log], where logger and
+	// event fields are still separate.
+	if opts.fieldSort == SortAscending || opts.fieldSort == SortDescending {
 		fields = slices.Clone(fields)
 		slices.SortFunc(fields, func(a, b Field) int {
 			cmp := strings.Compare(a.Key, b.Key)
@@ -96,15 +468,41 @@ func formatFields(fields []Field, opts formatFieldsOpts) string {
 		})
 	}
 
+	if len(opts.fieldOrder) > 0 {
+		fields = orderFields(fields, opts.fieldOrder)
+	}
+
+	var keyWidth int
+	if opts.fieldKeyAlign {
+		for _, f := range fields {
+			keyWidth = max(keyWidth, lipgloss.Width(f.Key))
+		}
+	}
+
 	var buf strings.Builder
 
 	for i := range fields {
 		f := fields[i]
 
+		var note, fieldSep string
+		if n, ok := f.Value.(noted); ok {
+			fieldSep = n.sep
+		}
+		f.Value, note = unwrapNoted(f.Value)
+		f.Value = unwrapSQLNull(f.Value)
+		if c, ok := f.Value.(callerSite); ok {
+			f.Value, note = c.location, c.function
+		}
+
+		var precision int
+		f.Value, precision = unwrapPrecise(f.Value)
+
 		// Elapsed pre-processing: round, apply minimum threshold, update value.
+		// A per-field precision replaces rounding, as the formatted digits
+		// already determine the displayed resolution.
 		if val, ok := f.Value.(elapsed); ok {
 			d := time.Duration(val)
-			if opts.elapsedRound > 0 {
+			if opts.elapsedRound > 0 && precision < 0 {
 				d = d.Round(opts.elapsedRound)
 			}
 			if d < opts.elapsedMinimum {
@@ -115,9 +513,9 @@ func formatFields(fields []Field, opts formatFieldsOpts) string {
 
 		buf.WriteString(" ")
 
-		sep := opts.separatorText
-		if sep == "" {
-			sep = "="
+		sep := cmp.Or(fieldSep, opts.separatorText, "=")
+		if d, ok := f.Value.(diff); ok && d.dry {
+			sep = dryChangeSep
 		}
 
 		if !opts.noColor && opts.styles != nil && opts.styles.KeyDefault != nil {
@@ -126,55 +524,263 @@ func formatFields(fields []Field, opts formatFieldsOpts) string {
 			buf.WriteString(f.Key)
 		}
 
+		if opts.fieldKeyAlign {
+			buf.WriteString(strings.Repeat(" ", keyWidth-lipgloss.Width(f.Key)))
+		}
+
 		if !opts.noColor && opts.styles != nil && opts.styles.Separator != nil {
 			buf.WriteString(opts.styles.Separator.Render(sep))
 		} else {
 			buf.WriteString(sep)
 		}
 
-		percentPrecision := opts.percentPrecision
-		elapsedPrecision := opts.elapsedPrecision
+		// Each line is styled separately; continuation lines are padded to
+		// the value column.
+		if ls, ok := f.Value.(textLines); ok {
+			pad := continuationPad(buf.String(), opts)
+			for i, line := range ls {
+				if i > 0 {
+					buf.WriteString(pad)
+				}
+				buf.WriteString(styledFieldValue(Field{Key: f.Key, Value: line}, line, kindString, opts))
+			}
+			continue
+		}
 
-		var valStr string
-		var kind valueKind
-		var customFormatted bool
-		switch val := f.Value.(type) {
-		case elapsed:
-			if opts.elapsedFormatFunc != nil {
-				valStr = opts.elapsedFormatFunc(time.Duration(val))
-				kind = kindElapsed
-				customFormatted = true
+		styled, kind := formatFieldValue(f, precision, opts)
+		// Multi-line JSON keeps its indentation relative to the value column.
+		if kind == kindJSON && strings.Contains(styled, "\n") {
+			styled = strings.ReplaceAll(styled, "\n", continuationPad(buf.String(), opts))
+		}
+		if kind == kindSlice && opts.wrapWidth > 0 {
+			col := fieldsColumn(buf.String(), opts.indent)
+			if col+lipgloss.Width(styled) > opts.indent+opts.wrapWidth {
+				if wrapped, ok := wrapSlice(f, precision, col, opts); ok {
+					styled = wrapped
+				}
 			}
-		case percent:
-			if opts.percentFormatFunc != nil {
-				valStr = opts.percentFormatFunc(float64(val))
-				kind = kindPercent
-				customFormatted = true
+		}
+		buf.WriteString(styled)
+
+		if note != "" {
+			buf.WriteString(" ")
+			var noteStyle Style
+			if !opts.noColor && opts.styles != nil && opts.level >= opts.fieldStyleLevel {
+				noteStyle = opts.styles.FieldNote
 			}
+			emitStyled(&buf, "("+note+")", noteStyle)
+		}
+	}
+	return buf.String()
+}
+
+// formatFieldValue formats and styles the value of f, whose [precise]
+// precision (or -1) has already been unwrapped, returning the result and
+// its kind.
+func formatFieldValue(f Field, precision int, opts formatFieldsOpts) (string, valueKind) {
+	percentPrecision := opts.percentPrecision
+	elapsedPrecision := opts.elapsedPrecision
+	if precision >= 0 {
+		percentPrecision, elapsedPrecision = precision, precision
+	}
+
+	var valStr string
+	var kind valueKind
+	var customFormatted, verbatim bool
+	switch val := f.Value.(type) {
+	case nil:
+		if opts.nilRepr != "" {
+			valStr = opts.nilRepr
+			customFormatted, verbatim = true, true
 		}
-		if !customFormatted {
-			valStr, kind = formatValue(
-				f.Value,
-				opts.quoteMode,
-				opts.quoteOpen,
-				opts.quoteClose,
-				opts.timeFormat,
-				percentPrecision,
-				elapsedPrecision,
+	case string:
+		if val == "" && opts.emptyRepr != "" {
+			valStr = opts.emptyRepr
+			customFormatted, verbatim = true, true
+		}
+	case elapsed:
+		if opts.elapsedFormatFunc != nil {
+			valStr = opts.elapsedFormatFunc(time.Duration(val))
+			kind = kindElapsed
+			customFormatted = true
+		}
+	case percent:
+		if opts.percentFormatFunc != nil {
+			valStr = opts.percentFormatFunc(float64(val))
+			kind = kindPercent
+			customFormatted = true
+		}
+	case bool:
+		if opts.boolWords != (boolWords{}) {
+			valStr = opts.boolWords.format(val)
+			kind = kindBool
+			customFormatted = true
+		}
+	case []bool:
+		if opts.boolWords != (boolWords{}) {
+			valStr = formatBoolSlice(val, nil, opts.boolWords)
+			kind = kindSlice
+			customFormatted = true
+		}
+	case []any:
+		if opts.boolWords != (boolWords{}) {
+			valStr = formatAnySlice(
+				val, nil, false, 0, opts.boolWords,
+				opts.quoteMode, opts.quoteOpen, opts.quoteClose,
 			)
+			kind = kindSlice
+			customFormatted = true
 		}
-		if opts.quoteMode != QuoteNever &&
-			(kind == kindDefault || kind == kindString || kind == kindError || kind == kindTime) &&
-			(opts.quoteMode == QuoteAlways || needsQuoting(valStr)) {
-			valStr = quoteString(valStr, opts.quoteOpen, opts.quoteClose)
+	case diff:
+		if opts.boolWords != (boolWords{}) {
+			valStr = formatDiff(
+				val, opts.quoteMode, opts.quoteOpen, opts.quoteClose,
+				opts.timeFormat, percentPrecision, elapsedPrecision, opts.boolWords,
+			)
+			kind = kindDiff
+			customFormatted = true
 		}
+	case measurement:
+		valStr = string(val.format(opts.measurePrecision))
+		kind = kindQuantity
+		customFormatted = true
+	case stats:
+		valStr = formatStats(val, opts.statsPrecision, nil)
+		kind = kindSlice
+		customFormatted = true
+	}
+	if !customFormatted {
+		valStr, kind = formatValue(
+			f.Value,
+			opts.quoteMode,
+			opts.quoteOpen,
+			opts.quoteClose,
+			opts.timeFormat,
+			percentPrecision,
+			elapsedPrecision,
+		)
+	}
+	if opts.numberGrouping != 0 {
+		switch kind { //nolint:exhaustive // only plain numbers are grouped
+		case kindNumber:
+			valStr = groupDigits(valStr, opts.numberGrouping)
+		case kindSlice:
+			if s, ok := formatGroupedNumberSlice(f.Value, nil, opts.numberGrouping); ok {
+				valStr = s
+			}
+		}
+	}
+	if spec, ok := opts.keyTruncate[f.Key]; ok &&
+		(kind == kindDefault || kind == kindString || kind == kindError) {
+		valStr = truncateMiddle(valStr, spec.head, spec.tail)
+	}
+	if !verbatim && opts.quoteMode != QuoteNever &&
+		(kind == kindDefault || kind == kindString || kind == kindError || kind == kindTime) &&
+		(opts.quoteMode == QuoteAlways || needsQuoting(valStr)) {
+		valStr = quoteString(valStr, opts.quoteOpen, opts.quoteClose)
+	}
 
-		styled := styledFieldValue(f, valStr, kind, opts)
-		buf.WriteString(styled)
+	styled := styledFieldValue(f, valStr, kind, opts)
+	switch kind { //nolint:exhaustive // only numeric-with-unit values are column-aligned
+	case kindDuration, kindElapsed:
+		styled = padColumn(styled, opts.durationColumnWidth)
+	case kindQuantity:
+		styled = padColumn(styled, opts.quantityColumnWidth)
+	}
+	return styled, kind
+}
+
+// wrapSlice formats the slice value of f across several lines so it fits
+// within opts.wrapWidth columns of opts.indent, breaking between elements.
+// col is the column at which the value starts; continuation lines are padded to align under the first
+// element. ok is false if the value is not a slice whose elements can be
+// formatted individually, e.g. when a [Styles.Keys] style covers the whole
+// slice.
+func wrapSlice(f Field, precision, col int, opts formatFieldsOpts) (string, bool) {
+	rv := reflect.ValueOf(f.Value)
+	if rv.Kind() != reflect.Slice || rv.Len() < 2 {
+		return "", false
+	}
+
+	elems := make([]string, rv.Len())
+	for i := range elems {
+		s, _ := formatFieldValue(Field{Key: f.Key, Value: rv.Slice(i, i+1).Interface()}, precision, opts)
+		inner, ok := strings.CutPrefix(s, string(sliceOpen))
+		if !ok {
+			return "", false
+		}
+		if elems[i], ok = strings.CutSuffix(inner, string(sliceClose)); !ok {
+			return "", false
+		}
+	}
+
+	pad := "\n" + strings.Repeat(" ", col+1)
+	var buf strings.Builder
+	buf.WriteByte(sliceOpen)
+	x := col + 1
+	for i, e := range elems {
+		piece := e + string(sliceClose)
+		if i < len(elems)-1 {
+			piece = e + strings.TrimRight(sliceSep, " ")
+		}
+		w := lipgloss.Width(piece)
+		if i > 0 {
+			if x+1+w > opts.indent+opts.wrapWidth {
+				buf.WriteString(pad)
+				x = col + 1
+			} else {
+				buf.WriteByte(' ')
+				x++
+			}
+		}
+		buf.WriteString(piece)
+		x += w
+	}
+	return buf.String(), true
+}
+
+// groupDigits inserts sep between each group of three digits in the integer
+// part of the decimal number s (e.g. "-1234.5" -> "-1,234.5"). The
+// fractional part is left as is. s is returned unchanged when sep is 0.
+func groupDigits(s string, sep rune) string {
+	if sep == 0 {
+		return s
+	}
+
+	start := 0
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		start = 1
+	}
+	end := start
+	for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+		end++
+	}
+	if end-start <= 3 {
+		return s
+	}
+
+	var buf strings.Builder
+	buf.WriteString(s[:start])
+	for i := start; i < end; i++ {
+		if i > start && (end-i)%3 == 0 {
+			buf.WriteRune(sep)
+		}
+		buf.WriteByte(s[i])
 	}
+	buf.WriteString(s[end:])
 	return buf.String()
 }
 
+// padColumn right-aligns s within width visible columns by prepending
+// spaces. ANSI escape sequences do not count towards the width. Values
+// already at least width wide, or a width of 0, are returned unchanged.
+func padColumn(s string, width int) string {
+	if gap := width - lipgloss.Width(s); gap > 0 {
+		return strings.Repeat(" ", gap) + s
+	}
+	return s
+}
+
 // formatValue converts a field value to its string representation.
 // The returned valueKind indicates the type category for styling and quoting.
 func formatValue(
@@ -186,10 +792,19 @@ func formatValue(
 	elapsedPrecision int,
 ) (string, valueKind) {
 	switch val := v.(type) {
+	case diff:
+		return formatDiff(
+			val, quoteMode, quoteOpen, quoteClose,
+			timeFormat, percentPrecision, elapsedPrecision, boolWords{},
+		), kindDiff
 	case elapsed:
 		return formatElapsed(time.Duration(val), elapsedPrecision), kindElapsed
 	case error:
 		return val.Error(), kindError
+	case callerSite:
+		return val.String(), kindString
+	case command:
+		return string(val), kindCmd
 	case rawJSON:
 		return string(val), kindJSON
 	case string:
@@ -206,12 +821,18 @@ func formatValue(
 		return strconv.FormatFloat(val, 'f', -1, 64), kindNumber
 	case bool:
 		return strconv.FormatBool(val), kindBool
+	case unknownBool:
+		return val.String(), kindBool
 	case percent:
 		return strconv.FormatFloat(float64(val), 'f', percentPrecision, 64) + "%", kindPercent
 	case quantity:
 		return string(val), kindQuantity
+	case measurement:
+		return val.String(), kindQuantity
 	case time.Duration:
 		return val.String(), kindDuration
+	case backoff:
+		return val.String(), kindDuration
 	case time.Time:
 		if timeFormat == "" {
 			timeFormat = time.DateTime
@@ -220,7 +841,7 @@ func formatValue(
 	case []time.Duration:
 		return formatDurationSlice(val, nil), kindSlice
 	case []quantity:
-		return formatQuantitySlice(val, nil, false), kindSlice
+		return formatQuantitySlice(val, nil, false, 0), kindSlice
 	case []string:
 		return formatStringSlice(val, nil, quoteMode, quoteOpen, quoteClose), kindSlice
 	case []int:
@@ -234,9 +855,21 @@ func formatValue(
 	case []float64:
 		return formatFloat64Slice(val, nil), kindSlice
 	case []bool:
-		return formatBoolSlice(val, nil), kindSlice
+		return formatBoolSlice(val, nil, boolWords{}), kindSlice
 	case []any:
-		return formatAnySlice(val, nil, false, quoteMode, quoteOpen, quoteClose), kindSlice
+		return formatAnySlice(val, nil, false, 0, boolWords{}, quoteMode, quoteOpen, quoteClose), kindSlice
+	case stats:
+		return formatStats(val, -1, nil), kindSlice
+	case sparkline:
+		return formatSparkline(val, nil), kindSlice
+	case attempt:
+		return val.String(), kindDefault
+	case enums:
+		return formatEnums(val, nil, quoteMode, quoteOpen, quoteClose), kindSlice
+	case textLines:
+		return strings.Join(val, "\n"), kindString
+	case validationErrors:
+		return formatValidationErrors(val, nil), kindSlice
 	default:
 		return fmt.Sprintf("%v", v), kindDefault
 	}
@@ -248,6 +881,8 @@ func formatAnySlice(
 	vals []any,
 	styles *Styles,
 	ignoreCase bool,
+	thousandsSep rune,
+	words boolWords,
 	quoteMode QuoteMode,
 	quoteOpen, quoteClose rune,
 ) string {
@@ -261,6 +896,9 @@ func formatAnySlice(
 		}
 
 		s := fmt.Sprintf("%v", v)
+		if b, ok := v.(bool); ok {
+			s = words.format(b)
+		}
 		kind := reflectValueKind(v)
 
 		if quoteMode != QuoteNever &&
@@ -270,7 +908,7 @@ func formatAnySlice(
 		}
 
 		if styles != nil {
-			styled := styleAnyElement(s, v, kind, styles, ignoreCase)
+			styled := styleAnyElement(s, v, kind, styles, ignoreCase, thousandsSep)
 			if styled != "" {
 				buf.WriteString(styled)
 
@@ -315,6 +953,107 @@ func formatSlice[T any](
 	return buf.String()
 }
 
+// formatValidationErrors formats validation errors as "[path: message, ...]".
+// When styles is non-nil, paths use [Styles.KeyDefault] and messages use
+// [Styles.FieldError]. Messages are never quoted.
+func formatValidationErrors(vals validationErrors, styles *Styles) string {
+	var pathStyle, msgStyle Style
+	if styles != nil {
+		pathStyle, msgStyle = styles.KeyDefault, styles.FieldError
+	}
+
+	return formatSlice(vals, nil,
+		func(f Field) string {
+			return f.Key + ": " + fmt.Sprint(f.Value)
+		},
+		func(f Field, _ string, _ *Styles) string {
+			var buf strings.Builder
+			emitStyled(&buf, f.Key, pathStyle)
+			buf.WriteString(": ")
+			emitStyled(&buf, fmt.Sprint(f.Value), msgStyle)
+			return buf.String()
+		},
+	)
+}
+
+// formatStats formats s as "[n=3 min=1 avg=2 max=3]", with the min, avg and
+// max rounded to precision decimal places (negative = shortest exact form).
+// When styles is non-nil, names use [Styles.KeyDefault], the "=" uses
+// [Styles.Separator] and values use [Styles.FieldNumber]. An empty slice
+// renders as "[n=0]".
+func formatStats(s stats, precision int, styles *Styles) string {
+	var keyStyle, sepStyle, numStyle Style
+	if styles != nil {
+		keyStyle, sepStyle, numStyle = styles.KeyDefault, styles.Separator, styles.FieldNumber
+	}
+
+	var buf strings.Builder
+	buf.WriteByte(sliceOpen)
+
+	write := func(name, val string) {
+		if buf.Len() > 1 {
+			buf.WriteByte(' ')
+		}
+		emitStyled(&buf, name, keyStyle)
+		emitStyled(&buf, "=", sepStyle)
+		emitStyled(&buf, val, numStyle)
+	}
+
+	write("n", strconv.Itoa(s.count))
+	if s.count > 0 {
+		write("min", strconv.FormatFloat(s.minimum, 'f', precision, 64))
+		write("avg", strconv.FormatFloat(s.mean, 'f', precision, 64))
+		write("max", strconv.FormatFloat(s.maximum, 'f', precision, 64))
+	}
+
+	buf.WriteByte(sliceClose)
+	return buf.String()
+}
+
+// sparkBlocks are the block characters of a sparkline, lowest first.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// formatSparkline renders vals as block characters scaled between their
+// minimum and maximum, e.g. "▁▃▆█". When all values are equal (including a
+// single value) every block is drawn at mid height, and non-finite values
+// (NaN and ±Inf) render as a space. When styles is non-nil each block is coloured from the
+// [Styles.PercentGradient] stops by its relative magnitude. An empty slice
+// renders as "".
+func formatSparkline(vals sparkline, styles *Styles) string {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range vals {
+		if isFinite(v) {
+			lo, hi = min(lo, v), max(hi, v)
+		}
+	}
+
+	var buf strings.Builder
+	for _, v := range vals {
+		if !isFinite(v) {
+			buf.WriteByte(' ')
+			continue
+		}
+
+		frac := 0.5
+		if hi > lo {
+			// Halve before subtracting so that hi-lo cannot overflow to +Inf.
+			frac = min(max((v/2-lo/2)/(hi/2-lo/2), 0), 1)
+		}
+		block := string(sparkBlocks[int(math.Round(frac*float64(len(sparkBlocks)-1)))])
+
+		var style Style
+		if styles != nil && len(styles.PercentGradient) > 0 {
+			c := interpolateGradient(frac, styles.PercentGradient)
+			style = new(lipgloss.NewStyle().Foreground(lipgloss.Color(c.Clamped().Hex())))
+		}
+		emitStyled(&buf, block, style)
+	}
+	return buf.String()
+}
+
+// isFinite reports whether v is neither NaN nor ±Inf.
+func isFinite(v float64) bool { return !math.IsNaN(v) && !math.IsInf(v, 0) }
+
 // numberSliceStyle is a stylize function for numeric slice elements.
 // It applies Styles.FieldNumber when set.
 func numberSliceStyle[T any](_ T, s string, styles *Styles) string {
@@ -324,10 +1063,11 @@ func numberSliceStyle[T any](_ T, s string, styles *Styles) string {
 	return ""
 }
 
-// formatBoolSlice formats a bool slice with comma separation.
-// When styles is non-nil, individual elements are styled via ValueStyles.
-func formatBoolSlice(vals []bool, styles *Styles) string {
-	return formatSlice(vals, styles, strconv.FormatBool, func(v bool, s string, st *Styles) string {
+// formatBoolSlice formats a bool slice with comma separation, rendering
+// elements with words. When styles is non-nil, individual elements are
+// styled via ValueStyles.
+func formatBoolSlice(vals []bool, styles *Styles, words boolWords) string {
+	return formatSlice(vals, styles, words.format, func(v bool, s string, st *Styles) string {
 		if st != nil {
 			if style := st.Values[v]; style != nil {
 				return style.Render(s)
@@ -337,6 +1077,63 @@ func formatBoolSlice(vals []bool, styles *Styles) string {
 	})
 }
 
+// formatDiff formats a [diff] value as "before → after", or as the after
+// value alone when both sides are equal.
+func formatDiff(
+	d diff,
+	quoteMode QuoteMode,
+	quoteOpen, quoteClose rune,
+	timeFormat string,
+	percentPrecision int,
+	elapsedPrecision int,
+	words boolWords,
+) string {
+	after, _ := formatDiffSide(
+		d.after, quoteMode, quoteOpen, quoteClose,
+		timeFormat, percentPrecision, elapsedPrecision, words,
+	)
+	if d.unchanged() {
+		return after
+	}
+	before, _ := formatDiffSide(
+		d.before, quoteMode, quoteOpen, quoteClose,
+		timeFormat, percentPrecision, elapsedPrecision, words,
+	)
+	return before + diffArrow + after
+}
+
+// formatDiffSide formats one side of a [diff] value, quoting it
+// independently so the arrow separator is never enclosed in quotes.
+func formatDiffSide(
+	v any,
+	quoteMode QuoteMode,
+	quoteOpen, quoteClose rune,
+	timeFormat string,
+	percentPrecision int,
+	elapsedPrecision int,
+	words boolWords,
+) (string, valueKind) {
+	if b, ok := v.(bool); ok {
+		return words.format(b), kindBool
+	}
+
+	s, kind := formatValue(
+		v,
+		quoteMode,
+		quoteOpen,
+		quoteClose,
+		timeFormat,
+		percentPrecision,
+		elapsedPrecision,
+	)
+	if quoteMode != QuoteNever &&
+		(kind == kindDefault || kind == kindString || kind == kindError || kind == kindTime) &&
+		(quoteMode == QuoteAlways || needsQuoting(s)) {
+		s = quoteString(s, quoteOpen, quoteClose)
+	}
+	return s, kind
+}
+
 // formatDurationSlice formats a [time.Duration] slice with comma separation.
 // When styles is non-nil, individual elements are styled via [styleDuration].
 func formatDurationSlice(vals []time.Duration, styles *Styles) string {
@@ -407,6 +1204,31 @@ func formatElapsed(d time.Duration, precision int) string {
 	return "0s"
 }
 
+// rateSuffixes are the SI suffixes used by [formatRate].
+var rateSuffixes = []string{"", "k", "M", "G", "T"}
+
+// formatRate formats count per duration as a per-second rate with an SI
+// suffix and one decimal place (e.g. "2.5k/s", "120/s"). Rates below 1
+// keep two significant digits ("0.5/s", "0.033/s"). A non-positive per
+// yields "∞/s".
+func formatRate(count int64, per time.Duration) string {
+	if per <= 0 {
+		return "∞/s"
+	}
+
+	rate := float64(count) / per.Seconds()
+	if math.Abs(rate) < 1 {
+		return strconv.FormatFloat(rate, 'g', 2, 64) + "/s"
+	}
+
+	i := 0
+	for math.Abs(rate) >= 999.95 && i < len(rateSuffixes)-1 {
+		rate /= 1000
+		i++
+	}
+	return strconv.FormatFloat(math.Round(rate*10)/10, 'f', -1, 64) + rateSuffixes[i] + "/s"
+}
+
 // formatFloat64Slice formats a float64 slice with comma separation.
 // When styles is non-nil, individual elements are styled via FieldNumber.
 func formatFloat64Slice(vals []float64, styles *Styles) string {
@@ -418,6 +1240,44 @@ func formatFloat64Slice(vals []float64, styles *Styles) string {
 	)
 }
 
+// formatGroupedNumberSlice formats an integer or float64 slice with digit
+// grouping (see [Logger.SetNumberGrouping]). When styles is non-nil,
+// individual elements are styled via FieldNumber. Reports false for
+// other values.
+func formatGroupedNumberSlice(v any, styles *Styles, sep rune) (string, bool) {
+	switch vals := v.(type) {
+	case []int:
+		return groupedSlice(vals, styles, sep, strconv.Itoa), true
+	case []int64:
+		return groupedSlice(vals, styles, sep, func(n int64) string {
+			return strconv.FormatInt(n, 10)
+		}), true
+	case []uint:
+		return groupedSlice(vals, styles, sep, func(n uint) string {
+			return strconv.FormatUint(uint64(n), 10)
+		}), true
+	case []uint64:
+		return groupedSlice(vals, styles, sep, func(n uint64) string {
+			return strconv.FormatUint(n, 10)
+		}), true
+	case []float64:
+		return groupedSlice(vals, styles, sep, func(n float64) string {
+			return strconv.FormatFloat(n, 'f', -1, 64)
+		}), true
+	}
+	return "", false
+}
+
+// groupedSlice formats a numeric slice, grouping the digits of each element.
+func groupedSlice[T any](vals []T, styles *Styles, sep rune, stringify func(T) string) string {
+	return formatSlice(vals, styles,
+		func(v T) string {
+			return groupDigits(stringify(v), sep)
+		},
+		numberSliceStyle[T],
+	)
+}
+
 // formatIntSlice formats an int slice with comma separation.
 // When styles is non-nil, individual elements are styled via FieldNumber.
 func formatIntSlice(vals []int, styles *Styles) string {
@@ -448,7 +1308,12 @@ func formatUintSlice(vals []uint, styles *Styles) string {
 
 // formatQuantitySlice formats a quantity slice with comma separation.
 // When styles is non-nil, individual elements are styled via [styleQuantity].
-func formatQuantitySlice(vals []quantity, styles *Styles, ignoreCase bool) string {
+func formatQuantitySlice(
+	vals []quantity,
+	styles *Styles,
+	ignoreCase bool,
+	thousandsSep rune,
+) string {
 	return formatSlice(
 		vals,
 		styles,
@@ -459,7 +1324,7 @@ func formatQuantitySlice(vals []quantity, styles *Styles, ignoreCase bool) strin
 			if st == nil {
 				return ""
 			}
-			return styleQuantity(s, st, ignoreCase)
+			return styleQuantity(s, st, ignoreCase, thousandsSep)
 		},
 	)
 }
@@ -487,20 +1352,54 @@ func formatStringSlice(
 		}
 
 		if styles != nil {
-			if style := styles.Values[v]; style != nil {
+			if style := valueOverrideStyle(v, styles); style != nil {
 				buf.WriteString(style.Render(display))
 
-				continue
-			}
+				continue
+			}
+
+			if styles.FieldString != nil {
+				buf.WriteString(styles.FieldString.Render(display))
+
+				continue
+			}
+		}
+
+		buf.WriteString(display)
+	}
+
+	buf.WriteByte(sliceClose)
+	return buf.String()
+}
+
+// formatEnums formats an [Event.Enums] slice like [formatStringSlice]. When
+// styles is non-nil, each element is styled by [Styles.Values] keyed by the
+// original value, then by its string, falling back to [Styles.FieldString].
+func formatEnums(
+	vals enums,
+	styles *Styles,
+	quoteMode QuoteMode,
+	quoteOpen, quoteClose rune,
+) string {
+	var buf strings.Builder
+
+	buf.WriteByte(sliceOpen)
 
-			if styles.FieldString != nil {
-				buf.WriteString(styles.FieldString.Render(display))
+	for i, s := range vals.strings() {
+		if i > 0 {
+			buf.WriteString(sliceSep)
+		}
 
-				continue
-			}
+		display := s
+		if quoteMode != QuoteNever && (quoteMode == QuoteAlways || needsQuoting(s)) {
+			display = quoteString(s, quoteOpen, quoteClose)
 		}
 
-		buf.WriteString(display)
+		var style Style
+		if styles != nil {
+			style = cmp.Or(valueOverrideStyle(vals[i], styles), valueOverrideStyle(s, styles), styles.FieldString)
+		}
+		emitStyled(&buf, display, style)
 	}
 
 	buf.WriteByte(sliceClose)
@@ -526,9 +1425,11 @@ func styleAnyElement(
 	kind valueKind,
 	styles *Styles,
 	ignoreCase bool,
+	thousandsSep rune,
 ) string {
-	// Per-value styling (typed key lookup — bool true ≠ string "true").
-	if style := lookupValueStyle(originalValue, styles.Values); style != nil {
+	// Per-value styling (typed key lookup — bool true ≠ string "true"),
+	// then pattern styling for strings.
+	if style := valueOverrideStyle(originalValue, styles); style != nil {
 		return style.Render(s)
 	}
 
@@ -558,7 +1459,7 @@ func styleAnyElement(
 			return styled
 		}
 	case kindQuantity:
-		if styled := styleQuantity(s, styles, ignoreCase); styled != "" {
+		if styled := styleQuantity(s, styles, ignoreCase, thousandsSep); styled != "" {
 			return styled
 		}
 
@@ -570,12 +1471,28 @@ func styleAnyElement(
 		if styles.FieldTime != nil {
 			return styles.FieldTime.Render(s)
 		}
-	case kindBool, kindDefault, kindJSON:
+	case kindCmd:
+		if styled := styleCmd(s, styles); styled != "" {
+			return styled
+		}
+	case kindBool, kindDefault, kindDiff, kindJSON:
 		// No type-based style for these.
 	}
 	return ""
 }
 
+// styleCmd renders a command line with [Styles.FieldCmd], falling back to
+// [Styles.FieldString]. Returns "" when both are nil.
+func styleCmd(s string, styles *Styles) string {
+	if styles.FieldCmd != nil {
+		return styles.FieldCmd.Render(s)
+	}
+	if styles.FieldString != nil {
+		return styles.FieldString.Render(s)
+	}
+	return ""
+}
+
 // styleDuration renders a duration string (from [time.Duration.String]) with
 // separate styles for numeric and unit segments using [Styles.FieldDurationNumber]
 // and [Styles.FieldDurationUnit]. Returns "" when both styles are nil.
@@ -587,6 +1504,7 @@ func styleDuration(s string, styles *Styles) string {
 		styles.DurationUnits,
 		styles.DurationThresholds,
 		true,
+		0,
 	)
 }
 
@@ -612,6 +1530,7 @@ func styleElapsed(s string, styles *Styles) string {
 		styles.DurationUnits,
 		styles.DurationThresholds,
 		true,
+		0,
 	)
 }
 
@@ -622,21 +1541,81 @@ func styledFieldValue(f Field, valStr string, kind valueKind, opts formatFieldsO
 		return valStr
 	}
 
+	// Route durations through quantity styling when enabled.
+	if opts.durationUsesQuantityStyles && kind == kindDuration {
+		kind = kindQuantity
+	}
+
+	// KeyStyles takes priority over per-side styling for diffs.
+	if d, ok := f.Value.(diff); ok && kind == kindDiff {
+		if style := opts.styles.Keys[f.Key]; style != nil {
+			return style.Render(valStr)
+		}
+		return styledDiff(f.Key, d, opts)
+	}
+
 	// KeyStyles takes priority over per-element styling for slices.
 	if kind == kindSlice {
 		if style := opts.styles.Keys[f.Key]; style != nil {
 			return style.Render(valStr)
 		}
+		if s, ok := f.Value.(stats); ok {
+			return formatStats(s, opts.statsPrecision, opts.styles)
+		}
+		if s, ok := f.Value.(sparkline); ok {
+			return formatSparkline(s, opts.styles)
+		}
+		if opts.numberGrouping != 0 {
+			if s, ok := formatGroupedNumberSlice(f.Value, opts.styles, opts.numberGrouping); ok {
+				return s
+			}
+		}
+		if ds, ok := f.Value.([]time.Duration); ok && opts.durationUsesQuantityStyles {
+			qs := make([]quantity, len(ds))
+			for i, d := range ds {
+				qs[i] = quantity(d.String())
+			}
+			return formatQuantitySlice(
+				qs,
+				opts.styles,
+				opts.quantityUnitsIgnoreCase,
+				opts.quantityThousandsSep,
+			)
+		}
 		return styledSlice(
 			f.Value,
 			opts.styles,
 			opts.quantityUnitsIgnoreCase,
+			opts.quantityThousandsSep,
+			opts.boolWords,
 			opts.quoteMode,
 			opts.quoteOpen,
 			opts.quoteClose,
 		)
 	}
 
+	if a, ok := f.Value.(attempt); ok && opts.styles.Keys[f.Key] == nil {
+		style := opts.styles.FieldNumber
+		if a.exhausted() {
+			style = cmp.Or(opts.styles.FieldRetryExhausted, opts.styles.FieldError)
+		}
+		if style != nil {
+			return style.Render(valStr)
+		}
+		return valStr
+	}
+
+	if opts.autoColorKeys[f.Key] && opts.styles.Keys[f.Key] == nil {
+		return autoColorStyle(fmt.Sprint(f.Value)).Render(valStr)
+	}
+
+	if kind == kindNumber && opts.styles.Keys[f.Key] == nil &&
+		valueOverrideStyle(f.Value, opts.styles) == nil {
+		if style := numberThresholdStyle(f.Value, opts.styles.NumberThresholds[f.Key]); style != nil {
+			return style.Render(valStr)
+		}
+	}
+
 	if styled := styleValue(
 		valStr,
 		f.Value,
@@ -644,27 +1623,105 @@ func styledFieldValue(f Field, valStr string, kind valueKind, opts formatFieldsO
 		kind,
 		opts.styles,
 		opts.quantityUnitsIgnoreCase,
+		opts.quantityThousandsSep,
 	); styled != "" {
 		return styled
 	}
 	return valStr
 }
 
+// styledDiff re-formats a diff value with [Styles.DiffOld] applied to the
+// old side and [Styles.DiffNew] to the new side. Unchanged values are
+// rendered once using the regular type-based styling. Dry diffs are
+// rendered whole in the [DryLevel] colour (see [styledDryChange]).
+func styledDiff(key string, d diff, opts formatFieldsOpts) string {
+	if d.dry {
+		return styledDryChange(d, opts)
+	}
+
+	after, afterKind := formatDiffSide(
+		d.after,
+		opts.quoteMode,
+		opts.quoteOpen,
+		opts.quoteClose,
+		opts.timeFormat,
+		opts.percentPrecision,
+		opts.elapsedPrecision,
+		opts.boolWords,
+	)
+
+	if d.unchanged() {
+		if styled := styleValue(
+			after,
+			d.after,
+			key,
+			afterKind,
+			opts.styles,
+			opts.quantityUnitsIgnoreCase,
+			opts.quantityThousandsSep,
+		); styled != "" {
+			return styled
+		}
+		return after
+	}
+
+	before, _ := formatDiffSide(
+		d.before,
+		opts.quoteMode,
+		opts.quoteOpen,
+		opts.quoteClose,
+		opts.timeFormat,
+		opts.percentPrecision,
+		opts.elapsedPrecision,
+		opts.boolWords,
+	)
+
+	var buf strings.Builder
+	emitStyled(&buf, before, opts.styles.DiffOld)
+	buf.WriteString(diffArrow)
+	emitStyled(&buf, after, opts.styles.DiffNew)
+	return buf.String()
+}
+
+// styledDryChange re-formats a dry diff with the foreground of the
+// [DryLevel] style from [Styles.Levels], leaving out its other attributes
+// (such as bold) so the value does not compete with the level label.
+func styledDryChange(d diff, opts formatFieldsOpts) string {
+	valStr := formatDiff(
+		d,
+		opts.quoteMode,
+		opts.quoteOpen,
+		opts.quoteClose,
+		opts.timeFormat,
+		opts.percentPrecision,
+		opts.elapsedPrecision,
+		opts.boolWords,
+	)
+
+	level := opts.styles.Levels[DryLevel]
+	if level == nil {
+		return valStr
+	}
+	return lipgloss.NewStyle().Foreground(level.GetForeground()).Render(valStr)
+}
+
 // styledSlice re-formats a slice value with per-element styling.
 func styledSlice(
 	v any,
 	styles *Styles,
 	ignoreCase bool,
+	thousandsSep rune,
+	words boolWords,
 	quoteMode QuoteMode,
 	quoteOpen, quoteClose rune,
 ) string {
 	switch vals := v.(type) {
 	case []bool:
-		return formatBoolSlice(vals, styles)
+		return formatBoolSlice(vals, styles, words)
 	case []time.Duration:
 		return formatDurationSlice(vals, styles)
 	case []quantity:
-		return formatQuantitySlice(vals, styles, ignoreCase)
+		return formatQuantitySlice(vals, styles, ignoreCase, thousandsSep)
 	case []int:
 		return formatIntSlice(vals, styles)
 	case []int64:
@@ -677,8 +1734,12 @@ func styledSlice(
 		return formatFloat64Slice(vals, styles)
 	case []string:
 		return formatStringSlice(vals, styles, quoteMode, quoteOpen, quoteClose)
+	case enums:
+		return formatEnums(vals, styles, quoteMode, quoteOpen, quoteClose)
 	case []any:
-		return formatAnySlice(vals, styles, ignoreCase, quoteMode, quoteOpen, quoteClose)
+		return formatAnySlice(vals, styles, ignoreCase, thousandsSep, words, quoteMode, quoteOpen, quoteClose)
+	case validationErrors:
+		return formatValidationErrors(vals, styles)
 	default:
 		s, _ := formatValue(v, quoteMode, quoteOpen, quoteClose, "", 0, 1)
 		return s
@@ -697,12 +1758,13 @@ func styleNumberUnit(
 	unitOverrides StyleMap,
 	thresholds ThresholdMap,
 	ignoreCase bool,
+	thousandsSep rune,
 ) string {
 	if numStyle == nil && unitStyle == nil && len(unitOverrides) == 0 && len(thresholds) == 0 {
 		return ""
 	}
 
-	if !isQuantityString(s) {
+	if !isQuantityString(s, thousandsSep) {
 		return ""
 	}
 
@@ -729,18 +1791,14 @@ func styleNumberUnit(
 				i++
 			}
 
-			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
-				i++
-			}
+			i = scanQuantityNumber(runes, i, thousandsSep)
 
 			pendingNum = string(runes[start:i])
 			pendingSpaces = ""
 
-		case unicode.IsLetter(r):
+		case scanQuantityUnit(runes, i) > i:
 			start := i
-			for i < len(runes) && unicode.IsLetter(runes[i]) {
-				i++
-			}
+			i = scanQuantityUnit(runes, i)
 
 			unit := string(runes[start:i])
 
@@ -749,7 +1807,7 @@ func styleNumberUnit(
 				pendingNum, unit,
 				numStyle, unitStyle,
 				unitOverrides, thresholds,
-				ignoreCase,
+				ignoreCase, thousandsSep,
 			)
 
 			// Render the pending number with the resolved style.
@@ -797,9 +1855,23 @@ func styleNumberUnit(
 	return buf.String()
 }
 
+// autoColorStyle returns a foreground style whose hue is derived from an
+// FNV-1a hash of s, so equal strings always get the same colour. Saturation
+// and lightness are fixed to keep colours readable on dark and light
+// backgrounds.
+func autoColorStyle(s string) lipgloss.Style {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(s))
+
+	hue := float64(h.Sum32() % 360) //nolint:mnd // degrees on the colour wheel
+	c := colorful.Hsl(hue, 0.65, 0.55)
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(c.Clamped().Hex()))
+}
+
 // stylePercent renders a percentage string with a gradient color based on the
 // value. The color is interpolated from the [Styles.PercentGradient] stops and
-// applied as the foreground on top of [Styles.FieldPercent] (if set).
+// applied as the foreground on top of [Styles.FieldPercent] (if set). A
+// matching [Styles.PercentThresholds] band takes precedence over both.
 // originalValue must be a [percent] typed value.
 // Returns "" when both FieldPercent and PercentGradient are nil/empty.
 func stylePercent(valStr string, originalValue any, styles *Styles) string {
@@ -808,6 +1880,10 @@ func stylePercent(valStr string, originalValue any, styles *Styles) string {
 		return ""
 	}
 
+	if style := percentThresholdStyle(float64(p), styles.PercentThresholds); style != nil {
+		return style.Render(valStr)
+	}
+
 	hasGradient := len(styles.PercentGradient) > 0
 
 	if !hasGradient && styles.FieldPercent == nil {
@@ -834,12 +1910,52 @@ func stylePercent(valStr string, originalValue any, styles *Styles) string {
 	return style.Render(valStr)
 }
 
+// percentThresholdStyle returns the style of the band with the highest
+// AtLeast that v meets, or nil if v is below every band.
+func percentThresholdStyle(v float64, thresholds []PercentThreshold) Style {
+	var style Style
+	best := math.Inf(-1)
+	for _, t := range thresholds {
+		if v >= t.AtLeast && t.AtLeast >= best && t.Style != nil {
+			style, best = t.Style, t.AtLeast
+		}
+	}
+	return style
+}
+
+// numberThresholdStyle returns the number style of the first threshold
+// that v meets, or nil if there is none or v is not a number.
+func numberThresholdStyle(v any, thresholds Thresholds) Style {
+	if len(thresholds) == 0 {
+		return nil
+	}
+
+	var n float64
+	switch rv := reflect.ValueOf(v); {
+	case rv.CanInt():
+		n = float64(rv.Int())
+	case rv.CanUint():
+		n = float64(rv.Uint())
+	case rv.CanFloat():
+		n = rv.Float()
+	default:
+		return nil
+	}
+
+	for _, t := range thresholds {
+		if n >= t.Value {
+			return t.Style.Number
+		}
+	}
+	return nil
+}
+
 // styleQuantity renders a quantity string with separate styles for the numeric
 // and unit segments (e.g. "5" in FieldQuantityNumber, "km" in FieldQuantityUnit).
 // Per-unit overrides in [Styles.QuantityUnits] take priority over [Styles.FieldQuantityUnit].
 // Returns "" when both default styles are nil and no unit overrides match,
 // or the string is not a valid quantity pattern.
-func styleQuantity(s string, styles *Styles, ignoreCase bool) string {
+func styleQuantity(s string, styles *Styles, ignoreCase bool, thousandsSep rune) string {
 	return styleNumberUnit(
 		s,
 		styles.FieldQuantityNumber,
@@ -847,6 +1963,7 @@ func styleQuantity(s string, styles *Styles, ignoreCase bool) string {
 		styles.QuantityUnits,
 		styles.QuantityThresholds,
 		ignoreCase,
+		thousandsSep,
 	)
 }
 
@@ -860,31 +1977,14 @@ func styleValue(
 	kind valueKind,
 	styles *Styles,
 	ignoreCase bool,
+	thousandsSep rune,
 ) string {
-	// Per-key styling takes priority.
-	if style := styles.Keys[key]; style != nil {
+	if style := valueStyle(originalValue, key, kind, styles); style != nil {
 		return style.Render(valStr)
 	}
 
-	// Per-value styling (typed key lookup — bool true ≠ string "true").
-	if style := lookupValueStyle(originalValue, styles.Values); style != nil {
-		return style.Render(valStr)
-	}
-
-	// Type-based styling.
+	// Type-based styling for kinds rendered in segments.
 	switch kind {
-	case kindString:
-		if styles.FieldString != nil {
-			return styles.FieldString.Render(valStr)
-		}
-	case kindNumber:
-		if styles.FieldNumber != nil {
-			return styles.FieldNumber.Render(valStr)
-		}
-	case kindError:
-		if styles.FieldError != nil {
-			return styles.FieldError.Render(valStr)
-		}
 	case kindDuration:
 		if styled := styleDuration(valStr, styles); styled != "" {
 			return styled
@@ -898,7 +1998,7 @@ func styleValue(
 			return styled
 		}
 	case kindQuantity:
-		if styled := styleQuantity(valStr, styles, ignoreCase); styled != "" {
+		if styled := styleQuantity(valStr, styles, ignoreCase, thousandsSep); styled != "" {
 			return styled
 		}
 
@@ -906,29 +2006,74 @@ func styleValue(
 		if styles.FieldString != nil {
 			return styles.FieldString.Render(valStr)
 		}
-	case kindTime:
-		if styles.FieldTime != nil {
-			return styles.FieldTime.Render(valStr)
-		}
 	case kindJSON:
 		return highlightJSON(valStr, styles.FieldJSON)
-	case kindBool, kindSlice, kindDefault:
-		// No type-based style for these.
+	case kindBool, kindCmd, kindDefault, kindDiff, kindError, kindNumber,
+		kindSlice, kindString, kindTime:
+		// Styled by valueStyle, or no type-based style.
 	}
 	return ""
 }
 
+// valueStyle returns the single style for a value: the key style, then the
+// value style, then the type style for kinds rendered with one style.
+// Returns nil if none applies.
+func valueStyle(originalValue any, key string, kind valueKind, styles *Styles) Style {
+	// Per-key styling takes priority.
+	if style := styles.Keys[key]; style != nil {
+		return style
+	}
+
+	// Per-value styling (typed key lookup — bool true ≠ string "true"),
+	// then pattern styling for strings.
+	if style := valueOverrideStyle(originalValue, styles); style != nil {
+		return style
+	}
+
+	switch kind {
+	case kindString:
+		return styles.FieldString
+	case kindNumber:
+		return styles.FieldNumber
+	case kindError:
+		return styles.FieldError
+	case kindTime:
+		return styles.FieldTime
+	case kindCmd:
+		return cmp.Or(styles.FieldCmd, styles.FieldString)
+	case kindBool, kindDefault, kindDiff, kindDuration, kindElapsed, kindJSON,
+		kindPercent, kindQuantity, kindSlice:
+		// Styled in segments, or no type-based style.
+	}
+	return nil
+}
+
 // needsQuoting returns true if the string needs quoting for parseable output.
 // Returns false for strings containing ANSI escapes (e.g. hyperlinks) to preserve them.
+//
+// Besides spaces, quotes and control characters, invisible runes that can
+// corrupt terminal layout also force quoting: format characters such as
+// zero-width spaces/joiners and bidi controls (rejected by
+// [strconv.IsPrint]), default-ignorable fillers, and combining marks with
+// no base character to attach to.
 func needsQuoting(s string) bool {
 	if strings.Contains(s, "\x1b") {
 		return false // preserve ANSI escape sequences (hyperlinks)
 	}
 
+	base := false // whether the previous rune can carry a combining mark
 	for _, r := range s {
-		if unicode.IsSpace(r) || r == '"' || !strconv.IsPrint(r) {
+		if unicode.IsSpace(r) || r == '"' || !strconv.IsPrint(r) ||
+			unicode.Is(unicode.Other_Default_Ignorable_Code_Point, r) {
 			return true
 		}
+		if unicode.In(r, unicode.Mn, unicode.Me) {
+			if !base {
+				return true
+			}
+			continue
+		}
+		base = true
 	}
 	return false
 }
@@ -947,13 +2092,243 @@ func quoteString(s string, openChar, closeChar rune) string {
 	return string(openChar) + s + string(closeChar)
 }
 
+// fieldsColumn returns the visible column at the end of s, a partial
+// [formatFields] result whose first line starts at column indent (after
+// the leading space that callers trim).
+func fieldsColumn(s string, indent int) int {
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		return lipgloss.Width(s[i+1:])
+	}
+	return indent + max(lipgloss.Width(s)-1, 0)
+}
+
+// continuationPad returns a newline and the padding that aligns the next
+// line under the column at the end of s, a partial [formatFields] result.
+func continuationPad(s string, opts formatFieldsOpts) string {
+	return "\n" + strings.Repeat(" ", fieldsColumn(s, opts.indent))
+}
+
+// escapeControl replaces control characters in s, other than newline and
+// tab, with Go escape sequences (e.g. "\x1b" for ESC) so they are displayed
+// rather than interpreted by the terminal.
+func escapeControl(s string) string {
+	if !strings.ContainsFunc(s, isEscapedControl) {
+		return s
+	}
+
+	var buf strings.Builder
+	for _, r := range s {
+		switch {
+		case !isEscapedControl(r):
+			buf.WriteRune(r)
+		case r < utf8.RuneSelf:
+			fmt.Fprintf(&buf, `\x%02x`, r)
+		default:
+			fmt.Fprintf(&buf, `\u%04x`, r)
+		}
+	}
+	return buf.String()
+}
+
+// escapeControlFields returns fields with [escapeControl] applied to their
+// keys and values. The input slice is never modified; it is cloned on the
+// first field that changes.
+func escapeControlFields(fields []Field) []Field {
+	out := fields
+	cloned := false
+	for i, f := range fields {
+		key := escapeControl(f.Key)
+		v := escapeControlValue(f.Value)
+		if v == nil && key == f.Key {
+			continue
+		}
+		if !cloned {
+			out, cloned = slices.Clone(fields), true
+		}
+		out[i].Key = key
+		if v != nil {
+			out[i].Value = v
+		}
+	}
+	return out
+}
+
+// escapeControlValue returns v with control characters escaped, or nil if
+// v needs no change. Wrapper types are escaped in place; values of other
+// types that render with control characters are replaced by their escaped
+// string.
+func escapeControlValue(v any) any {
+	switch v := v.(type) {
+	case nil, bool, int, int64, uint, uint64, float64, time.Time, time.Duration,
+		elapsed, backoff, percent, stats, sparkline, attempt, unknownBool,
+		[]int, []int64, []uint, []uint64, []float64, []bool, []time.Duration:
+		return nil
+	case string:
+		if s := escapeControl(v); s != v {
+			return s
+		}
+	case command:
+		if s := escapeControl(string(v)); s != string(v) {
+			return command(s)
+		}
+	case quantity:
+		if s := escapeControl(string(v)); s != string(v) {
+			return quantity(s)
+		}
+	case rawJSON:
+		if s := escapeControl(string(v)); s != string(v) {
+			return rawJSON(s)
+		}
+	case callerSite:
+		location, function := escapeControl(v.location), escapeControl(v.function)
+		if location != v.location || function != v.function {
+			return callerSite{location: location, function: function}
+		}
+	case measurement:
+		if unit := escapeControl(v.unit); unit != v.unit {
+			v.unit = unit
+			return v
+		}
+	case []string:
+		if !slices.ContainsFunc(v, func(s string) bool {
+			return strings.ContainsFunc(s, isEscapedControl)
+		}) {
+			return nil
+		}
+		out := make([]string, len(v))
+		for i, s := range v {
+			out[i] = escapeControl(s)
+		}
+		return out
+	case []quantity:
+		var out []quantity
+		for i, q := range v {
+			if s := escapeControl(string(q)); s != string(q) {
+				if out == nil {
+					out = slices.Clone(v)
+				}
+				out[i] = quantity(s)
+			}
+		}
+		if out != nil {
+			return out
+		}
+	case []any:
+		var out []any
+		for i, elem := range v {
+			if e := escapeControlValue(elem); e != nil {
+				if out == nil {
+					out = slices.Clone(v)
+				}
+				out[i] = e
+			}
+		}
+		if out != nil {
+			return out
+		}
+	case enums:
+		// Escaping replaces the values with their strings.
+		return escapeControlValue(v.strings())
+	case textLines:
+		if s := escapeControlValue([]string(v)); s != nil {
+			return textLines(s.([]string))
+		}
+	case diff:
+		before, after := escapeControlValue(v.before), escapeControlValue(v.after)
+		if before == nil && after == nil {
+			return nil
+		}
+		if before != nil {
+			v.before = before
+		}
+		if after != nil {
+			v.after = after
+		}
+		return v
+	case dictFields:
+		if out := escapeControlFields(v); !sameFields(out, v) {
+			return dictFields(out)
+		}
+	case validationErrors:
+		if out := escapeControlFields(v); !sameFields(out, v) {
+			return validationErrors(out)
+		}
+	case noted:
+		inner, note := escapeControlValue(v.value), escapeControl(v.note)
+		if inner == nil && note == v.note {
+			return nil
+		}
+		if inner != nil {
+			v.value = inner
+		}
+		v.note = note
+		return v
+	case precise:
+		if inner := escapeControlValue(v.value); inner != nil {
+			v.value = inner
+			return v
+		}
+	case error:
+		if msg := v.Error(); strings.ContainsFunc(msg, isEscapedControl) {
+			return escapedError{err: v, msg: escapeControl(msg)}
+		}
+	default:
+		if s := fmt.Sprintf("%v", v); strings.ContainsFunc(s, isEscapedControl) {
+			return escapeControl(s)
+		}
+	}
+	return nil
+}
+
+// sameFields reports whether a and b share the same backing array, as
+// [escapeControlFields] returns when nothing needed escaping.
+func sameFields(a, b []Field) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
+
+// escapedError is an error whose message has had control characters
+// escaped by [escapeControlValue]. It unwraps to the original error.
+type escapedError struct {
+	err error
+	msg string
+}
+
+func (e escapedError) Error() string { return e.msg }
+func (e escapedError) Unwrap() error { return e.err }
+
+// isEscapedControl reports whether r is a control character that
+// [escapeControl] escapes.
+func isEscapedControl(r rune) bool {
+	return unicode.IsControl(r) && r != '\n' && r != '\t'
+}
+
+// isEmptyField reports whether f's value is empty (see [isEmptyValue]).
+func isEmptyField(f Field) bool { return isEmptyValue(f.Value) }
+
+// isZeroField reports whether f's value is zero (see [isZeroValue]).
+func isZeroField(f Field) bool { return isZeroValue(f.Value) }
+
 // isEmptyValue reports whether v is semantically "nothing": nil, an empty
-// string, or a nil/empty slice or map.
+// string, a nil/empty slice or map, a diff whose values are equal, or a
+// zero [backoff].
 func isEmptyValue(v any) bool {
+	v, _ = unwrapNoted(v)
+	v = unwrapSQLNull(v)
+	v, _ = unwrapPrecise(v)
 	if v == nil {
 		return true
 	}
 
+	if d, ok := v.(diff); ok {
+		return d.unchanged()
+	}
+	if s, ok := v.(stats); ok {
+		return s.count == 0
+	}
+	if b, ok := v.(backoff); ok {
+		return b == 0
+	}
+
 	rv := reflect.ValueOf(v)
 
 	switch rv.Kind() { //nolint:exhaustive // only string, slice, and map are considered empty
@@ -968,8 +2343,9 @@ func isEmptyValue(v any) bool {
 
 // isQuantityString reports whether s looks like a quantity: an optional leading
 // '-' followed by one or more digit+letter groups with optional spaces between
-// the number and unit (e.g. "5m", "5.1km", "100 MB", "2h30m").
-func isQuantityString(s string) bool {
+// the number and unit (e.g. "5m", "5.1km", "100 MB", "2h30m", "1.5e3ms").
+// When thousandsSep is non-zero it may group digits in the number ("1,000MB").
+func isQuantityString(s string, thousandsSep rune) bool {
 	runes := []rune(s)
 	i := 0
 
@@ -988,22 +2364,18 @@ func isQuantityString(s string) bool {
 			return false
 		}
 
-		for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
-			i++
-		}
+		i = scanQuantityNumber(runes, i, thousandsSep)
 
 		// Skip optional space between number and unit.
 		for i < len(runes) && runes[i] == ' ' {
 			i++
 		}
 
-		if i >= len(runes) || !unicode.IsLetter(runes[i]) {
+		end := scanQuantityUnit(runes, i)
+		if end == i {
 			return false
 		}
-
-		for i < len(runes) && unicode.IsLetter(runes[i]) {
-			i++
-		}
+		i = end
 
 		// Skip optional space before next group.
 		for i < len(runes) && runes[i] == ' ' {
@@ -1015,14 +2387,93 @@ func isQuantityString(s string) bool {
 	return groups > 0
 }
 
+// scanQuantityNumber returns the index just past the number starting at
+// runes[i]: digits and '.', digit groups joined by thousandsSep (when
+// non-zero), and an optional exponent ("e3", "E-2"). An 'e' not followed by
+// a digit is left for the unit (e.g. "5em").
+func scanQuantityNumber(runes []rune, i int, thousandsSep rune) int {
+	start := i
+	for i < len(runes) {
+		r := runes[i]
+
+		switch {
+		case unicode.IsDigit(r) || r == '.':
+			i++
+		case thousandsSep != 0 && r == thousandsSep &&
+			i > start && unicode.IsDigit(runes[i-1]) &&
+			i+1 < len(runes) && unicode.IsDigit(runes[i+1]):
+			i++
+		case (r == 'e' || r == 'E') && i > start:
+			j := i + 1
+			if j < len(runes) && (runes[j] == '+' || runes[j] == '-') {
+				j++
+			}
+			if j >= len(runes) || !unicode.IsDigit(runes[j]) {
+				return i
+			}
+			for j < len(runes) && unicode.IsDigit(runes[j]) {
+				j++
+			}
+			return j
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// scanQuantityUnit returns the index just past the unit starting at
+// runes[i]: letters, optionally joined by '/' for rates (e.g. "MB/s",
+// "k/s", "/s"). A '/' must be followed by a letter. Returns i if no unit
+// starts there.
+func scanQuantityUnit(runes []rune, i int) int {
+	for i < len(runes) {
+		switch r := runes[i]; {
+		case unicode.IsLetter(r):
+			i++
+		case r == '/' && i+1 < len(runes) && unicode.IsLetter(runes[i+1]):
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// unwrapSQLNull returns the inner value of a database/sql Null type
+// (e.g. [sql.NullString], [sql.Null]), or nil when it is not valid. Other
+// values are returned unchanged.
+//
+// [sql.NullString]: https://pkg.go.dev/database/sql#NullString
+// [sql.Null]: https://pkg.go.dev/database/sql#Null
+func unwrapSQLNull(v any) any {
+	valuer, ok := v.(driver.Valuer)
+	if !ok || reflect.TypeOf(v).PkgPath() != "database/sql" {
+		return v
+	}
+
+	inner, err := valuer.Value()
+	if err != nil {
+		return v
+	}
+	return inner
+}
+
 // isZeroValue reports whether v is the zero value for its type. This is a
 // superset of [isEmptyValue] — it additionally covers 0, false, 0.0, zero
 // duration, and any other typed zero.
 func isZeroValue(v any) bool {
+	v, _ = unwrapNoted(v)
+	v = unwrapSQLNull(v)
+	v, _ = unwrapPrecise(v)
 	if v == nil {
 		return true
 	}
 
+	if d, ok := v.(diff); ok {
+		return d.unchanged()
+	}
+
 	rv := reflect.ValueOf(v)
 
 	// Empty slices and maps are considered zero even when non-nil.
@@ -1078,6 +2529,10 @@ func reflectValueKind(v any) valueKind {
 		return kindError
 	}
 
+	if _, ok := v.(diff); ok {
+		return kindDiff
+	}
+
 	rv := reflect.ValueOf(v)
 
 	switch rv.Kind() { //nolint:exhaustive // only string, numeric and bool kinds need special styling
@@ -1156,6 +2611,36 @@ func lookupValueStyle(v any, values ValueStyleMap) Style {
 	return values[v]
 }
 
+// lookupPatternStyle returns the style of the first pattern matching v.
+// Only string values are matched.
+func lookupPatternStyle(v any, patterns []ValuePattern) Style {
+	s, ok := v.(string)
+	if !ok {
+		return nil
+	}
+
+	for _, p := range patterns {
+		if p.Pattern != nil && p.Pattern.MatchString(s) {
+			return p.Style
+		}
+	}
+	return nil
+}
+
+// valueOverrideStyle returns the per-value style for v: an exact
+// [Styles.Values] match, else the first matching [Styles.ValuePatterns]
+// entry. Returns nil if neither applies. An [unknownBool] uses the style
+// for nil.
+func valueOverrideStyle(v any, styles *Styles) Style {
+	if _, ok := v.(unknownBool); ok {
+		v = nil
+	}
+	if style := lookupValueStyle(v, styles.Values); style != nil {
+		return style
+	}
+	return lookupPatternStyle(v, styles.ValuePatterns)
+}
+
 // resolveSegmentStyles determines the effective number and unit styles for a
 // single number+unit pair, applying threshold overrides when the numeric value
 // meets or exceeds a configured threshold.
@@ -1165,6 +2650,7 @@ func resolveSegmentStyles(
 	unitOverrides StyleMap,
 	thresholds ThresholdMap,
 	ignoreCase bool,
+	thousandsSep rune,
 ) (Style, Style) {
 	effNumStyle := numStyle
 
@@ -1177,6 +2663,10 @@ func resolveSegmentStyles(
 		return effNumStyle, effUnitStyle
 	}
 
+	if thousandsSep != 0 {
+		num = strings.ReplaceAll(num, string(thousandsSep), "")
+	}
+
 	numVal, err := strconv.ParseFloat(num, 64)
 	if err != nil {
 		return effNumStyle, effUnitStyle
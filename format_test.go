@@ -1,8 +1,10 @@
 package clog
 
 import (
+	"cmp"
 	"errors"
 	"math"
+	"regexp"
 	"strconv"
 	"strings"
 	"testing"
@@ -357,6 +359,51 @@ func TestNeedsQuoting(t *testing.T) {
 			s:    "hello\x00world",
 			want: true,
 		},
+		{
+			name: "zero_width_space",
+			s:    "ab\u200bcd",
+			want: true,
+		},
+		{
+			name: "zero_width_joiner",
+			s:    "ab\u200dcd",
+			want: true,
+		},
+		{
+			name: "bidi_override",
+			s:    "abc\u202edef",
+			want: true,
+		},
+		{
+			name: "hangul_filler",
+			s:    "ab\u3164cd",
+			want: true,
+		},
+		{
+			name: "combining_grapheme_joiner",
+			s:    "ab\u034fcd",
+			want: true,
+		},
+		{
+			name: "leading_combining_mark",
+			s:    "\u0301abc",
+			want: true,
+		},
+		{
+			name: "decomposed_accent",
+			s:    "cafe\u0301",
+			want: false,
+		},
+		{
+			name: "emoji_variation_selector",
+			s:    "\u2139\ufe0f",
+			want: false,
+		},
+		{
+			name: "plain_ascii",
+			s:    "deploy-42",
+			want: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -639,28 +686,28 @@ func TestStyleValuePriority(t *testing.T) {
 	styles.Keys["count"] = new(keyStyle)
 
 	// Key style should win over number style.
-	assert.Equal(t, keyStyle.Render("42"), styleValue("42", 42, "count", kindNumber, styles, true))
+	assert.Equal(t, keyStyle.Render("42"), styleValue("42", 42, "count", kindNumber, styles, true, 0))
 
 	// Without key style, number style should apply.
 	assert.Equal(
 		t,
 		styles.FieldNumber.Render("42"),
-		styleValue("42", 42, "other", kindNumber, styles, true),
+		styleValue("42", 42, "other", kindNumber, styles, true, 0),
 	)
 
 	// Value style should apply for matching values (typed bool key).
 	assert.Equal(
 		t,
 		styles.Values[true].Render("true"),
-		styleValue("true", true, "field", kindBool, styles, true),
+		styleValue("true", true, "field", kindBool, styles, true, 0),
 	)
 
 	// No style for unrecognised default kind values.
-	assert.Empty(t, styleValue("something", "something", "field", kindDefault, styles, true))
+	assert.Empty(t, styleValue("something", "something", "field", kindDefault, styles, true, 0))
 
 	// No style for slices (styledFieldValue handles slices before calling
 	// styleValue, but if it does reach here the slice itself is not styled).
-	assert.Empty(t, styleValue("[1, 2]", []int{1, 2}, "field", kindSlice, styles, true))
+	assert.Empty(t, styleValue("[1, 2]", []int{1, 2}, "field", kindSlice, styles, true, 0))
 }
 
 func TestFormatFieldsIntSliceStyled(t *testing.T) {
@@ -765,6 +812,52 @@ func TestFormatFieldsStringSliceStyled(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestFormatFieldsStringSliceValuePatterns(t *testing.T) {
+	withTrueColor(t)
+
+	styles := DefaultStyles()
+	red := new(lipgloss.NewStyle().Foreground(lipgloss.Color("1")))
+	styles.ValuePatterns = []ValuePattern{{Pattern: regexp.MustCompile(`^prod`), Style: red}}
+	opts := formatFieldsOpts{
+		level:  InfoLevel,
+		styles: styles,
+	}
+
+	got := formatFields([]Field{{Key: "env", Value: []string{"prod", "dev"}}}, opts)
+
+	want := " " + styles.KeyDefault.Render(
+		"env",
+	) + styles.Separator.Render(
+		"=",
+	) + "[" + red.Render("prod") + ", " + styles.FieldString.Render("dev") + "]"
+	assert.Equal(t, want, got)
+}
+
+func TestValuePatternsPriority(t *testing.T) {
+	withTrueColor(t)
+
+	styles := DefaultStyles()
+	red := new(lipgloss.NewStyle().Foreground(lipgloss.Color("1")))
+	yellow := new(lipgloss.NewStyle().Foreground(lipgloss.Color("3")))
+	styles.Values["production"] = yellow
+	styles.ValuePatterns = []ValuePattern{
+		{Pattern: regexp.MustCompile(`^prod`), Style: red},
+		{Pattern: regexp.MustCompile(`^pro`), Style: yellow},
+	}
+
+	// Exact Values match wins over patterns.
+	style, _ := ResolveValueStyle("env", "production", styles)
+	assert.Same(t, yellow, style)
+
+	// First matching pattern wins.
+	style, _ = ResolveValueStyle("env", "prod-eu", styles)
+	assert.Same(t, red, style)
+
+	// Non-string values are never matched.
+	style, _ = ResolveValueStyle("env", 42, styles)
+	assert.Same(t, styles.FieldNumber, style)
+}
+
 func TestFormatFieldsSliceKeyStylePriority(t *testing.T) {
 	styles := DefaultStyles()
 	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("4"))
@@ -841,7 +934,7 @@ func TestFormatFieldsStylesSkippedBelowInfo(t *testing.T) {
 
 func TestStyledSliceBool(t *testing.T) {
 	styles := DefaultStyles()
-	got := styledSlice([]bool{true, false}, styles, true, QuoteAuto, 0, 0)
+	got := styledSlice([]bool{true, false}, styles, true, 0, boolWords{}, QuoteAuto, 0, 0)
 
 	trueStyled := styles.Values[true].Render("true")
 	falseStyled := styles.Values[false].Render("false")
@@ -853,7 +946,7 @@ func TestStyledSliceBool(t *testing.T) {
 func TestStyledSliceFloat64(t *testing.T) {
 	styles := DefaultStyles()
 	styles.FieldNumber = nil // disable number styling so output is plain
-	got := styledSlice([]float64{1.5, 2.5}, styles, true, QuoteAuto, 0, 0)
+	got := styledSlice([]float64{1.5, 2.5}, styles, true, 0, boolWords{}, QuoteAuto, 0, 0)
 
 	assert.Equal(t, "[1.5, 2.5]", got)
 }
@@ -912,7 +1005,7 @@ func TestFormatFieldsAnySliceKeyStylePriority(t *testing.T) {
 
 func TestStyledSliceAny(t *testing.T) {
 	styles := DefaultStyles()
-	got := styledSlice([]any{true, 42, "text"}, styles, true, QuoteAuto, 0, 0)
+	got := styledSlice([]any{true, 42, "text"}, styles, true, 0, boolWords{}, QuoteAuto, 0, 0)
 
 	trueStyled := styles.Values[true].Render("true")
 	numStyled := styles.FieldNumber.Render("42")
@@ -957,6 +1050,9 @@ func TestReflectValueKind(t *testing.T) {
 		{
 			name: "error", val: errors.New("fail"), want: kindError,
 		},
+		{
+			name: "diff", val: diff{before: 1, after: 2}, want: kindDiff,
+		},
 		{
 			name: "slice", val: []int{1}, want: kindDefault,
 		},
@@ -975,7 +1071,7 @@ func TestReflectValueKind(t *testing.T) {
 func TestStyledSliceDefault(t *testing.T) {
 	styles := DefaultStyles()
 	// Pass an unsupported slice type to exercise the default branch.
-	got := styledSlice([]byte{1, 2}, styles, true, QuoteAuto, 0, 0)
+	got := styledSlice([]byte{1, 2}, styles, true, 0, boolWords{}, QuoteAuto, 0, 0)
 
 	assert.Equal(t, "[1 2]", got)
 }
@@ -985,7 +1081,7 @@ func TestFormatBoolSliceNoMatchingValueStyle(t *testing.T) {
 	// Remove all value styles so the bool values have no matching style.
 	styles.Values = ValueStyleMap{}
 
-	got := formatBoolSlice([]bool{true, false}, styles)
+	got := formatBoolSlice([]bool{true, false}, styles, boolWords{})
 
 	assert.Equal(t, "[true, false]", got)
 }
@@ -1103,7 +1199,7 @@ func TestMergeFields(t *testing.T) {
 
 func TestStyleValueDuration(t *testing.T) {
 	styles := DefaultStyles()
-	got := styleValue("5s", 5*time.Second, "elapsed", kindDuration, styles, true)
+	got := styleValue("5s", 5*time.Second, "elapsed", kindDuration, styles, true, 0)
 
 	want := styles.FieldDurationNumber.Render("5") + styles.FieldDurationUnit.Render("s")
 	assert.Equal(t, want, got)
@@ -1114,14 +1210,14 @@ func TestStyleValueDurationNil(t *testing.T) {
 	styles.FieldDurationNumber = nil
 	styles.FieldDurationUnit = nil
 
-	got := styleValue("5s", 5*time.Second, "elapsed", kindDuration, styles, true)
+	got := styleValue("5s", 5*time.Second, "elapsed", kindDuration, styles, true, 0)
 	assert.Empty(t, got)
 }
 
 func TestStyleValueTime(t *testing.T) {
 	styles := DefaultStyles()
 	ts := time.Date(2025, 6, 15, 10, 30, 0, 0, time.UTC)
-	got := styleValue("2025-06-15 10:30:00", ts, "ts", kindTime, styles, true)
+	got := styleValue("2025-06-15 10:30:00", ts, "ts", kindTime, styles, true, 0)
 	assert.Equal(t, styles.FieldTime.Render("2025-06-15 10:30:00"), got)
 }
 
@@ -1135,20 +1231,21 @@ func TestStyleValueTimeNil(t *testing.T) {
 		kindTime,
 		styles,
 		true,
+		0,
 	)
 	assert.Empty(t, got)
 }
 
 func TestStyleValueError(t *testing.T) {
 	styles := DefaultStyles()
-	got := styleValue("boom", errors.New("boom"), "err", kindError, styles, true)
+	got := styleValue("boom", errors.New("boom"), "err", kindError, styles, true, 0)
 	assert.Equal(t, styles.FieldError.Render("boom"), got)
 }
 
 func TestStyleValueErrorNil(t *testing.T) {
 	styles := DefaultStyles()
 	styles.FieldError = nil
-	got := styleValue("boom", errors.New("boom"), "err", kindError, styles, true)
+	got := styleValue("boom", errors.New("boom"), "err", kindError, styles, true, 0)
 	assert.Empty(t, got)
 }
 
@@ -1157,7 +1254,7 @@ func TestStyleValuePerKeyMatch(t *testing.T) {
 	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
 	styles.Keys["status"] = new(keyStyle)
 
-	got := styleValue("running", "running", "status", kindString, styles, true)
+	got := styleValue("running", "running", "status", kindString, styles, true, 0)
 	assert.Equal(t, keyStyle.Render("running"), got)
 }
 
@@ -1167,26 +1264,26 @@ func TestStyleValuePerValueMatch(t *testing.T) {
 	styles.Values["running"] = new(valStyle)
 
 	// No key style set, so value style should apply.
-	got := styleValue("running", "running", "status", kindString, styles, true)
+	got := styleValue("running", "running", "status", kindString, styles, true, 0)
 	assert.Equal(t, valStyle.Render("running"), got)
 }
 
 func TestStyleAnyElementError(t *testing.T) {
 	styles := DefaultStyles()
-	got := styleAnyElement("boom", errors.New("boom"), kindError, styles, true)
+	got := styleAnyElement("boom", errors.New("boom"), kindError, styles, true, 0)
 	assert.Equal(t, styles.FieldError.Render("boom"), got)
 }
 
 func TestStyleAnyElementErrorNil(t *testing.T) {
 	styles := DefaultStyles()
 	styles.FieldError = nil
-	got := styleAnyElement("boom", errors.New("boom"), kindError, styles, true)
+	got := styleAnyElement("boom", errors.New("boom"), kindError, styles, true, 0)
 	assert.Empty(t, got)
 }
 
 func TestStyleAnyElementDuration(t *testing.T) {
 	styles := DefaultStyles()
-	got := styleAnyElement("5s", 5*time.Second, kindDuration, styles, true)
+	got := styleAnyElement("5s", 5*time.Second, kindDuration, styles, true, 0)
 
 	want := styles.FieldDurationNumber.Render("5") + styles.FieldDurationUnit.Render("s")
 	assert.Equal(t, want, got)
@@ -1197,20 +1294,20 @@ func TestStyleAnyElementDurationNil(t *testing.T) {
 	styles.FieldDurationNumber = nil
 	styles.FieldDurationUnit = nil
 
-	got := styleAnyElement("5s", 5*time.Second, kindDuration, styles, true)
+	got := styleAnyElement("5s", 5*time.Second, kindDuration, styles, true, 0)
 	assert.Empty(t, got)
 }
 
 func TestStyleAnyElementTime(t *testing.T) {
 	styles := DefaultStyles()
-	got := styleAnyElement("2025-06-15", "2025-06-15", kindTime, styles, true)
+	got := styleAnyElement("2025-06-15", "2025-06-15", kindTime, styles, true, 0)
 	assert.Equal(t, styles.FieldTime.Render("2025-06-15"), got)
 }
 
 func TestStyleAnyElementTimeNil(t *testing.T) {
 	styles := DefaultStyles()
 	styles.FieldTime = nil
-	got := styleAnyElement("2025-06-15", "2025-06-15", kindTime, styles, true)
+	got := styleAnyElement("2025-06-15", "2025-06-15", kindTime, styles, true, 0)
 	assert.Empty(t, got)
 }
 
@@ -1266,7 +1363,7 @@ func TestStyleQuantity(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := styleQuantity(tt.input, styles, true)
+			got := styleQuantity(tt.input, styles, true, 0)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -1278,7 +1375,7 @@ func TestStyleQuantityPartialNil(t *testing.T) {
 
 	styles.FieldQuantityNumber = nil
 
-	got := styleQuantity("5s", styles, true)
+	got := styleQuantity("5s", styles, true, 0)
 	assert.Equal(t, "5"+unit("s"), got)
 }
 
@@ -1306,6 +1403,16 @@ func TestIsQuantityString(t *testing.T) {
 		{name: "spaced", input: "5 m", want: true},
 		{name: "spaced_distance", input: "5.1 km", want: true},
 		{name: "spaced_filesize", input: "100 MB", want: true},
+		{name: "scientific", input: "1.5e3ms", want: true},
+		{name: "scientific_signed", input: "2E-3s", want: true},
+		{name: "e_unit", input: "5em", want: true},
+		{name: "rate", input: "2.5k/s", want: true},
+		{name: "bare_rate", input: "0.5/s", want: true},
+		{name: "byte_rate", input: "10MB/s", want: true},
+		{name: "fraction", input: "1/2", want: false},
+		{name: "trailing_slash", input: "5k/", want: false},
+		{name: "bare_scientific", input: "1e3", want: false},
+		{name: "grouped_without_sep", input: "1,000MB", want: false},
 		{name: "word", input: "hello", want: false},
 		{name: "empty", input: "", want: false},
 		{name: "bare_number", input: "42", want: false},
@@ -1319,7 +1426,7 @@ func TestIsQuantityString(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			assert.Equal(t, tt.want, isQuantityString(tt.input))
+			assert.Equal(t, tt.want, isQuantityString(tt.input, 0))
 		})
 	}
 }
@@ -1328,7 +1435,7 @@ func TestStyleValueQuantityFallbackToString(t *testing.T) {
 	styles := DefaultStyles()
 
 	// "hello" is not a valid quantity, so styleValue should fall back to FieldString.
-	got := styleValue("hello", quantity("hello"), "field", kindQuantity, styles, true)
+	got := styleValue("hello", quantity("hello"), "field", kindQuantity, styles, true, 0)
 	assert.Equal(t, styles.FieldString.Render("hello"), got)
 }
 
@@ -1337,14 +1444,14 @@ func TestStyleValueQuantityFallbackNilString(t *testing.T) {
 	styles.FieldString = nil
 
 	// No quantity match, no string style — should return "".
-	got := styleValue("hello", quantity("hello"), "field", kindQuantity, styles, true)
+	got := styleValue("hello", quantity("hello"), "field", kindQuantity, styles, true, 0)
 	assert.Empty(t, got)
 }
 
 func TestStyleAnyElementQuantityFallbackToString(t *testing.T) {
 	styles := DefaultStyles()
 
-	got := styleAnyElement("hello", quantity("hello"), kindQuantity, styles, true)
+	got := styleAnyElement("hello", quantity("hello"), kindQuantity, styles, true, 0)
 	assert.Equal(t, styles.FieldString.Render("hello"), got)
 }
 
@@ -1355,7 +1462,7 @@ func TestStyleQuantityUnitOverride(t *testing.T) {
 
 	num := styles.FieldQuantityNumber.Render
 
-	got := styleQuantity("5.1km", styles, true)
+	got := styleQuantity("5.1km", styles, true, 0)
 	assert.Equal(t, num("5.1")+kmStyle.Render("km"), got)
 }
 
@@ -1368,7 +1475,7 @@ func TestStyleQuantityUnitOverrideCompound(t *testing.T) {
 	unit := styles.FieldQuantityUnit.Render
 
 	// "h" gets the override, "m" gets the default.
-	got := styleQuantity("2h30m", styles, true)
+	got := styleQuantity("2h30m", styles, true, 0)
 	assert.Equal(t, num("2")+hStyle.Render("h")+num("30")+unit("m"), got)
 }
 
@@ -1380,7 +1487,7 @@ func TestStyleQuantityOnlyUnitOverrides(t *testing.T) {
 	kmStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
 	styles.QuantityUnits["km"] = new(kmStyle)
 
-	got := styleQuantity("5km", styles, true)
+	got := styleQuantity("5km", styles, true, 0)
 	assert.Equal(t, "5"+kmStyle.Render("km"), got)
 }
 
@@ -1392,7 +1499,7 @@ func TestStyleQuantityUnitIgnoreCase(t *testing.T) {
 	num := styles.FieldQuantityNumber.Render
 
 	// "MB" should match "mb" with case-insensitive lookup (default).
-	got := styleQuantity("100MB", styles, true)
+	got := styleQuantity("100MB", styles, true, 0)
 	assert.Equal(t, num("100")+mbStyle.Render("MB"), got)
 }
 
@@ -1406,7 +1513,7 @@ func TestStyleQuantityUnitCaseSensitive(t *testing.T) {
 	unit := styles.FieldQuantityUnit.Render
 
 	// "MB" should NOT match "mb" when case-sensitive.
-	got := styleQuantity("100MB", styles, false)
+	got := styleQuantity("100MB", styles, false, 0)
 	assert.Equal(t, num("100")+unit("MB"), got)
 }
 
@@ -1463,7 +1570,7 @@ func TestFormatFieldsDurationSliceStyled(t *testing.T) {
 
 func TestFormatQuantitySlicePlain(t *testing.T) {
 	vals := []quantity{"5m", "2h30m", "100 MB"}
-	got := formatQuantitySlice(vals, nil, true)
+	got := formatQuantitySlice(vals, nil, true, 0)
 	assert.Equal(t, "[5m, 2h30m, 100 MB]", got)
 }
 
@@ -1473,7 +1580,7 @@ func TestFormatQuantitySliceStyled(t *testing.T) {
 	unit := styles.FieldQuantityUnit.Render
 
 	vals := []quantity{"5m", "100MB"}
-	got := formatQuantitySlice(vals, styles, true)
+	got := formatQuantitySlice(vals, styles, true, 0)
 
 	want := "[" +
 		num("5") + unit("m") +
@@ -1484,7 +1591,7 @@ func TestFormatQuantitySliceStyled(t *testing.T) {
 }
 
 func TestFormatQuantitySliceEmpty(t *testing.T) {
-	got := formatQuantitySlice([]quantity{}, nil, true)
+	got := formatQuantitySlice([]quantity{}, nil, true, 0)
 	assert.Equal(t, "[]", got)
 }
 
@@ -1560,7 +1667,7 @@ func TestStyleThreshold(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := styleQuantity(tt.input, styles, true)
+			got := styleQuantity(tt.input, styles, true, 0)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -1577,7 +1684,7 @@ func TestStyleThresholdCompound(t *testing.T) {
 	}
 
 	// "12h30m" — "h" threshold fires for 12, "m" uses default.
-	got := styleQuantity("12h30m", styles, true)
+	got := styleQuantity("12h30m", styles, true, 0)
 	assert.Equal(t, redNum.Render("12")+unit("h")+num("30")+unit("m"), got)
 }
 
@@ -1591,11 +1698,11 @@ func TestStyleThresholdNilOverrides(t *testing.T) {
 		{Value: 30, Style: ThresholdStyle{Number: new(yellowNum)}},
 	}
 
-	got := styleQuantity("60s", styles, true)
+	got := styleQuantity("60s", styles, true, 0)
 	assert.Equal(t, yellowNum.Render("60")+styles.FieldQuantityUnit.Render("s"), got)
 
 	// Below threshold — uses default.
-	got = styleQuantity("5s", styles, true)
+	got = styleQuantity("5s", styles, true, 0)
 	assert.Equal(t, num("5")+styles.FieldQuantityUnit.Render("s"), got)
 }
 
@@ -1629,14 +1736,64 @@ func TestStyleThresholdIgnoreCase(t *testing.T) {
 	}
 
 	// "MB" should match "mb" threshold with case-insensitive matching (default).
-	got := styleQuantity("1000MB", styles, true)
+	got := styleQuantity("1000MB", styles, true, 0)
 	assert.Equal(t, redNum.Render("1000")+styles.FieldQuantityUnit.Render("MB"), got)
 
 	// Below threshold — uses default number style.
-	got = styleQuantity("100MB", styles, true)
+	got = styleQuantity("100MB", styles, true, 0)
 	assert.Equal(t, num("100")+styles.FieldQuantityUnit.Render("MB"), got)
 }
 
+func TestIsQuantityStringThousandsSep(t *testing.T) {
+	assert.True(t, isQuantityString("1,000MB", ','))
+	assert.True(t, isQuantityString("-12,345,678 B", ','))
+	assert.False(t, isQuantityString(",100MB", ','))
+	assert.False(t, isQuantityString("1,,000MB", ','))
+	assert.False(t, isQuantityString("1,000MB", '_'))
+}
+
+func TestStyleQuantityScientific(t *testing.T) {
+	styles := DefaultStyles()
+	num := styles.FieldQuantityNumber.Render
+	unit := styles.FieldQuantityUnit.Render
+
+	redNum := lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	styles.QuantityThresholds["ms"] = []Threshold{
+		{Value: 1000, Style: ThresholdStyle{Number: new(redNum)}},
+	}
+
+	// The exponent stays with the number and counts towards the threshold.
+	got := styleQuantity("1.5e3ms", styles, true, 0)
+	assert.Equal(t, redNum.Render("1.5e3")+unit("ms"), got)
+
+	got = styleQuantity("1.5e2ms", styles, true, 0)
+	assert.Equal(t, num("1.5e2")+unit("ms"), got)
+}
+
+func TestStyleQuantityThousandsSep(t *testing.T) {
+	styles := DefaultStyles()
+	num := styles.FieldQuantityNumber.Render
+	unit := styles.FieldQuantityUnit.Render
+
+	redNum := lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	styles.QuantityThresholds["mb"] = []Threshold{
+		{Value: 500, Style: ThresholdStyle{Number: new(redNum)}},
+	}
+
+	// The grouping separator stays with the number and is ignored for thresholds.
+	got := styleQuantity("1,000MB", styles, true, ',')
+	assert.Equal(t, redNum.Render("1,000")+unit("MB"), got)
+
+	got = styleQuantity("1,000 MB", styles, true, ',')
+	assert.Equal(t, redNum.Render("1,000")+" "+unit("MB"), got)
+
+	got = styleQuantity("0,400MB", styles, true, ',')
+	assert.Equal(t, num("0,400")+unit("MB"), got)
+
+	// Without a separator configured the value is not a quantity.
+	assert.Empty(t, styleQuantity("1,000MB", styles, true, 0))
+}
+
 func TestStyleThresholdOnlyOverridesEnabled(t *testing.T) {
 	styles := DefaultStyles()
 	styles.FieldQuantityNumber = nil
@@ -1649,11 +1806,11 @@ func TestStyleThresholdOnlyOverridesEnabled(t *testing.T) {
 	}
 
 	// Above threshold — threshold styles apply even with nil defaults.
-	got := styleQuantity("500ms", styles, true)
+	got := styleQuantity("500ms", styles, true, 0)
 	assert.Equal(t, redNum.Render("500")+redUnit.Render("ms"), got)
 
 	// Below threshold — no default styles, no threshold match.
-	got = styleQuantity("50ms", styles, true)
+	got = styleQuantity("50ms", styles, true, 0)
 	assert.Equal(t, "50ms", got)
 }
 
@@ -1685,7 +1842,7 @@ func TestStyleValueNilViaAny(t *testing.T) {
 
 	// Any("k", nil) -> formatValue returns "<nil>", kindDefault.
 	// styleValue should find the nil value style via lookupValueStyle.
-	got := styleValue("<nil>", nil, "k", kindDefault, styles, true)
+	got := styleValue("<nil>", nil, "k", kindDefault, styles, true, 0)
 	assert.NotEmpty(t, got, "nil value should be styled via Values[nil]")
 }
 
@@ -1699,12 +1856,12 @@ func TestStyleValueBoolMatchesTyped(t *testing.T) {
 	styles.FieldString = new(strStyle)
 
 	// Bool field true -> styled via typed Values[true].
-	got := styleValue("true", true, "ok", kindBool, styles, true)
+	got := styleValue("true", true, "ok", kindBool, styles, true, 0)
 	assert.Equal(t, boolStyle.Render("true"), got)
 
 	// String field "true" -> NOT styled via Values (no string "true" key).
 	// Should fall through to FieldString styling.
-	got = styleValue("true", "true", "ok", kindString, styles, true)
+	got = styleValue("true", "true", "ok", kindString, styles, true, 0)
 	assert.Equal(t, strStyle.Render("true"), got)
 }
 
@@ -1812,7 +1969,7 @@ func TestStylePercentSingleStop(t *testing.T) {
 
 func TestStyleValuePercent(t *testing.T) {
 	styles := DefaultStyles()
-	got := styleValue("75%", percent(75), "progress", kindPercent, styles, true)
+	got := styleValue("75%", percent(75), "progress", kindPercent, styles, true, 0)
 	assert.NotEmpty(t, got)
 	assert.Contains(t, got, "75%")
 }
@@ -1820,7 +1977,7 @@ func TestStyleValuePercent(t *testing.T) {
 func TestStyleValuePercentNilGradient(t *testing.T) {
 	styles := DefaultStyles()
 	styles.PercentGradient = nil
-	got := styleValue("50%", percent(50), "progress", kindPercent, styles, true)
+	got := styleValue("50%", percent(50), "progress", kindPercent, styles, true, 0)
 	assert.Empty(t, got)
 }
 
@@ -1844,9 +2001,59 @@ func TestStylePercentBaseStyleOnly(t *testing.T) {
 	assert.Equal(t, bold.Render("50%"), got)
 }
 
+func TestStylePercentThresholds(t *testing.T) {
+	withTrueColor(t)
+
+	green := new(lipgloss.NewStyle().Foreground(lipgloss.Color("2")))
+	yellow := new(lipgloss.NewStyle().Foreground(lipgloss.Color("3")))
+	red := new(lipgloss.NewStyle().Foreground(lipgloss.Color("1")))
+
+	styles := DefaultStyles()
+	// Deliberately unordered: the highest matching band wins.
+	styles.PercentThresholds = []PercentThreshold{
+		{AtLeast: 95, Style: red},
+		{AtLeast: 0, Style: green},
+		{AtLeast: 80, Style: yellow},
+	}
+
+	tests := []struct {
+		val  float64
+		want Style
+	}{
+		{0, green},
+		{50, green},
+		{79.9, green},
+		{80, yellow},
+		{90, yellow},
+		{95, red},
+		{99, red},
+		{100, red},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.FormatFloat(tt.val, 'f', -1, 64), func(t *testing.T) {
+			got := stylePercent("x%", percent(tt.val), styles)
+			assert.Equal(t, tt.want.Render("x%"), got)
+		})
+	}
+}
+
+func TestStylePercentThresholdsBelowAllBands(t *testing.T) {
+	withTrueColor(t)
+
+	styles := DefaultStyles()
+	styles.PercentThresholds = []PercentThreshold{
+		{AtLeast: 80, Style: new(lipgloss.NewStyle().Bold(true))},
+	}
+
+	// Falls back to the gradient.
+	withGradient := DefaultStyles()
+	assert.Equal(t, stylePercent("10%", percent(10), withGradient), stylePercent("10%", percent(10), styles))
+}
+
 func TestStyleAnyElementPercent(t *testing.T) {
 	styles := DefaultStyles()
-	got := styleAnyElement("75%", percent(75), kindPercent, styles, true)
+	got := styleAnyElement("75%", percent(75), kindPercent, styles, true, 0)
 	assert.NotEmpty(t, got)
 	assert.Contains(t, got, "75%")
 }
@@ -1949,7 +2156,7 @@ func TestStyleNumberUnit(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := styleNumberUnit(tt.input, tt.num, tt.unit, tt.overr, tt.thresh, tt.ignore)
+			got := styleNumberUnit(tt.input, tt.num, tt.unit, tt.overr, tt.thresh, tt.ignore, 0)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -2024,6 +2231,40 @@ func TestFormatFieldsSortNone(t *testing.T) {
 	assert.Equal(t, " c=3 a=1", got)
 }
 
+func TestFormatFieldsOrder(t *testing.T) {
+	opts := formatFieldsOpts{
+		fieldOrder: []string{"op", "id"},
+		noColor:    true,
+	}
+
+	fields := []Field{
+		{Key: "id", Value: "42"},
+		{Key: "x", Value: "y"},
+		{Key: "op", Value: "get"},
+	}
+
+	got := formatFields(fields, opts)
+	assert.Equal(t, " op=get id=42 x=y", got)
+
+	// Original slice must not be mutated.
+	assert.Equal(t, "id", fields[0].Key)
+}
+
+func TestFormatFieldsOrderThenSort(t *testing.T) {
+	opts := formatFieldsOpts{
+		fieldOrder: []string{"op"},
+		fieldSort:  SortAscending,
+		noColor:    true,
+	}
+
+	got := formatFields([]Field{
+		{Key: "c", Value: "3"},
+		{Key: "op", Value: "get"},
+		{Key: "a", Value: "1"},
+	}, opts)
+	assert.Equal(t, " op=get a=1 c=3", got)
+}
+
 func TestElapsedFormatFunc(t *testing.T) {
 	opts := formatFieldsOpts{
 		noColor: true,
@@ -2301,3 +2542,290 @@ func TestStyleElapsed(t *testing.T) {
 		assert.Empty(t, got)
 	})
 }
+
+func TestStyledFieldValueDurationUsesQuantityStyles(t *testing.T) {
+	styles := DefaultStyles()
+
+	redNum := lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	styles.QuantityThresholds["s"] = []Threshold{
+		{Value: 30, Style: ThresholdStyle{Number: new(redNum)}},
+	}
+
+	opts := formatFieldsOpts{
+		level:  InfoLevel,
+		styles: styles,
+	}
+
+	f := Field{Key: "took", Value: 45 * time.Second}
+
+	// Disabled (default): duration styles apply, quantity thresholds ignored.
+	got := styledFieldValue(f, "45s", kindDuration, opts)
+	assert.Equal(
+		t,
+		styles.FieldDurationNumber.Render("45")+styles.FieldDurationUnit.Render("s"),
+		got,
+	)
+
+	// Enabled: quantity thresholds apply.
+	opts.durationUsesQuantityStyles = true
+	got = styledFieldValue(f, "45s", kindDuration, opts)
+	assert.Equal(t, redNum.Render("45")+styles.FieldQuantityUnit.Render("s"), got)
+
+	// Slices route through quantity styling too.
+	f = Field{Key: "took", Value: []time.Duration{45 * time.Second, 5 * time.Second}}
+	got = styledFieldValue(f, "[45s, 5s]", kindSlice, opts)
+	assert.Equal(t, formatQuantitySlice([]quantity{"45s", "5s"}, styles, true, 0), got)
+	assert.Contains(t, got, redNum.Render("45"))
+}
+
+func TestTruncateMiddle(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		head, tail int
+		want       string
+	}{
+		{"long", "abcd0123456789012345678901234567890wxyz", 4, 4, "abcd…wxyz"},
+		{"short", "abcdwxyz", 4, 4, "abcdwxyz"},
+		{"exact_fit", "abcd1wxyz", 4, 4, "abcd1wxyz"},
+		{"head_only", "abcdefgh", 2, 0, "ab…"},
+		{"tail_only", "abcdefgh", 0, 2, "…gh"},
+		{"runes", "日本語のテキストです", 2, 2, "日本…です"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, truncateMiddle(tt.input, tt.head, tt.tail))
+		})
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		maxLen int
+		mode   TruncateMode
+		want   string
+	}{
+		{"end", "long message", 10, TruncateEnd, "long mess…"},
+		{"middle", "long message", 8, TruncateMiddle, "long…age"},
+		{"fits", "short", 5, TruncateMiddle, "short"},
+		{"unlimited", "long message", 0, TruncateEnd, "long message"},
+		{"runes", "日本語のテキスト", 9, TruncateMiddle, "日本…スト"},
+		{"ansi", "\x1b[1mlong message\x1b[0m", 8, TruncateMiddle, "long…age"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateText(tt.input, tt.maxLen, tt.mode)
+			assert.Equal(t, tt.want, StripANSI(got))
+		})
+	}
+}
+
+func TestEscapeControl(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain", "hello", "hello"},
+		{"clear_screen", "a\x1b[2Jb", `a\x1b[2Jb`},
+		{"carriage_return", "ok\rfail", `ok\x0dfail`},
+		{"bell_and_del", "\a\x7f", `\x07\x7f`},
+		{"c1_csi", "\u009b2J", `\u009b2J`},
+		{"newline_and_tab", "a\n\tb", "a\n\tb"},
+		{"unicode", "日本語", "日本語"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, escapeControl(tt.input))
+		})
+	}
+}
+
+func TestEscapeControlFieldsDoesNotMutateInput(t *testing.T) {
+	fields := []Field{
+		{Key: "a", Value: "x\x1b[2J"},
+		{Key: "b", Value: []string{"ok", "\x1b]0;title\a"}},
+		{Key: "c", Value: 1},
+	}
+
+	got := escapeControlFields(fields)
+
+	assert.Equal(t, `x\x1b[2J`, got[0].Value)
+	assert.Equal(t, []string{"ok", `\x1b]0;title\x07`}, got[1].Value)
+	assert.Equal(t, 1, got[2].Value)
+	assert.Equal(t, "x\x1b[2J", fields[0].Value)
+	assert.Equal(t, []string{"ok", "\x1b]0;title\a"}, fields[1].Value)
+}
+
+func TestPadColumnIgnoresANSI(t *testing.T) {
+	withTrueColor(t)
+
+	styles := DefaultStyles()
+	opts := formatFieldsOpts{
+		durationColumnWidth: 6,
+		level:               InfoLevel,
+		styles:              styles,
+	}
+
+	short := formatFields([]Field{{Key: "d", Value: 500 * time.Millisecond}}, opts)
+	long := formatFields([]Field{{Key: "d", Value: 1200 * time.Millisecond}}, opts)
+
+	require.Contains(t, short, "\x1b[")
+	assert.Equal(t, lipgloss.Width(short), lipgloss.Width(long))
+	assert.Equal(t, len(" d=")+6, lipgloss.Width(short))
+}
+
+func TestGroupDigits(t *testing.T) {
+	tests := []struct {
+		in   string
+		sep  rune
+		want string
+	}{
+		{"9876543210", ',', "9,876,543,210"},
+		{"-1234", ',', "-1,234"},
+		{"123", ',', "123"},
+		{"-123", ',', "-123"},
+		{"1234.5678", ',', "1,234.5678"},
+		{"1000000", '.', "1.000.000"},
+		{"1234", 0, "1234"},
+		{"NaN", ',', "NaN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			assert.Equal(t, tt.want, groupDigits(tt.in, tt.sep))
+		})
+	}
+}
+
+func TestFormatRate(t *testing.T) {
+	tests := []struct {
+		name  string
+		count int64
+		per   time.Duration
+		want  string
+	}{
+		{name: "kilo", count: 5000, per: 2 * time.Second, want: "2.5k/s"},
+		{name: "plain", count: 120, per: time.Second, want: "120/s"},
+		{name: "rounded", count: 1000, per: 3 * time.Second, want: "333.3/s"},
+		{name: "sub_one", count: 1, per: 2 * time.Second, want: "0.5/s"},
+		{name: "small", count: 1, per: 30 * time.Second, want: "0.033/s"},
+		{name: "zero_count", count: 0, per: time.Second, want: "0/s"},
+		{name: "zero_duration", count: 10, per: 0, want: "∞/s"},
+		{name: "mega", count: 3_000_000, per: time.Second, want: "3M/s"},
+		{name: "carry", count: 999_990, per: time.Second, want: "1M/s"},
+		{name: "per_minute", count: 600, per: time.Minute, want: "10/s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatRate(tt.count, tt.per))
+		})
+	}
+}
+
+func TestAutoColorStyle(t *testing.T) {
+	withTrueColor(t)
+
+	a := autoColorStyle("web-1").Render("x")
+	b := autoColorStyle("web-2").Render("x")
+
+	require.Contains(t, a, "\x1b[")
+	assert.NotEqual(t, a, b)
+	assert.Equal(t, a, autoColorStyle("web-1").Render("x"))
+}
+
+func TestFormatFieldsAutoColorKeys(t *testing.T) {
+	withTrueColor(t)
+
+	styles := DefaultStyles()
+	opts := formatFieldsOpts{
+		autoColorKeys: map[string]bool{"host": true},
+		level:         InfoLevel,
+		styles:        styles,
+	}
+
+	got := formatFields([]Field{
+		{Key: "host", Value: "web-1"},
+		{Key: "user", Value: "web-1"},
+	}, opts)
+
+	want := " " + styles.KeyDefault.Render("host") + styles.Separator.Render("=") +
+		autoColorStyle("web-1").Render("web-1") +
+		" " + styles.KeyDefault.Render("user") + styles.Separator.Render("=") +
+		styles.FieldString.Render("web-1")
+	assert.Equal(t, want, got)
+
+	// Key styles take precedence.
+	styles.Keys["host"] = new(lipgloss.NewStyle().Bold(true))
+	got = formatFields([]Field{{Key: "host", Value: "web-1"}}, opts)
+	assert.Contains(t, got, styles.Keys["host"].Render("web-1"))
+}
+
+func TestPadColumn(t *testing.T) {
+	assert.Equal(t, "   ab", padColumn("ab", 5))
+	assert.Equal(t, "abcdef", padColumn("abcdef", 5))
+	assert.Equal(t, "ab", padColumn("ab", 0))
+}
+
+func TestResolveValueStylePriority(t *testing.T) {
+	styles := DefaultStyles()
+	keyStyle := new(lipgloss.NewStyle().Foreground(lipgloss.Color("4")))
+	styles.Keys["count"] = keyStyle
+
+	// Key style should win over number style.
+	style, kind := ResolveValueStyle("count", 42, styles)
+	assert.Same(t, keyStyle, style)
+	assert.Equal(t, ValueKindNumber, kind)
+
+	// Without key style, number style should apply.
+	style, kind = ResolveValueStyle("other", 42, styles)
+	assert.Same(t, styles.FieldNumber, style)
+	assert.Equal(t, ValueKindNumber, kind)
+
+	// Value style should apply for matching values (typed bool key).
+	style, kind = ResolveValueStyle("field", true, styles)
+	assert.Same(t, styles.Values[true], style)
+	assert.Equal(t, ValueKindBool, kind)
+
+	// No style for slices.
+	style, kind = ResolveValueStyle("field", []int{1, 2}, styles)
+	assert.Nil(t, style)
+	assert.Equal(t, ValueKindSlice, kind)
+}
+
+func TestResolveValueStyleKinds(t *testing.T) {
+	styles := DefaultStyles()
+
+	style, kind := ResolveValueStyle("err", errors.New("boom"), styles)
+	assert.Same(t, styles.FieldError, style)
+	assert.Equal(t, ValueKindError, kind)
+
+	style, kind = ResolveValueStyle("cmd", command("git status"), styles)
+	assert.Same(t, cmp.Or(styles.FieldCmd, styles.FieldString), style)
+	assert.Equal(t, ValueKindCmd, kind)
+
+	// Durations are styled per segment, so no single style applies.
+	style, kind = ResolveValueStyle("took", time.Second, styles)
+	assert.Nil(t, style)
+	assert.Equal(t, ValueKindDuration, kind)
+
+	style, kind = ResolveValueStyle("size", quantity("5GB"), nil)
+	assert.Nil(t, style)
+	assert.Equal(t, ValueKindQuantity, kind)
+}
+
+func TestValueKindValues(t *testing.T) {
+	// Public kinds are stable regardless of internal ordering.
+	assert.Equal(t, ValueKind(0), ValueKindDefault)
+	assert.Equal(t, ValueKind(13), ValueKindTime)
+	assert.Len(t, publicValueKinds, int(kindTime)+1)
+	for k, pk := range publicValueKinds[kindBool:] {
+		assert.NotEqual(t, ValueKindDefault, pk, "kind %d unmapped", k+int(kindBool))
+	}
+}
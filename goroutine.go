@@ -0,0 +1,58 @@
+package clog
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+)
+
+// goroutineKey is the field key for goroutine and worker ids.
+const goroutineKey = "goroutine"
+
+// WithWorker returns a sub-logger that tags every entry with a "goroutine"
+// field holding id, regardless of [Logger.SetReportGoroutine]. Use it to
+// label worker pools with stable, meaningful ids.
+//
+//	for i := range 4 {
+//		go work(logger.WithWorker(i))
+//	}
+func (l *Logger) WithWorker(id int) *Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	sub := l.clone()
+	sub.mu = l.mu // share mutex
+	sub.worker = &id
+	sub.atomicLevel.Store(int32(sub.gateLevel())) //nolint:gosec // Level values are small constants (0-6)
+	return sub
+}
+
+// goroutineID returns the id to report for the calling goroutine: the
+// [Logger.WithWorker] id if set, else the runtime goroutine id when
+// [Logger.SetReportGoroutine] is enabled. The caller must hold l.mu.
+func (l *Logger) goroutineID() (int, bool) {
+	if l.worker != nil {
+		return *l.worker, true
+	}
+	if !l.reportGoroutine {
+		return 0, false
+	}
+	return currentGoroutineID(), true
+}
+
+// currentGoroutineID parses the calling goroutine's id from the header of
+// its stack trace ("goroutine 42 [running]:"). Returns 0 if the header
+// cannot be parsed.
+func currentGoroutineID() int {
+	var buf [64]byte
+	n := runtime.Stack(buf[:], false)
+
+	b := bytes.TrimPrefix(buf[:n], []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, err := strconv.Atoi(string(b))
+	if err != nil {
+		return 0
+	}
+	return id
+}
@@ -0,0 +1,107 @@
+package clog
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportGoroutineDisabledByDefault(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Info().Msg("hello")
+
+	assert.Equal(t, "INF ℹ️ hello\n", buf.String())
+}
+
+func TestReportGoroutine(t *testing.T) {
+	var got Entry
+
+	l := NewWriter(io.Discard)
+	l.SetReportGoroutine(true)
+	l.SetHandler(HandlerFunc(func(e Entry) { got = e }))
+	l.Info().Str("k", "v").Msg("hello")
+
+	require.Len(t, got.Fields, 2)
+	assert.Equal(t, goroutineKey, got.Fields[0].Key)
+	id, ok := got.Fields[0].Value.(int)
+	require.True(t, ok)
+	assert.Positive(t, id)
+	assert.Equal(t, currentGoroutineID(), id)
+}
+
+func TestReportGoroutineConcurrent(t *testing.T) {
+	var (
+		mu  sync.Mutex
+		ids []int
+	)
+
+	l := NewWriter(io.Discard)
+	l.SetReportGoroutine(true)
+	l.SetHandler(HandlerFunc(func(e Entry) {
+		mu.Lock()
+		defer mu.Unlock()
+		ids = append(ids, e.Fields[0].Value.(int))
+	}))
+
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Go(func() { l.Info().Msg("work") })
+	}
+	wg.Wait()
+
+	require.Len(t, ids, 2)
+	assert.NotEqual(t, ids[0], ids[1])
+}
+
+func TestWithWorker(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	w := l.WithWorker(0)
+	w.Info().Str("k", "v").Msg("hello")
+	l.Info().Msg("parent")
+
+	assert.Equal(t, "INF ℹ️ hello goroutine=0 k=v\nINF ℹ️ parent\n", buf.String())
+}
+
+func TestWithWorkerOverridesGoroutine(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetReportGoroutine(true)
+	l.WithWorker(7).Info().Msg("hello")
+
+	assert.Equal(t, "INF ℹ️ hello goroutine=7\n", buf.String())
+}
+
+func TestPartGoroutine(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartLevel, PartGoroutine, PartMessage, PartFields)
+	l.WithWorker(3).Info().Str("k", "v").Msg("hello")
+	l.Info().Msg("untagged")
+
+	assert.Equal(t, "INF goroutine=3 hello k=v\nINF untagged\n", buf.String())
+}
+
+func TestCurrentGoroutineIDDiffers(t *testing.T) {
+	main := currentGoroutineID()
+
+	var other int
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		other = currentGoroutineID()
+	}()
+	<-done
+
+	assert.Positive(t, other)
+	assert.NotEqual(t, main, other)
+}
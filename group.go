@@ -94,7 +94,7 @@ func (g *Group) Wait() *GroupResult {
 				formatFields(*s.fieldsPtr.Load(), s.fieldOpts), " ",
 			)
 			line := buildLine(s.cfg.order, s.cfg.reportTS,
-				time.Now().In(s.cfg.timeLoc).Format(s.cfg.timeFmt),
+				formatTimestamp(time.Now().In(s.cfg.timeLoc), s.cfg.timeFmt),
 				s.cfg.label, s.prefix, *s.msgPtr.Load(), fieldsStr)
 			_, _ = io.WriteString(s.cfg.out, line+"\n")
 		}
@@ -204,10 +204,11 @@ func (ge *GroupEntry) Progress(task ProgressTask) *SlotResult {
 	g := ge.group
 
 	update := &ProgressUpdate{
-		msg:       b.msg,
-		msgPtr:    s.msgPtr,
-		fieldsPtr: s.fieldsPtr,
-		base:      b.fields,
+		msg:        b.msg,
+		msgPtr:     s.msgPtr,
+		fieldsPtr:  s.fieldsPtr,
+		base:       b.fields,
+		stepsTotal: b.stepsTotal,
 	}
 	if b.mode == animationBar {
 		update.progressPtr = b.barProgressPtr
@@ -414,10 +415,11 @@ func (r *GroupResult) joinErrors() error {
 type slotConfig struct {
 	isTTY       bool      // output.IsTTY()
 	label       string    // pre-computed padded label
-	levelPrefix string    // styled label (via styles.Levels[level])
+	levelBadge  bool      // l.levelBadge
+	levelPrefix string    // styled label (via styledLevel)
 	noColor     bool      // output.ColorsDisabled()
 	order       []Part    // l.parts
-	out         io.Writer // output.Writer()
+	out         io.Writer // writer()
 	output      *Output   // for Width() in bar mode
 	reportTS    bool
 	styles      *Styles
@@ -461,45 +463,56 @@ func captureSlotConfig(s *groupSlot) {
 	l := b.resolveLogger()
 	l.mu.Lock()
 	s.cfg = slotConfig{
-		isTTY:    l.output.IsTTY(),
-		label:    l.formatLabel(b.level),
-		noColor:  l.output.ColorsDisabled(),
-		order:    l.parts,
-		out:      l.output.Writer(),
-		output:   l.output,
-		reportTS: l.reportTimestamp,
-		styles:   l.styles,
-		termOut:  l.output.Renderer().Output(),
-		timeFmt:  l.timeFormat,
-		timeLoc:  l.timeLocation,
+		isTTY:      l.output.IsTTY(),
+		label:      l.formatLabel(b.level),
+		levelBadge: l.levelBadge,
+		noColor:    l.output.ColorsDisabled(),
+		order:      l.parts,
+		out:        l.writer(),
+		output:     l.output,
+		reportTS:   l.reportTimestamp,
+		styles:     l.styles,
+		termOut:    l.output.Renderer().Output(),
+		timeFmt:    l.timeFormat,
+		timeLoc:    l.timeLocation,
 	}
 	s.fieldOpts = formatFieldsOpts{
-		elapsedFormatFunc:       l.elapsedFormatFunc,
-		elapsedMinimum:          l.elapsedMinimum,
-		elapsedPrecision:        l.elapsedPrecision,
-		elapsedRound:            l.elapsedRound,
-		fieldSort:               l.fieldSort,
-		fieldStyleLevel:         l.fieldStyleLevel,
-		level:                   b.level,
-		noColor:                 l.output.ColorsDisabled(),
-		percentFormatFunc:       l.percentFormatFunc,
-		percentPrecision:        l.percentPrecision,
-		quantityUnitsIgnoreCase: l.quantityUnitsIgnoreCase,
-		quoteOpen:               l.quoteOpen,
-		quoteClose:              l.quoteClose,
-		quoteMode:               l.quoteMode,
-		separatorText:           l.separatorText,
-		styles:                  l.styles,
-		timeFormat:              l.fieldTimeFormat,
+		autoColorKeys:              l.autoColorKeys,
+		boolWords:                  l.boolWords,
+		durationColumnWidth:        l.durationColumnWidth,
+		durationUsesQuantityStyles: l.durationUsesQuantityStyles,
+		elapsedFormatFunc:          l.elapsedFormatFunc,
+		elapsedMinimum:             l.elapsedMinimum,
+		elapsedPrecision:           l.elapsedPrecision,
+		elapsedRound:               l.elapsedRound,
+		emptyRepr:                  l.emptyRepr,
+		fieldKeyAlign:              l.fieldKeyAlign,
+		fieldOrder:                 l.fieldOrder,
+		fieldSort:                  l.fieldSort,
+		fieldStyleLevel:            l.styleFieldsLevel(),
+		keyTruncate:                l.keyTruncate,
+		level:                      b.level,
+		measurePrecision:           l.measurePrecision,
+		nilRepr:                    l.nilRepr,
+		noColor:                    l.output.ColorsDisabled(),
+		numberGrouping:             l.numberGrouping,
+		percentFormatFunc:          l.percentFormatFunc,
+		percentPrecision:           l.percentPrecision,
+		quantityColumnWidth:        l.quantityColumnWidth,
+		quantityThousandsSep:       l.quantityThousandsSep,
+		quantityUnitsIgnoreCase:    l.quantityUnitsIgnoreCase,
+		quoteOpen:                  l.quoteOpen,
+		quoteClose:                 l.quoteClose,
+		quoteMode:                  l.quoteMode,
+		separatorText:              l.separatorText,
+		statsPrecision:             l.statsPrecision,
+		styles:                     l.styles,
+		timeFormat:                 l.fieldTimeFormat,
 	}
 	l.mu.Unlock()
 
 	// Styled level prefix.
-	if style := s.cfg.styles.Levels[b.level]; style != nil && !s.cfg.noColor {
-		s.cfg.levelPrefix = style.Render(s.cfg.label)
-	} else {
-		s.cfg.levelPrefix = s.cfg.label
-	}
+	s.cfg.levelPrefix = styledLevel(s.cfg.label, b.level, s.cfg.styles, s.cfg.levelBadge, s.cfg.noColor)
 
 	// Resolve the prefix icon.
 	s.prefix = b.prefix
@@ -549,6 +562,8 @@ func buildLine(order []Part, reportTS bool, tsStr, levelStr, prefix, msg, fields
 			part = msg
 		case PartFields:
 			part = fieldsStr
+		case PartGoroutine:
+			// Animations are not tagged with goroutine ids.
 		}
 		if part != "" {
 			parts = append(parts, part)
@@ -586,7 +601,7 @@ func renderSlotTimestamp(s *groupSlot) string {
 	if !s.cfg.reportTS {
 		return ""
 	}
-	ts := time.Now().In(s.cfg.timeLoc).Format(s.cfg.timeFmt)
+	ts := formatTimestamp(time.Now().In(s.cfg.timeLoc), s.cfg.timeFmt)
 	if s.cfg.styles.Timestamp != nil && !s.cfg.noColor {
 		return s.cfg.styles.Timestamp.Render(ts)
 	}
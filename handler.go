@@ -1,6 +1,16 @@
 package clog
 
-import "time"
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
 
 // Handler processes log entries. Implement this interface to customise
 // how log entries are formatted and output (e.g. JSON logging).
@@ -18,6 +28,304 @@ type HandlerFunc func(Entry)
 // Log calls f(e).
 func (f HandlerFunc) Log(e Entry) { f(e) }
 
+// MultiHandler returns a [Handler] that fans each [Entry] out to every
+// handler in order. Nil handlers are skipped.
+//
+//	logger.SetHandler(clog.MultiHandler(
+//	    clog.PrettyHandler(clog.New(clog.Stderr(clog.ColorAuto))),
+//	    clog.NewJSONHandler(auditFile, nil),
+//	))
+func MultiHandler(handlers ...Handler) Handler {
+	hs := make([]Handler, 0, len(handlers))
+	for _, h := range handlers {
+		if h != nil {
+			hs = append(hs, h)
+		}
+	}
+
+	return HandlerFunc(func(e Entry) {
+		for _, h := range hs {
+			h.Log(e)
+		}
+	})
+}
+
+// HandlerMiddleware wraps a [Handler] to add behaviour at the handler
+// boundary, such as injecting fields or dropping entries. Install it with
+// [Logger.UseHandlerMiddleware].
+type HandlerMiddleware func(Handler) Handler
+
+// FieldInjector returns a [HandlerMiddleware] that adds fields to every
+// [Entry] before the next handler sees it. The fields are placed before
+// the entry's own fields.
+func FieldInjector(fields ...Field) HandlerMiddleware {
+	fields = slices.Clone(fields)
+
+	return func(next Handler) Handler {
+		return HandlerFunc(func(e Entry) {
+			e.Fields = slices.Concat(fields, e.Fields)
+			next.Log(e)
+		})
+	}
+}
+
+// Sampler returns a [HandlerMiddleware] that passes only the first of every
+// n entries to the next handler. Entries at [ErrorLevel] and above are
+// always passed. An n of 1 or less passes every entry.
+func Sampler(n int) HandlerMiddleware {
+	return func(next Handler) Handler {
+		if n <= 1 {
+			return next
+		}
+
+		var count atomic.Uint64
+
+		return HandlerFunc(func(e Entry) {
+			if e.Level >= ErrorLevel || (count.Add(1)-1)%uint64(n) == 0 {
+				next.Log(e)
+			}
+		})
+	}
+}
+
+// PrettyHandler returns a [Handler] that renders each [Entry] with l's
+// built-in pretty formatter (see [Logger.Render]) and writes it to l's
+// [Output]. The handler configured on l, if any, is ignored.
+//
+// Handlers are invoked while the emitting logger's lock is held. When that
+// logger shares l's lock (l itself, or a sub-logger created from l with
+// [Logger.With], [Logger.Tree] and the like), the entry is rendered under
+// the lock already held, so the handler must be called synchronously from
+// the handler chain rather than from another goroutine.
+func PrettyHandler(l *Logger) Handler {
+	return HandlerFunc(func(e Entry) {
+		if e.logger == nil || e.logger.mu != l.mu {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+		}
+		_, _ = io.WriteString(l.writer(), l.render(e, l.entryParts(e))+"\n")
+	})
+}
+
+// JSONOptions configures a handler returned by [NewJSONHandler].
+type JSONOptions struct {
+	// DurationAsNumber encodes durations, including [Event.Elapsed] values,
+	// as a number of milliseconds (e.g. 1500) rather than a string
+	// (e.g. "1.5s"), so they can be queried numerically.
+	DurationAsNumber bool
+}
+
+// NewJSONHandler returns a [Handler] that writes each [Entry] to w as a
+// single-line JSON object. The object holds "time" (RFC 3339, omitted when
+// zero), "level", "prefix" (omitted when empty) and "msg", followed by the
+// entry's fields in order.
+//
+// Field values keep their JSON types: numbers are encoded as numbers, bools
+// as bools, times as RFC 3339 strings and [Event.RawJSON] values are
+// embedded as-is. Errors are encoded as strings, as are durations unless
+// [JSONOptions.DurationAsNumber] is set. Values that cannot be encoded fall
+// back to their [fmt.Sprint] string. ANSI escapes (e.g. hyperlinks) are
+// stripped with [StripANSI]. opts may be nil.
+func NewJSONHandler(w io.Writer, opts *JSONOptions) Handler {
+	var mu sync.Mutex
+	var o JSONOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	return HandlerFunc(func(e Entry) {
+		buf := marshalJSONEntry(e, o)
+
+		mu.Lock()
+		defer mu.Unlock()
+		_, _ = w.Write(buf)
+	})
+}
+
+// NewCSVHandler returns a [Handler] that writes entries to w as CSV rows,
+// one column per name in columns, preceded by a header row of the column
+// names before the first entry. A column holds the value of the entry's
+// field with that key, formatted as in pretty output without colours, or is
+// empty if the entry has no such field. The names "level", "message" and
+// "time" (RFC 3339) select the entry's own values instead. Quoting follows
+// [encoding/csv]. The handler is safe for concurrent use.
+//
+//	logger.SetHandler(clog.NewCSVHandler(f, []string{"time", "file", "status"}))
+func NewCSVHandler(w io.Writer, columns []string) Handler {
+	var mu sync.Mutex
+	var wroteHeader bool
+	cw := csv.NewWriter(w)
+	columns = slices.Clone(columns)
+
+	return HandlerFunc(func(e Entry) {
+		row := make([]string, len(columns))
+		for i, col := range columns {
+			row[i] = csvColumn(e, col)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if !wroteHeader {
+			_ = cw.Write(columns)
+			wroteHeader = true
+		}
+		_ = cw.Write(row)
+		cw.Flush()
+	})
+}
+
+// csvColumn returns the value of the named column for [NewCSVHandler].
+func csvColumn(e Entry, col string) string {
+	switch col {
+	case "level":
+		name, _ := e.Level.MarshalText()
+		return string(name)
+	case "message":
+		return StripANSI(e.Message)
+	case "time":
+		if e.Time.IsZero() {
+			return ""
+		}
+		return e.Time.Format(time.RFC3339Nano)
+	}
+
+	// The last field with the key wins, as when fields are merged.
+	for _, f := range slices.Backward(e.Fields) {
+		if f.Key == col {
+			return csvValue(f.Value)
+		}
+	}
+	return ""
+}
+
+// csvValue formats a field value as plain text for [NewCSVHandler].
+func csvValue(v any) string {
+	v, _ = unwrapNoted(v)
+	v = unwrapSQLNull(v)
+	v, precision := unwrapPrecise(v)
+	precision = max(precision, 0)
+
+	if ls, ok := v.(textLines); ok {
+		return StripANSI(strings.Join(ls, "\n"))
+	}
+	s, _ := formatValue(v, QuoteNever, 0, 0, time.RFC3339, precision, precision)
+	return StripANSI(s)
+}
+
+// marshalJSONEntry encodes an entry as a newline-terminated JSON object,
+// preserving field order.
+func marshalJSONEntry(e Entry, opts JSONOptions) []byte {
+	buf := make([]byte, 0, 128) //nolint:mnd // initial capacity
+	buf = append(buf, '{')
+
+	appendPair := func(key string, val any) {
+		if len(buf) > 1 {
+			buf = append(buf, ',')
+		}
+
+		k, _ := json.Marshal(key)
+		buf = append(buf, k...)
+		buf = append(buf, ':')
+
+		v, err := json.Marshal(jsonHandlerValue(val, opts))
+		if err != nil {
+			v, _ = json.Marshal(fmt.Sprint(val))
+		}
+		buf = append(buf, v...)
+	}
+
+	if !e.Time.IsZero() {
+		appendPair("time", e.Time.Format(time.RFC3339Nano))
+	}
+
+	appendPair("level", e.Level)
+
+	if e.Prefix != "" {
+		appendPair("prefix", e.Prefix)
+	}
+
+	appendPair("msg", StripANSI(e.Message))
+
+	for _, f := range e.Fields {
+		appendPair(f.Key, f.Value)
+	}
+
+	return append(buf, '}', '\n')
+}
+
+// jsonHandlerValue converts field values that have no useful JSON encoding
+// into ones that do.
+func jsonHandlerValue(v any, opts JSONOptions) any {
+	v, _ = unwrapNoted(v)
+	v = unwrapSQLNull(v)
+	v, _ = unwrapPrecise(v)
+
+	switch v := v.(type) {
+	case string:
+		return StripANSI(v)
+	case []string:
+		out := make([]string, len(v))
+		for i, s := range v {
+			out[i] = StripANSI(s)
+		}
+		return out
+	case callerSite:
+		return v.String()
+	case enums:
+		return jsonHandlerValue(v.strings(), opts)
+	case error:
+		return StripANSI(v.Error())
+	case time.Duration:
+		return jsonDuration(v, opts)
+	case []time.Duration:
+		out := make([]any, len(v))
+		for i, d := range v {
+			out[i] = jsonDuration(d, opts)
+		}
+		return out
+	case elapsed:
+		return jsonDuration(time.Duration(v), opts)
+	case backoff:
+		return jsonDuration(time.Duration(v), opts)
+	case percent:
+		return float64(v)
+	case quantity:
+		return string(v)
+	case measurement:
+		return v.String()
+	case rawJSON:
+		return json.RawMessage(v)
+	case stats:
+		out := map[string]any{"n": v.count}
+		if v.count > 0 {
+			out["min"], out["avg"], out["max"] = v.minimum, v.mean, v.maximum
+		}
+		return out
+	case sparkline:
+		return []float64(v)
+	case textLines:
+		return StripANSI(strings.Join(v, "\n"))
+	case unknownBool:
+		return nil
+	case validationErrors:
+		out := make(map[string]string, len(v))
+		for _, f := range v {
+			out[f.Key] = StripANSI(fmt.Sprint(f.Value))
+		}
+		return out
+	}
+	return v
+}
+
+// jsonDuration encodes d as milliseconds when [JSONOptions.DurationAsNumber]
+// is set, or as its string form otherwise.
+func jsonDuration(d time.Duration, opts JSONOptions) any {
+	if opts.DurationAsNumber {
+		return float64(d) / float64(time.Millisecond)
+	}
+	return d.String()
+}
+
 // Field is a typed key-value pair attached to a log entry.
 type Field struct {
 	Key   string `json:"key"`
@@ -26,9 +334,18 @@ type Field struct {
 
 // Entry represents a completed log entry passed to a [Handler].
 type Entry struct {
+	DryRun  bool      `json:"dry_run,omitempty"` // set by Logger.SetDryRun
 	Fields  []Field   `json:"fields,omitempty"`
 	Level   Level     `json:"level"`
 	Message string    `json:"message"`
 	Prefix  string    `json:"prefix,omitempty"`
 	Time    time.Time `json:"time,omitzero"`
+
+	// logger is the logger that emitted the entry, used by the pretty
+	// formatter wrapped by [Logger.UseHandlerMiddleware]. Its mu is held
+	// while the handler runs.
+	logger *Logger
+
+	hiddenParts []Part // set by Event.HidePart
+	onlyParts   []Part // set by Event.OnlyParts; nil = use the logger's parts
 }
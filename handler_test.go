@@ -1,8 +1,13 @@
 package clog
 
 import (
+	"bytes"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"io"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -153,3 +158,385 @@ func TestEntryJSONMarshal(t *testing.T) {
 		assert.JSONEq(t, want, string(data))
 	})
 }
+
+func TestMultiHandler(t *testing.T) {
+	var first, second []string
+
+	h := MultiHandler(
+		HandlerFunc(func(e Entry) { first = append(first, e.Message) }),
+		nil,
+		HandlerFunc(func(e Entry) { second = append(second, e.Message) }),
+	)
+
+	l := NewWriter(io.Discard)
+	l.SetHandler(h)
+	l.Info().Msg("one")
+	l.Warn().Msg("two")
+
+	assert.Equal(t, []string{"one", "two"}, first)
+	assert.Equal(t, []string{"one", "two"}, second)
+}
+
+func TestUseHandlerMiddlewareFieldInjector(t *testing.T) {
+	l := NewWriter(io.Discard)
+
+	var got Entry
+
+	l.SetHandler(HandlerFunc(func(e Entry) {
+		got = e
+	}))
+	l.UseHandlerMiddleware(FieldInjector(Field{Key: "host", Value: "web-1"}))
+
+	l.Info().Str("k", "v").Msg("hello")
+
+	assert.Equal(t, "hello", got.Message)
+	assert.Equal(t, []Field{{Key: "host", Value: "web-1"}, {Key: "k", Value: "v"}}, got.Fields)
+}
+
+func TestUseHandlerMiddlewareOrder(t *testing.T) {
+	l := NewWriter(io.Discard)
+
+	var calls []string
+
+	trace := func(name string) HandlerMiddleware {
+		return func(next Handler) Handler {
+			return HandlerFunc(func(e Entry) {
+				calls = append(calls, name)
+				next.Log(e)
+			})
+		}
+	}
+
+	l.SetHandler(HandlerFunc(func(Entry) {
+		calls = append(calls, "handler")
+	}))
+	l.UseHandlerMiddleware(trace("a"), nil, trace("b"))
+	l.UseHandlerMiddleware(trace("c"))
+
+	l.Info().Msg("hello")
+
+	assert.Equal(t, []string{"c", "a", "b", "handler"}, calls)
+}
+
+func TestUseHandlerMiddlewareWithoutHandler(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.UseHandlerMiddleware(FieldInjector(Field{Key: "host", Value: "web-1"}))
+
+	l.Info().Msg("hello")
+
+	assert.Equal(t, "INF ℹ️ hello host=web-1\n", buf.String())
+}
+
+func TestUseHandlerMiddlewareWithoutHandlerSubLogger(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.UseHandlerMiddleware(FieldInjector(Field{Key: "host", Value: "web-1"}))
+
+	// The sub-logger shares the middleware chain but renders with its own
+	// configuration.
+	sub := l.With().Logger()
+	sub.SetParts(PartMessage, PartFields)
+
+	sub.Info().Msg("hello")
+	l.Info().Msg("hello")
+
+	assert.Equal(t, "hello host=web-1\nINF ℹ️ hello host=web-1\n", buf.String())
+}
+
+func TestUseHandlerMiddlewareJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := NewWriter(io.Discard)
+	l.SetHandler(NewJSONHandler(&buf, nil))
+	l.UseHandlerMiddleware(FieldInjector(Field{Key: "host", Value: "web-1"}))
+
+	l.Info().Int("n", 1).Msg("hello")
+
+	assert.JSONEq(t, `{"level":"info","prefix":"ℹ️","msg":"hello","host":"web-1","n":1}`, buf.String())
+}
+
+func TestSampler(t *testing.T) {
+	l := NewWriter(io.Discard)
+
+	var msgs []string
+
+	l.SetHandler(HandlerFunc(func(e Entry) {
+		msgs = append(msgs, e.Message)
+	}))
+	l.UseHandlerMiddleware(Sampler(3))
+
+	for _, m := range []string{"a", "b", "c", "d", "e"} {
+		l.Info().Msg(m)
+	}
+	l.Error().Msg("err")
+
+	assert.Equal(t, []string{"a", "d", "err"}, msgs)
+}
+
+func TestSamplerPassesAllWhenNOne(t *testing.T) {
+	var n int
+
+	h := Sampler(1)(HandlerFunc(func(Entry) { n++ }))
+	for range 3 {
+		h.Log(Entry{})
+	}
+
+	assert.Equal(t, 3, n)
+}
+
+func TestPrettyHandler(t *testing.T) {
+	var buf bytes.Buffer
+
+	pretty := New(TestOutput(&buf))
+	pretty.SetParts(PartLevel, PartMessage, PartFields)
+
+	l := NewWriter(io.Discard)
+	l.SetHandler(PrettyHandler(pretty))
+	l.Info().Str("k", "v").Msg("hello")
+
+	assert.Equal(t, "INF hello k=v\n", buf.String())
+}
+
+func TestPrettyHandlerSharedLock(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartLevel, PartMessage, PartFields)
+	sub := l.With().Str("k", "v").Logger()
+	sub.SetHandler(MultiHandler(PrettyHandler(l)))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		sub.Info().Msg("hello")
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("PrettyHandler deadlocked on a shared lock")
+	}
+	assert.Equal(t, "INF hello k=v\n", buf.String())
+}
+
+func TestPrettyHandlerEventParts(t *testing.T) {
+	var buf bytes.Buffer
+
+	pretty := New(TestOutput(&buf))
+	pretty.SetParts(PartLevel, PartMessage, PartFields)
+
+	l := NewWriter(io.Discard)
+	l.SetHandler(PrettyHandler(pretty))
+	l.Info().HidePart(PartLevel).Str("k", "v").Msg("hidden")
+	l.Info().OnlyParts(PartFields, PartMessage).Str("k", "v").Msg("only")
+
+	assert.Equal(t, "hidden k=v\nk=v only\n", buf.String())
+}
+
+func TestRender(t *testing.T) {
+	l := New(TestOutput(io.Discard))
+	l.SetParts(PartTimestamp, PartLevel, PartMessage, PartFields)
+	l.SetTimeFormat(time.DateOnly)
+
+	got := l.Render(Entry{
+		Level:   WarnLevel,
+		Message: "careful",
+		Fields:  []Field{{Key: "n", Value: 1}},
+		Time:    time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	})
+
+	assert.Equal(t, "2025-01-02 WRN careful n=1", got)
+}
+
+func TestNewJSONHandler(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := NewWriter(io.Discard)
+	l.SetHandler(NewJSONHandler(&buf, nil))
+	l.Error().
+		Str("path", "/tmp").
+		Err(errors.New("boom")).
+		Duration("took", 1500*time.Millisecond).
+		Int("n", 3).
+		Msg("failed")
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 1)
+	assert.JSONEq(
+		t,
+		`{"level":"error","prefix":"❌","msg":"failed","path":"/tmp","error":"boom","took":"1.5s","n":3}`,
+		lines[0],
+	)
+
+	// Field order is preserved.
+	assert.Less(t, strings.Index(lines[0], `"path"`), strings.Index(lines[0], `"n"`))
+}
+
+func TestNewJSONHandlerTypes(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := NewWriter(io.Discard)
+	l.SetHandler(NewJSONHandler(&buf, nil))
+	l.Info().
+		Int("n", 42).
+		Uint64("u", 7).
+		Float64("ratio", 0.5).
+		Bool("ok", true).
+		Time("at", time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)).
+		RawJSON("raw", []byte(`{"a":[1,2]}`)).
+		Durations("waits", []time.Duration{time.Second, 250 * time.Millisecond}).
+		Msg("typed")
+
+	assert.JSONEq(
+		t,
+		`{"level":"info","prefix":"ℹ️","msg":"typed","n":42,"u":7,"ratio":0.5,"ok":true,`+
+			`"at":"2025-01-02T03:04:05Z","raw":{"a":[1,2]},"waits":["1s","250ms"]}`,
+		buf.String(),
+	)
+	assert.Contains(t, buf.String(), `"n":42,`)
+	assert.Contains(t, buf.String(), `"ok":true,`)
+}
+
+func TestJSONHandlerDurationAsNumber(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := NewWriter(io.Discard)
+	l.SetHandler(NewJSONHandler(&buf, &JSONOptions{DurationAsNumber: true}))
+	l.Info().
+		Duration("took", 1500*time.Millisecond).
+		Durations("waits", []time.Duration{time.Second, 250 * time.Microsecond}).
+		Msg("done")
+
+	assert.JSONEq(
+		t,
+		`{"level":"info","prefix":"ℹ️","msg":"done","took":1500,"waits":[1000,0.25]}`,
+		buf.String(),
+	)
+}
+
+func TestNewJSONHandlerSQLNull(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := NewWriter(io.Discard)
+	l.SetHandler(NewJSONHandler(&buf, nil))
+	l.Info().
+		Any("name", sql.NullString{String: "x", Valid: true}).
+		Any("age", sql.NullInt64{}).
+		Msg("row")
+
+	assert.JSONEq(t, `{"level":"info","prefix":"ℹ️","msg":"row","name":"x","age":null}`, buf.String())
+}
+
+func TestNewJSONHandlerTime(t *testing.T) {
+	var buf bytes.Buffer
+
+	h := NewJSONHandler(&buf, nil)
+	h.Log(Entry{
+		Level:   InfoLevel,
+		Message: "hi",
+		Time:    time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	})
+
+	assert.Equal(t, `{"time":"2025-01-02T03:04:05Z","level":"info","msg":"hi"}`+"\n", buf.String())
+}
+
+func TestNewCSVHandler(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := NewWriter(io.Discard)
+	l.SetHandler(NewCSVHandler(&buf, []string{"level", "message", "file", "size"}))
+	l.Info().Str("file", "a.txt").Int("size", 10).Msg("copied")
+	l.Warn().Str("file", "b, c.txt").Msg(`skipped "b"`)
+	l.Error().Int("size", 0).Msg("empty")
+
+	want := "level,message,file,size\n" +
+		"info,copied,a.txt,10\n" +
+		"warn,\"skipped \"\"b\"\"\",\"b, c.txt\",\n" +
+		"error,empty,,0\n"
+	assert.Equal(t, want, buf.String())
+}
+
+func TestNewCSVHandlerTime(t *testing.T) {
+	var buf bytes.Buffer
+
+	h := NewCSVHandler(&buf, []string{"time", "took", "tags"})
+	h.Log(Entry{
+		Time:   time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Fields: []Field{{Key: "took", Value: 1500 * time.Millisecond}, {Key: "tags", Value: []string{"x", "y"}}},
+	})
+	h.Log(Entry{})
+
+	assert.Equal(t, "time,took,tags\n2025-01-02T03:04:05Z,1.5s,\"[x, y]\"\n,,\n", buf.String())
+}
+
+func TestNewCSVHandlerConcurrent(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := NewWriter(io.Discard)
+	l.SetHandler(NewCSVHandler(&buf, []string{"message", "n"}))
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Go(func() { l.Info().Int("n", i).Msg("row") })
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 11)
+	assert.Equal(t, "message,n", lines[0])
+}
+
+func TestMultiHandlerPrettyAndJSON(t *testing.T) {
+	var pretty, audit bytes.Buffer
+
+	p := New(TestOutput(&pretty))
+	p.SetParts(PartLevel, PartMessage)
+
+	l := NewWriter(io.Discard)
+	l.SetHandler(MultiHandler(PrettyHandler(p), NewJSONHandler(&audit, nil)))
+	l.Info().Msg("first")
+	l.Warn().Msg("second")
+
+	assert.Equal(t, "INF first\nWRN second\n", pretty.String())
+	assert.Equal(
+		t,
+		`{"level":"info","prefix":"ℹ️","msg":"first"}`+"\n"+
+			`{"level":"warn","prefix":"⚠️","msg":"second"}`+"\n",
+		audit.String(),
+	)
+}
+
+func TestNewJSONHandlerStripsANSI(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(NewOutput(io.Discard, ColorAlways))
+	l.SetHandler(NewJSONHandler(&buf, nil))
+	l.Info().
+		Link("docs", "https://example.com", "docs").
+		Strs("tags", []string{"\x1b[32mok\x1b[0m"}).
+		Msg("\x1b[1mbold\x1b[0m")
+
+	assert.NotContains(t, buf.String(), `\u001b`)
+	assert.JSONEq(
+		t,
+		`{"level":"info","prefix":"ℹ️","msg":"bold","docs":"docs","tags":["ok"]}`,
+		buf.String(),
+	)
+}
+
+func TestResolveValueStyleInHandler(t *testing.T) {
+	styles := DefaultStyles()
+
+	// Resolving styles from a handler must not take any logger's lock.
+	var got Style
+	l := NewWriter(io.Discard)
+	l.SetHandler(HandlerFunc(func(e Entry) {
+		got, _ = ResolveValueStyle(e.Fields[0].Key, e.Fields[0].Value, styles)
+	}))
+	l.Info().Int("count", 42).Msg("x")
+
+	assert.Same(t, styles.FieldNumber, got)
+}
@@ -232,6 +232,30 @@ func (o *Output) pathLink(path string, line, column int) string {
 	return osc8(resolvePathURL(path, line, column), display)
 }
 
+// callerLink renders a source location as "display:line", linked to path
+// when hyperlinks are enabled. Used by [Event.Caller] and friends.
+func (o *Output) callerLink(display, path string, line int) string {
+	text := pathDisplayText(display, line, 0)
+
+	if !hyperlinksEnabled.Load() || o.ColorsDisabled() {
+		return text
+	}
+	return osc8(resolvePathURL(path, line, 0), text)
+}
+
+// shortCallerPath trims path to its last directory and file name (e.g.
+// "/src/app/server/handler.go" becomes "server/handler.go").
+func shortCallerPath(path string) string {
+	i := strings.LastIndexByte(path, '/')
+	if i <= 0 {
+		return path
+	}
+	if j := strings.LastIndexByte(path[:i], '/'); j >= 0 {
+		return path[j+1:]
+	}
+	return path
+}
+
 // absPath resolves a path to its absolute form.
 // Returns the original path if resolution fails.
 func absPath(path string) string {
@@ -319,6 +319,20 @@ func TestOutputPathLinkAlwaysDir(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestOutputCallerLink(t *testing.T) {
+	clearFormats(t)
+
+	output := NewOutput(io.Discard, ColorAlways)
+	hyperlinksEnabled.Store(true)
+	defer hyperlinksEnabled.Store(false)
+
+	// The short path is displayed; the link targets the full path.
+	got := output.callerLink("app/test.go", "/tmp/app/test.go", 42)
+	want := "\x1b]8;;file:///tmp/app/test.go\x1b\\app/test.go:42\x1b]8;;\x1b\\"
+
+	assert.Equal(t, want, got)
+}
+
 func TestOutputPathLinkNever(t *testing.T) {
 	output := NewOutput(io.Discard, ColorNever)
 	got := output.pathLink("/tmp/test.go", 42, 0)
@@ -2,13 +2,15 @@ package clog
 
 import (
 	"bytes"
+	"encoding/json"
 	"strconv"
 	"strings"
 )
 
 // highlightJSON applies syntax highlighting to s using the provided styles.
 // Inter-token whitespace is stripped, flattening pretty-printed JSON to a
-// single line. Returns s unchanged when styles is nil.
+// single line, unless [JSONStyles.PreserveWhitespace] is set. Returns s
+// unchanged when styles is nil.
 //
 // The scanner is defensive: on any unexpected byte the remaining input is
 // emitted unstyled rather than panicking.
@@ -34,18 +36,28 @@ func highlightJSON(s string, styles *JSONStyles) string {
 	expectKey := false
 	hjson := styles.Mode == JSONModeHuman
 
+	// The original whitespace takes the place of the inserted spacing.
+	spacing := styles.Spacing
+	if styles.PreserveWhitespace {
+		spacing = 0
+	}
+
 	for i < n {
 		c := data[i]
 
-		// strip inter-token whitespace to flatten pretty-printed JSON
+		// strip inter-token whitespace to flatten pretty-printed JSON,
+		// or keep it (minus carriage returns) when preserving whitespace
 		if c == ' ' || c == '\t' || c == '\n' || c == '\r' {
+			if styles.PreserveWhitespace && c != '\r' {
+				buf.WriteByte(c)
+			}
 			i++
 			continue
 		}
 
 		switch {
 		case c == '{':
-			if len(stack) > 0 && styles.Spacing&JSONSpacingBeforeObject != 0 {
+			if len(stack) > 0 && spacing&JSONSpacingBeforeObject != 0 {
 				buf.WriteByte(' ')
 			}
 			braceStyle := styles.Brace
@@ -70,7 +82,7 @@ func highlightJSON(s string, styles *JSONStyles) string {
 			i++
 
 		case c == '[':
-			if len(stack) > 0 && styles.Spacing&JSONSpacingBeforeArray != 0 {
+			if len(stack) > 0 && spacing&JSONSpacingBeforeArray != 0 {
 				buf.WriteByte(' ')
 			}
 			bracketStyle := styles.Bracket
@@ -94,7 +106,7 @@ func highlightJSON(s string, styles *JSONStyles) string {
 
 		case c == ':':
 			emitStyled(&buf, ":", styles.Colon)
-			if styles.Spacing&JSONSpacingAfterColon != 0 {
+			if spacing&JSONSpacingAfterColon != 0 {
 				buf.WriteByte(' ')
 			}
 			expectKey = false
@@ -104,7 +116,7 @@ func highlightJSON(s string, styles *JSONStyles) string {
 			if !styles.OmitCommas {
 				emitStyled(&buf, ",", styles.Comma)
 			}
-			if styles.Spacing&JSONSpacingAfterComma != 0 {
+			if spacing&JSONSpacingAfterComma != 0 {
 				buf.WriteByte(' ')
 			}
 			if len(stack) > 0 && stack[len(stack)-1] == '{' {
@@ -220,6 +232,68 @@ func resolveStringToken(raw string, isKey, hjson bool, styles *JSONStyles) (stri
 	return raw, styles.String
 }
 
+// maxJSONSpanCandidates caps the opening brackets [findJSONSpan] tries, as
+// each attempt scans the rest of the string.
+const maxJSONSpanCandidates = 16
+
+// findJSONSpan returns the byte range of the first JSON object or array
+// embedded in s: a span starting at '{' or '[' whose brackets balance
+// (ignoring those inside strings) and which is valid JSON. Candidates that
+// fail either check are skipped, up to [maxJSONSpanCandidates] of them.
+// Reports false when no span is found.
+func findJSONSpan(s string) (int, int, bool) {
+	candidates := 0
+	for start := 0; start < len(s) && candidates < maxJSONSpanCandidates; start++ {
+		if s[start] != '{' && s[start] != '[' {
+			continue
+		}
+		candidates++
+		if end := matchJSONBrackets(s, start); end > 0 && json.Valid([]byte(s[start:end])) {
+			return start, end, true
+		}
+	}
+	return 0, 0, false
+}
+
+// matchJSONBrackets returns the index just past the bracket closing the one
+// at s[start], skipping string contents, or -1 if brackets do not balance.
+func matchJSONBrackets(s string, start int) int {
+	var stack []byte
+	inString := false
+
+	for i := start; i < len(s); i++ {
+		c := s[i]
+
+		if inString {
+			switch c {
+			case '\\':
+				i++
+			case '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch c {
+		case '"':
+			inString = true
+		case '{':
+			stack = append(stack, '}')
+		case '[':
+			stack = append(stack, ']')
+		case '}', ']':
+			if len(stack) == 0 || stack[len(stack)-1] != c {
+				return -1
+			}
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				return i + 1
+			}
+		}
+	}
+	return -1
+}
+
 // renderFlatJSON flattens nested object keys with dot notation and renders
 // the result using human-mode quoting. Arrays are rendered intact.
 // Non-object root values fall back to human-mode rendering.
@@ -364,6 +438,60 @@ func collectFlatPairs(data []byte, prefix string) []flatPair {
 	return pairs
 }
 
+// jsonFields flattens a JSON object into individual fields with dotted keys
+// under prefix (via [collectFlatPairs]). Invalid JSON falls back to a single
+// string field; valid non-object JSON is kept as a single [rawJSON] field.
+func jsonFields(prefix string, data []byte) []Field {
+	if !json.Valid(data) {
+		return []Field{{Key: prefix, Value: string(data)}}
+	}
+
+	data = bytes.TrimSpace(data)
+	if data[0] != '{' {
+		return []Field{{Key: prefix, Value: rawJSON(data)}}
+	}
+
+	pairs := collectFlatPairs(data, prefix)
+	fields := make([]Field, len(pairs))
+	for i, p := range pairs {
+		fields[i] = Field{Key: p.key, Value: jsonLeafValue(p.value)}
+	}
+	return fields
+}
+
+// jsonLeafValue converts a raw JSON leaf (as produced by [collectFlatPairs])
+// to a Go value so it is styled like a regular field. Integers become int64,
+// other numbers float64, and arrays are kept as [rawJSON].
+func jsonLeafValue(raw []byte) any {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	switch raw[0] {
+	case '"':
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			return s
+		}
+	case '[':
+		return rawJSON(raw)
+	case 't':
+		return true
+	case 'f':
+		return false
+	case 'n':
+		return nil
+	default:
+		if n, err := strconv.ParseInt(string(raw), 10, 64); err == nil {
+			return n
+		}
+		if f, err := strconv.ParseFloat(string(raw), 64); err == nil {
+			return f
+		}
+	}
+	return string(raw)
+}
+
 // scanJSONValueEnd returns the index one past the end of the JSON value
 // starting at i in data. Handles strings, objects, arrays, and bare literals.
 func scanJSONValueEnd(data []byte, i int) int {
@@ -3,6 +3,7 @@ package clog
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/stretchr/testify/assert"
@@ -13,6 +14,57 @@ import (
 // highlightJSON
 // ---------------------------------------------------------------------------
 
+func TestFindJSONSpan(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		want  string
+		found bool
+	}{
+		{name: "object_suffix", in: `response: {"ok":true}`, want: `{"ok":true}`, found: true},
+		{name: "array_middle", in: `ids [1,2,3] done`, want: `[1,2,3]`, found: true},
+		{name: "brace_in_string", in: `got {"a":"}{"} ok`, want: `{"a":"}{"}`, found: true},
+		{name: "escaped_quote", in: `x {"a":"\"}"}`, want: `{"a":"\"}"}`, found: true},
+		{name: "skips_invalid", in: `[warn] {"ok":1}`, want: `{"ok":1}`, found: true},
+		{name: "unbalanced", in: `open {"a":1`, found: false},
+		{name: "not_json", in: `map[a:1] {oops}`, found: false},
+		{name: "plain", in: `hello world`, found: false},
+		{
+			name:  "candidate_cap",
+			in:    strings.Repeat("[", maxJSONSpanCandidates) + ` {"a":1}`,
+			found: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, ok := findJSONSpan(tt.in)
+			require.Equal(t, tt.found, ok)
+			if ok {
+				assert.Equal(t, tt.want, tt.in[start:end])
+			}
+		})
+	}
+}
+
+func TestFindJSONSpanUnmatchedBrackets(t *testing.T) {
+	// Many unmatched brackets must not make the search quadratic.
+	in := strings.Repeat("[", 1<<20) + ` {"a":1}`
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _, ok := findJSONSpan(in)
+		assert.False(t, ok)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("findJSONSpan did not return")
+	}
+}
+
 func TestHighlightJSON(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -324,6 +376,48 @@ func TestHighlightJSONOmitCommasWithSpacing(t *testing.T) {
 	assert.Equal(t, `{"a":1 "b":2}`, got)
 }
 
+func TestHighlightJSONPreserveWhitespace(t *testing.T) {
+	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	numStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+
+	in := "{\n  \"a\": 1,\n  \"b\": [\n    2\n  ]\n}"
+
+	styles := &JSONStyles{
+		PreserveWhitespace: true,
+		Spacing:            JSONSpacingAll,
+		Key:                new(keyStyle),
+		Number:             new(numStyle),
+	}
+
+	got := highlightJSON(in, styles)
+	assert.Contains(t, got, "\n  "+keyStyle.Render(`"a"`)+": "+numStyle.Render("1"))
+	assert.Equal(t, in, highlightJSON(in, &JSONStyles{PreserveWhitespace: true}))
+
+	// Carriage returns are dropped.
+	assert.Equal(t,
+		"{\n  \"a\": 1\n}",
+		highlightJSON("{\r\n  \"a\": 1\r\n}", &JSONStyles{PreserveWhitespace: true}),
+	)
+
+	// The default flattens.
+	assert.Equal(t, `{"a":1,"b":[2]}`, highlightJSON(in, &JSONStyles{}))
+}
+
+func TestHighlightJSONPreserveWhitespaceField(t *testing.T) {
+	var buf strings.Builder
+
+	styles := DefaultStyles()
+	styles.FieldJSON = &JSONStyles{PreserveWhitespace: true}
+
+	l := New(NewOutput(&buf, ColorAlways))
+	l.SetStyles(styles)
+	l.SetParts(PartMessage, PartFields)
+	l.Info().RawJSON("cfg", []byte("{\n  \"a\": 1\n}")).Msg("loaded")
+
+	// Continuation lines are indented relative to the value column.
+	assert.Contains(t, StripANSI(buf.String()), "loaded cfg={\n             \"a\": 1\n           }\n")
+}
+
 func TestHighlightJSONStyled(t *testing.T) {
 	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
 	numStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
@@ -532,6 +626,67 @@ func TestCollectFlatPairsMalformedKey(t *testing.T) {
 	assert.Empty(t, pairs)
 }
 
+// ---------------------------------------------------------------------------
+// jsonFields
+// ---------------------------------------------------------------------------
+
+func TestJSONFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		input  string
+		want   []Field
+	}{
+		{
+			name:   "nested_object",
+			prefix: "error",
+			input:  `{"status":422,"detail":"invalid","meta":{"retry":true,"ratio":0.5}}`,
+			want: []Field{
+				{Key: "error.status", Value: int64(422)},
+				{Key: "error.detail", Value: "invalid"},
+				{Key: "error.meta.retry", Value: true},
+				{Key: "error.meta.ratio", Value: 0.5},
+			},
+		},
+		{
+			name:   "empty_prefix",
+			prefix: "",
+			input:  `{"a":{"b":null}}`,
+			want:   []Field{{Key: "a.b", Value: nil}},
+		},
+		{
+			name:   "array_kept_intact",
+			prefix: "p",
+			input:  `{"tags":["a","b"]}`,
+			want:   []Field{{Key: "p.tags", Value: rawJSON(`["a","b"]`)}},
+		},
+		{
+			name:   "escaped_string",
+			prefix: "p",
+			input:  `{"msg":"say \"hi\""}`,
+			want:   []Field{{Key: "p.msg", Value: `say "hi"`}},
+		},
+		{
+			name:   "non_object_root",
+			prefix: "p",
+			input:  ` [1,2] `,
+			want:   []Field{{Key: "p", Value: rawJSON(`[1,2]`)}},
+		},
+		{
+			name:   "invalid_json",
+			prefix: "p",
+			input:  `{not json`,
+			want:   []Field{{Key: "p", Value: `{not json`}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, jsonFields(tt.prefix, []byte(tt.input)))
+		})
+	}
+}
+
 // ---------------------------------------------------------------------------
 // scanJSONValueEnd
 // ---------------------------------------------------------------------------
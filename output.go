@@ -10,6 +10,30 @@ import (
 	"golang.org/x/term"
 )
 
+// ColorProfile is the range of colours an [Output] can display. Colours in
+// styles that the profile can't display are converted to the nearest one
+// it can.
+type ColorProfile int
+
+const (
+	// ProfileTrueColor supports 24-bit colours.
+	ProfileTrueColor ColorProfile = iota
+	// ProfileANSI256 supports the 256-colour palette.
+	ProfileANSI256
+	// ProfileANSI16 supports the 16 basic ANSI colours.
+	ProfileANSI16
+	// ProfileASCII supports no colours.
+	ProfileASCII
+)
+
+// termenvProfiles maps each [ColorProfile] to its [termenv.Profile].
+var termenvProfiles = map[ColorProfile]termenv.Profile{
+	ProfileTrueColor: termenv.TrueColor,
+	ProfileANSI256:   termenv.ANSI256,
+	ProfileANSI16:    termenv.ANSI,
+	ProfileASCII:     termenv.Ascii,
+}
+
 // Output bundles an [io.Writer] with its detected terminal capabilities
 // (TTY, width, color profile). Each [Logger] holds an *Output so that
 // capability detection is per-writer instead of per-process.
@@ -22,6 +46,7 @@ type Output struct {
 	widthMu   sync.Mutex
 	widthDone bool
 	width     int
+	height    int
 }
 
 // NewOutput creates a new Output that wraps w. TTY detection is automatic
@@ -59,21 +84,103 @@ func TestOutput(w io.Writer) *Output {
 	return NewOutput(w, ColorNever)
 }
 
+// NewTestOutputColor returns an Output for tests that behaves like a
+// terminal of the given width: colors are forced on ([ColorAlways]),
+// [Output.IsTTY] reports true, [Output.Width] returns width and the
+// background is treated as dark. This makes color- and width-dependent
+// output deterministic when writing to a buffer. It is intended for tests
+// only; use [NewOutput] for real writers.
+//
+// [Styles] render through lipgloss's default renderer, which has no color
+// profile under "go test"; set one with [lipgloss.SetColorProfile] to see
+// escapes in the output.
+func NewTestOutputColor(w io.Writer, width int) *Output {
+	o := NewOutput(w, ColorAlways)
+	o.isTTY = true
+	o.renderer.SetHasDarkBackground(true)
+
+	o.widthDone = true
+	o.width = width
+
+	return o
+}
+
 // Writer returns the underlying [io.Writer].
 func (o *Output) Writer() io.Writer { return o.w }
 
 // IsTTY returns true if the writer is connected to a terminal.
 func (o *Output) IsTTY() bool { return o.isTTY }
 
+// IsTerminal returns true if the writer is connected to a terminal.
+// It is an alias for [Output.IsTTY].
+func (o *Output) IsTerminal() bool { return o.isTTY }
+
 // ColorsDisabled returns true if this output should suppress colors.
 func (o *Output) ColorsDisabled() bool {
 	return o.renderer.ColorProfile() == termenv.Ascii
 }
 
+// ColorProfile returns the colour profile of the output. For [ColorAuto]
+// terminals it is detected from the COLORTERM and TERM environment
+// variables; [ColorAlways] uses [ProfileTrueColor] and [ColorNever] uses
+// [ProfileASCII].
+func (o *Output) ColorProfile() ColorProfile {
+	p := o.renderer.ColorProfile()
+	for cp, tp := range termenvProfiles {
+		if tp == p {
+			return cp
+		}
+	}
+	return ProfileTrueColor
+}
+
+// SetColorProfile overrides the detected colour profile. Truecolour and
+// 256-colour escapes written by a [Logger] are converted to the nearest
+// colours in p, so styles defined with hex colours render sensibly on
+// terminals with a smaller palette. [ProfileASCII] disables colours.
+func (o *Output) SetColorProfile(p ColorProfile) {
+	if tp, ok := termenvProfiles[p]; ok {
+		o.renderer.SetColorProfile(tp)
+	}
+}
+
+// colorWriter returns w wrapped to convert colour escapes to the output's
+// profile, or w itself when no conversion is needed.
+func (o *Output) colorWriter(w io.Writer) io.Writer {
+	switch p := o.renderer.ColorProfile(); p { //nolint:exhaustive // only reduced palettes are converted
+	case termenv.ANSI256, termenv.ANSI:
+		return profileWriter{w: w, profile: p}
+	}
+	return w
+}
+
 // Width returns the terminal width, or 0 for non-TTY writers.
 // The value is lazily detected and cached; call [Output.RefreshWidth]
 // to re-detect.
 func (o *Output) Width() int {
+	w, _ := o.size()
+	return w
+}
+
+// Height returns the terminal height, or 0 for non-TTY writers.
+// The value is detected and cached alongside [Output.Width].
+func (o *Output) Height() int {
+	_, h := o.size()
+	return h
+}
+
+// RefreshWidth clears the cached terminal size so that the next call
+// to [Output.Width] or [Output.Height] re-queries the terminal.
+func (o *Output) RefreshWidth() {
+	o.widthMu.Lock()
+	defer o.widthMu.Unlock()
+	o.widthDone = false
+	o.width = 0
+	o.height = 0
+}
+
+// size lazily detects and caches the terminal dimensions.
+func (o *Output) size() (width, height int) {
 	o.widthMu.Lock()
 	defer o.widthMu.Unlock()
 
@@ -81,22 +188,14 @@ func (o *Output) Width() int {
 		o.widthDone = true
 
 		if o.isTTY && o.fd >= 0 {
-			if w, _, err := term.GetSize(o.fd); err == nil {
+			if w, h, err := term.GetSize(o.fd); err == nil {
 				o.width = w
+				o.height = h
 			}
 		}
 	}
 
-	return o.width
-}
-
-// RefreshWidth clears the cached terminal width so that the next call
-// to [Output.Width] re-queries the terminal.
-func (o *Output) RefreshWidth() {
-	o.widthMu.Lock()
-	defer o.widthMu.Unlock()
-	o.widthDone = false
-	o.width = 0
+	return o.width, o.height
 }
 
 // Renderer returns the [lipgloss.Renderer] configured for this output.
@@ -2,10 +2,14 @@ package clog
 
 import (
 	"bytes"
+	"io"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/term"
 )
 
 func TestStderr(t *testing.T) {
@@ -54,6 +58,26 @@ func TestWidth(t *testing.T) {
 	})
 }
 
+func TestOutputIsTerminal(t *testing.T) {
+	var buf bytes.Buffer
+
+	out := TestOutput(&buf)
+
+	assert.False(t, out.IsTerminal())
+	assert.Equal(t, out.IsTTY(), out.IsTerminal())
+}
+
+func TestHeight(t *testing.T) {
+	var buf bytes.Buffer
+
+	out := TestOutput(&buf)
+
+	assert.Equal(t, 0, out.Height())
+
+	out.RefreshWidth()
+	assert.Equal(t, 0, out.Height())
+}
+
 func TestRefreshWidth(t *testing.T) {
 	var buf bytes.Buffer
 
@@ -67,3 +91,128 @@ func TestRefreshWidth(t *testing.T) {
 	assert.Equal(t, 0, w1)
 	assert.Equal(t, 0, w2)
 }
+
+func TestNewTestOutputColor(t *testing.T) {
+	var buf bytes.Buffer
+
+	out := NewTestOutputColor(&buf, 80)
+
+	assert.True(t, out.IsTTY())
+	assert.Equal(t, 80, out.Width())
+	assert.False(t, out.ColorsDisabled())
+	assert.True(t, out.Renderer().HasDarkBackground())
+
+	// The forced width survives a refresh only until re-detection, which
+	// finds no terminal behind a buffer.
+	out.RefreshWidth()
+	assert.Equal(t, 0, out.Width())
+}
+
+func TestNewTestOutputColorRendersColor(t *testing.T) {
+	withTrueColor(t)
+
+	var buf bytes.Buffer
+
+	l := New(NewTestOutputColor(&buf, 80))
+	l.Info().Msg("hello")
+
+	assert.Contains(t, buf.String(), "\x1b[")
+	assert.Equal(t, "INF ℹ️ hello\n", StripANSI(buf.String()))
+
+	// TestOutput renders the same entry without colour.
+	buf.Reset()
+	New(TestOutput(&buf)).Info().Msg("hello")
+
+	assert.Equal(t, "INF ℹ️ hello\n", buf.String())
+}
+
+func TestNewTestOutputColorWrapsSlices(t *testing.T) {
+	files := []string{"alpha.go", "beta.go", "gamma.go"}
+
+	var buf bytes.Buffer
+
+	// Slices wrap at the forced width.
+	l := New(NewTestOutputColor(&buf, 30))
+	l.SetWrapSlices(true)
+	l.Info().Strs("files", files).Msg("found")
+
+	want := "INF ℹ️ found files=[alpha.go,\n" +
+		"                    beta.go,\n" +
+		"                    gamma.go]\n"
+	assert.Equal(t, want, buf.String())
+
+	// With no width, as from TestOutput, they never wrap.
+	buf.Reset()
+	l = New(TestOutput(&buf))
+	l.SetWrapSlices(true)
+	l.Info().Strs("files", files).Msg("found")
+
+	assert.Equal(t, "INF ℹ️ found files=[alpha.go, beta.go, gamma.go]\n", buf.String())
+}
+
+func TestNewTestOutputColorWidth(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(NewTestOutputColor(&buf, 24))
+	l.SetSectionRule(true)
+	l.Section("Build")
+
+	assert.Equal(t, "Build\n"+strings.Repeat("─", 24)+"\n", StripANSI(buf.String()))
+
+	// Without a width, the rule only spans the title.
+	buf.Reset()
+	l = New(TestOutput(&buf))
+	l.SetSectionRule(true)
+	l.Section("Build")
+
+	assert.Equal(t, "Build\n"+strings.Repeat("─", 5)+"\n", buf.String())
+
+	// Auto-sized bars use a quarter of the terminal width, clamped.
+	assert.Equal(t, 10, resolveBarWidth(BarStyle{}, NewTestOutputColor(&buf, 24).Width()))
+	assert.Equal(t, 30, resolveBarWidth(BarStyle{}, NewTestOutputColor(&buf, 120).Width()))
+	assert.Equal(t, 40, resolveBarWidth(BarStyle{}, NewTestOutputColor(&buf, 400).Width()))
+}
+
+func TestNewOutputFile(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "clog")
+	require.NoError(t, err)
+	defer f.Close()
+
+	out := NewOutput(f, ColorAuto)
+
+	assert.Equal(t, f, out.Writer())
+	assert.Equal(t, int(f.Fd()), out.fd) //nolint:gosec // test fd fits in int
+	assert.False(t, out.IsTTY())
+	assert.True(t, out.ColorsDisabled())
+	assert.Equal(t, 0, out.Width())
+
+	assert.False(t, NewOutput(f, ColorAlways).ColorsDisabled())
+}
+
+func TestNewOutputFileTerminalWidth(t *testing.T) {
+	f, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		t.Skip("no controlling terminal")
+	}
+	defer f.Close()
+
+	out := NewOutput(f, ColorAuto)
+	require.True(t, out.IsTTY())
+
+	w, _, err := term.GetSize(int(f.Fd())) //nolint:gosec // test fd fits in int
+	require.NoError(t, err)
+	assert.Equal(t, w, out.Width())
+}
+
+func TestOutputColorProfile(t *testing.T) {
+	assert.Equal(t, ProfileTrueColor, NewOutput(io.Discard, ColorAlways).ColorProfile())
+	assert.Equal(t, ProfileASCII, NewOutput(io.Discard, ColorNever).ColorProfile())
+
+	out := NewOutput(io.Discard, ColorAlways)
+	out.SetColorProfile(ProfileANSI256)
+	assert.Equal(t, ProfileANSI256, out.ColorProfile())
+	assert.False(t, out.ColorsDisabled())
+
+	out.SetColorProfile(ProfileASCII)
+	assert.True(t, out.ColorsDisabled())
+}
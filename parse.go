@@ -0,0 +1,203 @@
+package clog
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ParseLine parses a single line of pretty output back into an [Entry].
+// It is the inverse of the formatter for the common layout and is intended
+// for golden-output assertions and re-ingesting logs.
+//
+// Only lines produced with [DefaultParts], the default labels, timestamp
+// format and quote characters, [ColorNever] and the "=" separator are
+// supported. The prefix is recognised as a token containing no ASCII letters
+// or digits (such as the default emoji). Field values are returned as strings,
+// and slices as []string; the original Go types are not recovered. A
+// message containing text of the form "key=value" is indistinguishable
+// from fields and is parsed as such.
+func ParseLine(s string) (Entry, error) {
+	var e Entry
+
+	rest := strings.TrimRight(s, "\r\n")
+	if strings.TrimSpace(rest) == "" {
+		return e, errors.New("clog: empty line")
+	}
+
+	tok, after := cutToken(rest)
+	if t, err := time.Parse("15:04:05.000", tok); err == nil {
+		e.Time = t
+		tok, after = cutToken(after)
+	}
+
+	level, ok := levelFromLabel(tok)
+	if !ok {
+		return e, fmt.Errorf("clog: unknown level label %q", tok)
+	}
+	e.Level = level
+	rest = after
+
+	if tok, after := cutToken(rest); tok != "" && isPrefixToken(tok) {
+		e.Prefix = tok
+		rest = after
+	}
+
+	// Fields start at the first token from which the rest of the line parses
+	// cleanly as key=value pairs; everything before that is the message.
+	for i := range len(rest) {
+		if i > 0 && rest[i-1] != ' ' {
+			continue
+		}
+
+		fields, err := parseFields(rest[i:])
+		if err != nil {
+			continue
+		}
+
+		e.Message = strings.TrimSpace(rest[:i])
+		e.Fields = fields
+		return e, nil
+	}
+
+	e.Message = strings.TrimSpace(rest)
+	return e, nil
+}
+
+// cutToken returns the first space-delimited token of s and the remainder
+// with leading spaces removed.
+func cutToken(s string) (string, string) {
+	s = strings.TrimLeft(s, " ")
+	tok, after, _ := strings.Cut(s, " ")
+	return tok, strings.TrimLeft(after, " ")
+}
+
+// levelFromLabel maps a default level label (e.g. "INF") to its [Level].
+func levelFromLabel(label string) (Level, bool) {
+	for level, l := range levelLabels {
+		if l == label {
+			return level, true
+		}
+	}
+	return 0, false
+}
+
+// isPrefixToken reports whether tok looks like a prefix rather than the
+// first word of a message or a field.
+func isPrefixToken(tok string) bool {
+	return !strings.ContainsFunc(tok, func(r rune) bool {
+		return r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '=')
+	})
+}
+
+// parseFields parses a space-separated list of key=value pairs. It fails
+// unless the whole of s is consumed.
+func parseFields(s string) ([]Field, error) {
+	var fields []Field
+
+	s = strings.TrimSpace(s)
+	for s != "" {
+		key, rest, ok := strings.Cut(s, "=")
+		if !ok || key == "" || strings.ContainsAny(key, " \"[]") {
+			return nil, fmt.Errorf("clog: invalid field at %q", s)
+		}
+
+		val, rest, err := parseFieldValue(rest)
+		if err != nil {
+			return nil, err
+		}
+		if rest != "" && rest[0] != ' ' {
+			return nil, fmt.Errorf("clog: unexpected text after value of %q", key)
+		}
+
+		fields = append(fields, Field{Key: key, Value: val})
+		s = strings.TrimLeft(rest, " ")
+	}
+	return fields, nil
+}
+
+// parseFieldValue parses one value from the start of s and returns it with
+// the unconsumed remainder.
+func parseFieldValue(s string) (any, string, error) {
+	switch {
+	case strings.HasPrefix(s, `"`):
+		return parseQuoted(s)
+	case strings.HasPrefix(s, "["):
+		return parseSlice(s)
+	default:
+		end := bareValueEnd(s)
+		return s[:end], s[end:], nil
+	}
+}
+
+// parseQuoted parses a Go-quoted string from the start of s.
+func parseQuoted(s string) (string, string, error) {
+	q, err := strconv.QuotedPrefix(s)
+	if err != nil {
+		return "", "", fmt.Errorf("clog: invalid quoted value %q", s)
+	}
+	v, err := strconv.Unquote(q)
+	if err != nil {
+		return "", "", fmt.Errorf("clog: invalid quoted value %q", q)
+	}
+	return v, s[len(q):], nil
+}
+
+// parseSlice parses a "[a, b]" slice from the start of s into a []string.
+func parseSlice(s string) ([]string, string, error) {
+	vals := []string{}
+
+	rest := s[1:]
+	if strings.HasPrefix(rest, "]") {
+		return vals, rest[1:], nil
+	}
+
+	for {
+		var v string
+		if strings.HasPrefix(rest, `"`) {
+			var err error
+			if v, rest, err = parseQuoted(rest); err != nil {
+				return nil, "", err
+			}
+		} else {
+			end := strings.IndexAny(rest, ",]")
+			if end < 0 {
+				return nil, "", fmt.Errorf("clog: unterminated slice %q", s)
+			}
+			v, rest = rest[:end], rest[end:]
+		}
+		vals = append(vals, v)
+
+		switch {
+		case strings.HasPrefix(rest, ", "):
+			rest = rest[2:]
+		case strings.HasPrefix(rest, "]"):
+			return vals, rest[1:], nil
+		default:
+			return nil, "", fmt.Errorf("clog: unterminated slice %q", s)
+		}
+	}
+}
+
+// bareValueEnd returns the index of the first space in s that is not
+// enclosed in brackets (e.g. the spaces in "map[a:1 b:2]").
+func bareValueEnd(s string) int {
+	depth := 0
+	for i, r := range s {
+		switch r {
+		case '[', '{':
+			depth++
+		case ']', '}':
+			depth = max(depth-1, 0)
+		case ' ':
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(s)
+}
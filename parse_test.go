@@ -0,0 +1,79 @@
+package clog
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLineRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Warn().
+		Str("path", "/tmp/my file").
+		Strs("tags", []string{"a b", "c"}).
+		Int("n", 3).
+		Duration("took", 1500*time.Millisecond).
+		Str("empty", "").
+		Err(errors.New("disk full")).
+		Msg("Write failed")
+
+	e, err := ParseLine(buf.String())
+	require.NoError(t, err)
+
+	assert.Equal(t, WarnLevel, e.Level)
+	assert.Equal(t, "⚠️", e.Prefix)
+	assert.Equal(t, "Write failed", e.Message)
+	assert.Equal(t, []Field{
+		{Key: "path", Value: "/tmp/my file"},
+		{Key: "tags", Value: []string{"a b", "c"}},
+		{Key: "n", Value: "3"},
+		{Key: "took", Value: "1.5s"},
+		{Key: "empty", Value: ""},
+		{Key: "error", Value: "disk full"},
+	}, e.Fields)
+}
+
+func TestParseLineTimestamp(t *testing.T) {
+	e, err := ParseLine("12:34:56.789 INF ℹ️ Ready port=8080")
+	require.NoError(t, err)
+
+	assert.Equal(t, 12, e.Time.Hour())
+	assert.Equal(t, 789*time.Millisecond, time.Duration(e.Time.Nanosecond()))
+	assert.Equal(t, InfoLevel, e.Level)
+	assert.Equal(t, "Ready", e.Message)
+	assert.Equal(t, []Field{{Key: "port", Value: "8080"}}, e.Fields)
+}
+
+func TestParseLineMessageOnly(t *testing.T) {
+	e, err := ParseLine("ERR boom = bang")
+	require.NoError(t, err)
+
+	assert.Empty(t, e.Prefix)
+	assert.Equal(t, "boom = bang", e.Message)
+	assert.Empty(t, e.Fields)
+}
+
+func TestParseLineFieldsOnly(t *testing.T) {
+	e, err := ParseLine("INF ℹ️ m=map[a:1 b:2] s=[]")
+	require.NoError(t, err)
+
+	assert.Empty(t, e.Message)
+	assert.Equal(t, []Field{
+		{Key: "m", Value: "map[a:1 b:2]"},
+		{Key: "s", Value: []string{}},
+	}, e.Fields)
+}
+
+func TestParseLineErrors(t *testing.T) {
+	_, err := ParseLine("")
+	require.Error(t, err)
+
+	_, err = ParseLine("XYZ hello")
+	require.Error(t, err)
+}
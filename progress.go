@@ -2,8 +2,11 @@ package clog
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"slices"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -47,6 +50,8 @@ type ProgressUpdate struct {
 	msg         string
 	msgPtr      *atomic.Pointer[string]
 	progressPtr *atomic.Int64 // bar mode: current progress value; nil for non-bar modes
+	step        atomic.Int64  // steps completed via Step(); read by Progress after the task
+	stepsTotal  int           // set by AnimationBuilder.Steps; 0 = no step counter
 	totalPtr    *atomic.Int64 // bar mode: total progress value; nil for non-bar modes
 }
 
@@ -85,6 +90,53 @@ func (p *ProgressUpdate) Msg(msg string) *ProgressUpdate {
 	return p
 }
 
+// Field sets key to value on the animated line, replacing the value if key
+// is already shown rather than adding a duplicate. Unlike the other field
+// methods, which only apply to the next [ProgressUpdate.Send], the field is
+// kept for later updates, so a value computed progressively can be updated
+// in place:
+//
+//	for i, f := range files {
+//		fetch(f)
+//		p.Field("downloaded", strconv.Itoa(i+1)).Send()
+//	}
+func (p *ProgressUpdate) Field(key string, value any) *ProgressUpdate {
+	// Clone rather than update in place: the last Send may have published
+	// p.base to the renderer.
+	base := slices.Clone(p.base)
+	if i := slices.IndexFunc(base, func(f Field) bool { return f.Key == key }); i >= 0 {
+		base[i].Value = value
+	} else {
+		base = append(base, Field{Key: key, Value: value})
+	}
+	p.base = base
+	return p
+}
+
+// Step advances the step counter and sets the animation's message to
+// "[current/total] label", where total is set by [AnimationBuilder.Steps].
+// The counter does not advance past total. Like [ProgressUpdate.Msg], the
+// change is applied by [ProgressUpdate.Send].
+func (p *ProgressUpdate) Step(label string) *ProgressUpdate {
+	current := int(p.step.Load()) + 1
+	if p.stepsTotal > 0 {
+		current = min(current, p.stepsTotal)
+	}
+	p.step.Store(int64(current))
+
+	if p.stepsTotal > 0 {
+		p.msg = "[" + formatStep(current, p.stepsTotal) + "] " + label
+	} else {
+		p.msg = label
+	}
+	return p
+}
+
+// formatStep formats a step counter as "current/total".
+func formatStep(current, total int) string {
+	return strconv.Itoa(current) + "/" + strconv.Itoa(total)
+}
+
 // Send applies the accumulated message and field changes to the animation atomically.
 func (p *ProgressUpdate) Send() {
 	msg := p.msg
@@ -115,6 +167,8 @@ type AnimationBuilder struct {
 	shimmerStops   []ColorStop
 	speed          Speed
 	spinner        SpinnerStyle
+	stepsTotal     int           // when set, ProgressUpdate.Step renders "[n/total]" labels
+	timeout        time.Duration // when set, the task's context expires after this long
 }
 
 // resolveLogger returns the builder's logger, falling back to [Default].
@@ -134,6 +188,17 @@ func (b *AnimationBuilder) After(d time.Duration) *AnimationBuilder {
 	return b
 }
 
+// Timeout fails the task if it runs longer than d. [AnimationBuilder.Wait]
+// and [AnimationBuilder.Progress] pass the task a context that expires
+// after d; on expiry the animation stops without waiting for the task and
+// the [WaitResult] error reports the timeout (matching
+// [context.DeadlineExceeded]), logged at the [WaitResult.OnErrorLevel]
+// level. A timeout of zero or less disables it.
+func (b *AnimationBuilder) Timeout(d time.Duration) *AnimationBuilder {
+	b.timeout = d
+	return b
+}
+
 // Prefix sets the icon displayed beside the message during animation.
 // For [Pulse] and [Shimmer] this defaults to "⏳".
 // For [Spinner] the prefix is the current spinner frame and this setting is ignored.
@@ -161,7 +226,8 @@ func (b *AnimationBuilder) Style(s AnimationStyle) *AnimationBuilder {
 // using [formatElapsed] with [Styles.ElapsedPrecision].
 //
 // The field respects the position where Elapsed is called relative to other
-// field methods (e.g. Str, Int) on the builder.
+// field methods (e.g. Str, Int) on the builder. Elapsed may be called more
+// than once to show the same timer under several keys.
 func (b *AnimationBuilder) Elapsed(key string) *AnimationBuilder {
 	b.elapsedKey = key
 	b.fields = append(b.fields, Field{Key: key, Value: elapsed(0)})
@@ -200,10 +266,13 @@ func (b *AnimationBuilder) resolveDynamicFields(fields []Field, dur time.Duratio
 	}
 	fields = slices.Clone(fields)
 	for i := range fields {
-		switch fields[i].Key {
-		case b.elapsedKey:
+		// Every elapsed-typed field is refreshed, so calling Elapsed with
+		// several keys yields several live timers.
+		if _, ok := fields[i].Value.(elapsed); ok && b.elapsedKey != "" {
 			fields[i].Value = elapsed(dur)
-		case b.barPercentKey:
+			continue
+		}
+		if b.barPercentKey != "" && fields[i].Key == b.barPercentKey {
 			fields[i].Value = b.barPercentValue()
 		}
 	}
@@ -216,6 +285,18 @@ func (b *AnimationBuilder) resolveDynamicFields(fields []Field, dur time.Duratio
 	return fields
 }
 
+// Steps enables a step counter for multi-step tasks run with
+// [AnimationBuilder.Progress]. Each call to [ProgressUpdate.Step] advances
+// the counter and shows "[current/total] label" as the animated text.
+//
+// When the task finishes, the completion message defaults to the builder's
+// original message and a "steps" field records how many steps were reached
+// (e.g. steps=5/5, or steps=2/5 if the task failed part-way).
+func (b *AnimationBuilder) Steps(total int) *AnimationBuilder {
+	b.stepsTotal = max(total, 0)
+	return b
+}
+
 // Path adds a file path field as a clickable terminal hyperlink.
 // Uses the builder's logger's [Output] setting.
 func (b *AnimationBuilder) Path(key, path string) *AnimationBuilder {
@@ -295,10 +376,11 @@ func (b *AnimationBuilder) Progress(
 	fieldsPtr.Store(&b.fields)
 
 	update := &ProgressUpdate{
-		msg:       b.msg,
-		msgPtr:    &msgPtr,
-		fieldsPtr: &fieldsPtr,
-		base:      b.fields,
+		msg:        b.msg,
+		msgPtr:     &msgPtr,
+		fieldsPtr:  &fieldsPtr,
+		base:       b.fields,
+		stepsTotal: b.stepsTotal,
 	}
 	if b.mode == animationBar {
 		update.progressPtr = b.barProgressPtr
@@ -310,8 +392,19 @@ func (b *AnimationBuilder) Progress(
 		return task(ctx, update)
 	}
 
+	if b.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, b.timeout, &timeoutError{timeout: b.timeout})
+		defer cancel()
+	}
+
 	startTime := time.Now()
 	err := runAnimation(ctx, b, wrapped, &msgPtr, &fieldsPtr, startTime)
+	// Report the timeout itself, but only when it fired: a task may return
+	// a deadline error of its own.
+	if b.timeout > 0 && ctx.Err() != nil && errors.Is(err, context.DeadlineExceeded) {
+		err = context.Cause(ctx)
+	}
 
 	msg := *msgPtr.Load()
 	w := &WaitResult{
@@ -322,10 +415,25 @@ func (b *AnimationBuilder) Progress(
 		errorLevel:   ErrorLevel,
 	}
 	w.fields = b.resolveDynamicFields(*fieldsPtr.Load(), time.Since(startTime))
+	if b.stepsTotal > 0 {
+		steps := formatStep(int(update.step.Load()), b.stepsTotal)
+		w.successMsg = b.msg
+		w.fields = append(slices.Clip(w.fields), Field{Key: "steps", Value: steps})
+	}
 	w.initSelf(w)
 	return w
 }
 
+// timeoutError is the error of a task that exceeded its
+// [AnimationBuilder.Timeout].
+type timeoutError struct {
+	timeout time.Duration
+}
+
+func (e *timeoutError) Error() string { return "timed out after " + e.timeout.String() }
+
+func (e *timeoutError) Is(target error) bool { return target == context.DeadlineExceeded }
+
 // WaitResult holds the result of an [AnimationBuilder.Wait] operation and
 // allows chaining additional fields before finalising the log output.
 type WaitResult struct {
@@ -433,9 +541,15 @@ func runAnimation(
 	fields *atomic.Pointer[[]Field],
 	startTime time.Time,
 ) error {
-	// Run the task in a goroutine.
+	// Run the task in a goroutine. A panic in the task is captured and
+	// re-raised on this goroutine once the animation has been cleaned up.
 	done := make(chan error, 1)
 	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- &taskPanic{value: r}
+			}
+		}()
 		done <- task(ctx)
 	}()
 
@@ -446,7 +560,7 @@ func runAnimation(
 		select {
 		case err := <-done:
 			timer.Stop()
-			return err
+			return repanic(err)
 		case <-ctx.Done():
 			timer.Stop()
 			return ctx.Err()
@@ -465,12 +579,12 @@ func runAnimation(
 			formatFields(*fields.Load(), slot.fieldOpts), " ",
 		)
 		line := buildLine(slot.cfg.order, slot.cfg.reportTS,
-			time.Now().In(slot.cfg.timeLoc).Format(slot.cfg.timeFmt),
+			formatTimestamp(time.Now().In(slot.cfg.timeLoc), slot.cfg.timeFmt),
 			slot.cfg.label, slot.prefix, *msgPtr.Load(), fieldsStr)
 		_, _ = io.WriteString(slot.cfg.out, line+"\n")
 		select {
 		case err := <-done:
-			return err
+			return repanic(err)
 		case <-ctx.Done():
 			return ctx.Err()
 		}
@@ -498,7 +612,7 @@ func runAnimation(
 				_, _ = io.WriteString(slot.cfg.out, frameBuf.String())
 			}
 			_, _ = io.WriteString(slot.cfg.out, clearLine)
-			return err
+			return repanic(err)
 		case now := <-ticker.C:
 			line := renderSlotLine(slot, false, now)
 			frameBuf.Reset()
@@ -512,6 +626,26 @@ func runAnimation(
 	}
 }
 
+// taskPanic carries a value recovered from a panicking animation task back
+// to the goroutine running the animation.
+type taskPanic struct {
+	value any
+}
+
+func (p *taskPanic) Error() string {
+	return fmt.Sprintf("clog: task panicked: %v", p.value)
+}
+
+// repanic re-raises the original panic value if err is a [taskPanic], and
+// otherwise returns err unchanged. Deferred cleanup (showing the cursor,
+// stopping the ticker) still runs as the panic unwinds.
+func repanic(err error) error {
+	if p, ok := err.(*taskPanic); ok { //nolint:errorlint // never wrapped
+		panic(p.value)
+	}
+	return err
+}
+
 // alignBarLine positions barPart relative to msgParts according to the
 // alignment mode and terminal width. sep is the fallback separator used
 // when the terminal is too narrow for padding.
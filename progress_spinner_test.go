@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -920,3 +921,113 @@ func TestProgressUpdateStringers(t *testing.T) {
 	assert.Equal(t, "items", result.fields[0].Key)
 	assert.Equal(t, []string{"a", Nil, Nil, "d"}, result.fields[0].Value)
 }
+
+func TestSpinnerSteps(t *testing.T) {
+	origDefault := Default
+	defer func() { Default = origDefault }()
+
+	var buf bytes.Buffer
+
+	// Capture animation frames as if writing to a terminal.
+	out := NewOutput(&buf, ColorAlways)
+	out.isTTY = true
+	Default = New(out)
+
+	fastSpinner := SpinnerStyle{
+		Frames: []string{"A"},
+		FPS:    time.Millisecond,
+	}
+
+	result := Spinner("Deploying").
+		Style(fastSpinner).
+		Steps(3).
+		Progress(context.Background(), func(_ context.Context, update *ProgressUpdate) error {
+			for _, label := range []string{"Building", "Pushing image", "Restarting"} {
+				update.Step(label).Send()
+				time.Sleep(20 * time.Millisecond)
+			}
+			return nil
+		})
+
+	require.NoError(t, result.err)
+
+	frames := StripANSI(buf.String())
+	first := strings.Index(frames, "[1/3] Building")
+	second := strings.Index(frames, "[2/3] Pushing image")
+	third := strings.Index(frames, "[3/3] Restarting")
+	require.NotEqual(t, -1, first)
+	assert.Greater(t, second, first)
+	assert.Greater(t, third, second)
+
+	assert.Equal(t, "Deploying", result.successMsg)
+	assert.Equal(t, []Field{{Key: "steps", Value: "3/3"}}, result.fields)
+}
+
+func TestProgressUpdateStepClamps(t *testing.T) {
+	update := &ProgressUpdate{stepsTotal: 2}
+
+	update.Step("a")
+	update.Step("b")
+	update.Step("c")
+
+	assert.Equal(t, "[2/2] c", update.msg)
+	assert.Equal(t, int64(2), update.step.Load())
+}
+
+func TestProgressUpdateStepWithoutTotal(t *testing.T) {
+	update := &ProgressUpdate{}
+	update.Step("a")
+
+	assert.Equal(t, "a", update.msg)
+	assert.Equal(t, int64(1), update.step.Load())
+}
+
+func TestSpinnerPanicRestoresTerminal(t *testing.T) {
+	origDefault := Default
+	defer func() { Default = origDefault }()
+
+	var buf bytes.Buffer
+
+	out := NewOutput(&buf, ColorAlways)
+	out.isTTY = true
+	Default = New(out)
+
+	fastSpinner := SpinnerStyle{
+		Frames: []string{"A"},
+		FPS:    time.Millisecond,
+	}
+
+	var recovered any
+	func() {
+		defer func() { recovered = recover() }()
+		Spinner("Working").
+			Style(fastSpinner).
+			Wait(context.Background(), func(_ context.Context) error {
+				time.Sleep(10 * time.Millisecond)
+				panic("boom")
+			})
+	}()
+
+	require.Equal(t, "boom", recovered)
+
+	got := buf.String()
+	assert.Contains(t, got, "\x1b[?25l", "cursor should be hidden during animation")
+	assert.True(
+		t,
+		strings.HasSuffix(got, clearLine+"\x1b[?25h"),
+		"line should be cleared and cursor shown before the panic propagates: %q", got,
+	)
+}
+
+func TestSpinnerPanicWithoutTTY(t *testing.T) {
+	origDefault := Default
+	defer func() { Default = origDefault }()
+
+	Default = NewWriter(io.Discard)
+
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = Spinner("Working").Wait(context.Background(), func(_ context.Context) error {
+			panic("boom")
+		})
+	})
+}
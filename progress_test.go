@@ -1,8 +1,11 @@
 package clog
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"io"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -89,6 +92,75 @@ func TestAfterContextCancelledDuringDelay(t *testing.T) {
 	require.ErrorIs(t, result.err, context.Canceled)
 }
 
+func TestTimeoutExpires(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+
+	start := time.Now()
+	result := l.Spinner("flaky").
+		Timeout(100*time.Millisecond).
+		Wait(context.Background(), func(_ context.Context) error {
+			time.Sleep(time.Second)
+			return nil
+		})
+
+	// The animation stops at the deadline rather than waiting for the task.
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	require.ErrorIs(t, result.err, context.DeadlineExceeded)
+	assert.EqualError(t, result.err, "timed out after 100ms")
+
+	buf.Reset()
+	err := result.OnErrorLevel(WarnLevel).Msg("done")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, "WRN ⚠️ timed out after 100ms\n", buf.String())
+}
+
+func TestTimeoutTaskSeesDeadline(t *testing.T) {
+	result := NewWriter(io.Discard).Spinner("loading").
+		Timeout(50*time.Millisecond).
+		Progress(context.Background(), func(ctx context.Context, _ *ProgressUpdate) error {
+			_, ok := ctx.Deadline()
+			assert.True(t, ok)
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+	require.ErrorIs(t, result.err, context.DeadlineExceeded)
+}
+
+func TestTimeoutNotReached(t *testing.T) {
+	result := NewWriter(io.Discard).Spinner("fast").
+		Timeout(time.Second).
+		Wait(context.Background(), func(_ context.Context) error { return nil })
+
+	require.NoError(t, result.err)
+}
+
+func TestTaskDeadlineErrorWithoutTimeout(t *testing.T) {
+	taskErr := fmt.Errorf("fetching: %w", context.DeadlineExceeded)
+
+	result := NewWriter(io.Discard).Spinner("fetching").
+		Wait(context.Background(), func(_ context.Context) error { return taskErr })
+
+	require.ErrorIs(t, result.err, taskErr)
+	assert.Equal(t, "fetching: context deadline exceeded", result.err.Error())
+}
+
+func TestTimeoutParentCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result := NewWriter(io.Discard).Spinner("cancelled").
+		Timeout(time.Second).
+		Wait(ctx, func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+	require.ErrorIs(t, result.err, context.Canceled)
+}
+
 func TestElapsedFieldOrdering(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -151,3 +223,64 @@ func TestElapsedFieldOrdering(t *testing.T) {
 		})
 	}
 }
+
+func TestElapsedMultipleKeys(t *testing.T) {
+	b := Spinner("test").
+		Elapsed("total").
+		Str("x", "y").
+		Elapsed("step")
+
+	fields := b.resolveDynamicFields(b.fields, 3*time.Second)
+
+	require.Len(t, fields, 3)
+	assert.Equal(t, elapsed(3*time.Second), fields[0].Value)
+	assert.Equal(t, "y", fields[1].Value)
+	assert.Equal(t, elapsed(3*time.Second), fields[2].Value)
+}
+
+func TestTapAnimation(t *testing.T) {
+	var buf, tap bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Tap(&tap)
+
+	err := l.Spinner("loading").
+		Wait(context.Background(), func(context.Context) error { return nil }).
+		Msg("done")
+
+	require.NoError(t, err)
+	assert.Equal(t, "INF ⏳ loading\nINF ℹ️ done\n", buf.String())
+	assert.Equal(t, buf.String(), tap.String())
+}
+
+func TestProgressUpdateFieldReplaces(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+
+	err := l.Spinner("downloading").
+		Str("file", "a.tar").
+		Progress(context.Background(), func(_ context.Context, p *ProgressUpdate) error {
+			p.Field("downloaded", "0.6GB").Send()
+			p.Field("downloaded", "1.2GB").Send()
+			// Persists across updates that don't set it.
+			p.Msg("verifying").Send()
+			return nil
+		}).
+		Msg("done")
+
+	require.NoError(t, err)
+	assert.Equal(t, "INF ⏳ downloading file=a.tar\nINF ℹ️ done file=a.tar downloaded=1.2GB\n", buf.String())
+}
+
+func TestProgressUpdateFieldReplacesBase(t *testing.T) {
+	var fields atomic.Pointer[[]Field]
+	base := []Field{{Key: "k", Value: "old"}}
+
+	u := &ProgressUpdate{base: base, fieldsPtr: &fields, msgPtr: new(atomic.Pointer[string])}
+	u.initSelf(u)
+	u.Field("k", "new").Send()
+
+	assert.Equal(t, []Field{{Key: "k", Value: "new"}}, *fields.Load())
+	assert.Equal(t, "old", base[0].Value, "builder fields are not modified")
+}
@@ -0,0 +1,185 @@
+package clog
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// ErrNotInteractive is returned by [Logger.Confirm] when the input is not a
+// terminal and no default answer has been set with
+// [Logger.SetConfirmDefault].
+var ErrNotInteractive = errors.New("clog: input is not interactive")
+
+// confirmAnswerKey is the field key [Logger.Confirm] logs the decision under.
+const confirmAnswerKey = "answer"
+
+// Confirm writes question to the logger's output as a y/n prompt, styled
+// with [Styles.Prompt], and reads the answer from the input set with
+// [Logger.SetInput] (default [os.Stdin]). "y" and "yes" answer true, "n"
+// and "no" answer false (case-insensitive); anything else re-prompts. An
+// empty answer selects the default set with [Logger.SetConfirmDefault], if
+// any.
+//
+// The decision is logged at [InfoLevel] with the question as the message
+// and an answer=yes or answer=no field.
+//
+// When the input is not a terminal, Confirm returns the default answer
+// without prompting, or [ErrNotInteractive] when no default is set. If ctx
+// is cancelled while waiting, Confirm returns ctx.Err(); the pending read
+// may still consume the next line of input.
+//
+//	ok, err := clog.Confirm(ctx, "Deploy to production?")
+func (l *Logger) Confirm(ctx context.Context, question string) (bool, error) {
+	l.mu.Lock()
+	in := l.input
+	def := l.confirmDefault
+	l.mu.Unlock()
+
+	if in == nil {
+		in = os.Stdin
+	}
+
+	if !inputIsInteractive(in) {
+		if def == nil {
+			return false, ErrNotInteractive
+		}
+		l.logDecision(question, *def)
+		return *def, nil
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		l.writePrompt(question, def)
+
+		line, err := readLine(ctx, in)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return false, err
+		}
+
+		answer, ok := parseConfirm(line, def)
+		if ok {
+			l.logDecision(question, answer)
+			return answer, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+}
+
+// Confirm prompts for a y/n answer using the [Default] logger.
+func Confirm(ctx context.Context, question string) (bool, error) {
+	return Default.Confirm(ctx, question)
+}
+
+// writePrompt writes question and a y/n hint reflecting def, without a
+// trailing newline.
+func (l *Logger) writePrompt(question string, def *bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var style Style
+	if !l.colorsDisabled() {
+		style = l.styles.Prompt
+	}
+
+	hint := "[y/n]"
+	if def != nil {
+		hint = "[y/N]"
+		if *def {
+			hint = "[Y/n]"
+		}
+	}
+
+	var buf strings.Builder
+
+	emitStyled(&buf, question, style)
+	buf.WriteByte(' ')
+	buf.WriteString(hint)
+	buf.WriteByte(' ')
+
+	_, _ = io.WriteString(l.writer(), buf.String())
+}
+
+// logDecision logs the answer to question at [InfoLevel].
+func (l *Logger) logDecision(question string, answer bool) {
+	value := "no"
+	if answer {
+		value = "yes"
+	}
+	l.Info().Str(confirmAnswerKey, value).Msg(question)
+}
+
+// parseConfirm maps a y/n answer to a bool. An empty answer selects def.
+// Reports false when the answer is not recognised.
+func parseConfirm(s string, def *bool) (answer, ok bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "y", "yes":
+		return true, true
+	case "n", "no":
+		return false, true
+	case "":
+		if def != nil {
+			return *def, true
+		}
+	}
+	return false, false
+}
+
+// inputIsInteractive reports whether r is a terminal. Readers other than an
+// [*os.File] are assumed to be interactive.
+func inputIsInteractive(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	if !ok {
+		return true
+	}
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// readLine reads a single line from r, one byte at a time so no input
+// beyond the newline is consumed. It returns early with ctx.Err() if ctx is
+// cancelled first. A final line without a newline is returned with io.EOF.
+func readLine(ctx context.Context, r io.Reader) (string, error) {
+	type result struct {
+		line string
+		err  error
+	}
+
+	ch := make(chan result, 1)
+
+	go func() {
+		var (
+			buf []byte
+			b   [1]byte
+		)
+		for {
+			n, err := r.Read(b[:])
+			if n > 0 {
+				if b[0] == '\n' {
+					ch <- result{line: string(buf)}
+					return
+				}
+				buf = append(buf, b[0])
+			}
+			if err != nil {
+				ch <- result{line: string(buf), err: err}
+				return
+			}
+		}
+	}()
+
+	select {
+	case res := <-ch:
+		return res.line, res.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
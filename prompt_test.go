@@ -0,0 +1,108 @@
+package clog
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirmYes(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetInput(strings.NewReader("y\n"))
+
+	ok, err := l.Confirm(t.Context(), "Deploy?")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "Deploy? [y/n] INF ℹ️ Deploy? answer=yes\n", buf.String())
+}
+
+func TestConfirmNo(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetInput(strings.NewReader("No\n"))
+
+	ok, err := l.Confirm(t.Context(), "Deploy?")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Contains(t, buf.String(), "answer=no")
+}
+
+func TestConfirmReprompts(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetInput(strings.NewReader("maybe\n\nyes\n"))
+
+	ok, err := l.Confirm(t.Context(), "Deploy?")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 3, strings.Count(buf.String(), "Deploy? [y/n] "))
+}
+
+func TestConfirmDefault(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetInput(strings.NewReader("\n"))
+	l.SetConfirmDefault(true)
+
+	ok, err := l.Confirm(t.Context(), "Deploy?")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Contains(t, buf.String(), "Deploy? [Y/n] ")
+	assert.Contains(t, buf.String(), "answer=yes")
+}
+
+func TestConfirmEOF(t *testing.T) {
+	l := New(TestOutput(io.Discard))
+	l.SetInput(strings.NewReader(""))
+
+	_, err := l.Confirm(t.Context(), "Deploy?")
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestConfirmNotInteractive(t *testing.T) {
+	f, err := os.Open(os.DevNull)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetInput(f)
+
+	_, err = l.Confirm(t.Context(), "Deploy?")
+	require.ErrorIs(t, err, ErrNotInteractive)
+	assert.Empty(t, buf.String())
+
+	l.SetConfirmDefault(false)
+
+	ok, err := l.Confirm(t.Context(), "Deploy?")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, "INF ℹ️ Deploy? answer=no\n", buf.String())
+}
+
+func TestConfirmContextCancelled(t *testing.T) {
+	r, w := io.Pipe()
+	t.Cleanup(func() { _ = w.Close() })
+
+	l := New(TestOutput(io.Discard))
+	l.SetInput(r)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := l.Confirm(ctx, "Deploy?")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,58 @@
+package clog
+
+import "sync"
+
+// loggerKey is the field key set by [Logger.Named].
+const loggerKey = "logger"
+
+// registry holds loggers registered by name with [Register].
+var registry = struct {
+	mu      sync.RWMutex
+	loggers map[string]*Logger
+}{loggers: make(map[string]*Logger)}
+
+// Register stores l under name so it can be fetched with [Named] anywhere in
+// the program, replacing any logger already registered under name. A nil l
+// is equivalent to [Unregister].
+func Register(name string, l *Logger) {
+	if l == nil {
+		Unregister(name)
+		return
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.loggers[name] = l
+}
+
+// Unregister removes the logger registered under name, if any.
+func Unregister(name string) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	delete(registry.loggers, name)
+}
+
+// Named returns the logger registered under name, or [Default] if there
+// is none.
+func Named(name string) *Logger {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	if l, ok := registry.loggers[name]; ok {
+		return l
+	}
+	return Default
+}
+
+// Named returns a sub-logger that adds a "logger" field holding name to
+// every entry, and registers it under name (see [Register]) so it can be
+// fetched with the package-level [Named].
+//
+//	db := clog.Default.Named("db")
+//	// elsewhere:
+//	clog.Named("db").Info().Msg("Connected")
+//	// INF ℹ️ Connected logger=db
+func (l *Logger) Named(name string) *Logger {
+	sub := l.With().Str(loggerKey, name).Logger()
+	Register(name, sub)
+	return sub
+}
@@ -0,0 +1,81 @@
+package clog
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterAndNamed(t *testing.T) {
+	api := NewWriter(io.Discard)
+	db := NewWriter(io.Discard)
+
+	Register("api", api)
+	Register("db", db)
+	t.Cleanup(func() {
+		Unregister("api")
+		Unregister("db")
+	})
+
+	assert.Same(t, api, Named("api"))
+	assert.Same(t, db, Named("db"))
+}
+
+func TestNamedUnknownReturnsDefault(t *testing.T) {
+	assert.Same(t, Default, Named("unknown"))
+}
+
+func TestUnregister(t *testing.T) {
+	l := NewWriter(io.Discard)
+
+	Register("tmp", l)
+	assert.Same(t, l, Named("tmp"))
+
+	Unregister("tmp")
+	assert.Same(t, Default, Named("tmp"))
+}
+
+func TestRegisterNilUnregisters(t *testing.T) {
+	Register("tmp", NewWriter(io.Discard))
+	Register("tmp", nil)
+
+	assert.Same(t, Default, Named("tmp"))
+}
+
+func TestLoggerNamed(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	sub := l.Named("plugin")
+	t.Cleanup(func() { Unregister("plugin") })
+
+	assert.Same(t, sub, Named("plugin"))
+
+	Named("plugin").Info().Msg("loaded")
+	assert.Equal(t, "INF ℹ️ loaded logger=plugin\n", buf.String())
+
+	buf.Reset()
+	l.Info().Msg("parent")
+	assert.Equal(t, "INF ℹ️ parent\n", buf.String())
+}
+
+func TestRegistryConcurrentAccess(t *testing.T) {
+	l := NewWriter(io.Discard)
+	t.Cleanup(func() { Unregister("concurrent") })
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 100 {
+			Register("concurrent", l)
+		}
+	}()
+	for range 100 {
+		_ = Named("concurrent")
+	}
+	<-done
+
+	assert.Same(t, l, Named("concurrent"))
+}
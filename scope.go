@@ -0,0 +1,139 @@
+package clog
+
+import "sync"
+
+// WithSettings snapshots the logger's configuration, runs fn to apply
+// temporary changes, and returns a function that restores the snapshot.
+// This gives a `defer restore()` pattern for one-off formatting changes:
+//
+//	restore := clog.WithSettings(func(l *clog.Logger) {
+//	    l.SetQuoteMode(clog.QuoteAlways)
+//	    l.SetParts(clog.PartMessage, clog.PartFields)
+//	})
+//	defer restore()
+//
+// If fn panics, the snapshot is restored before the panic propagates.
+// Calling restore more than once has no further effect. The snapshot is
+// shallow: in-place mutations of a shared [Styles] value are not reverted,
+// so use [Logger.SetStyles] with a fresh value instead.
+func (l *Logger) WithSettings(fn func(*Logger)) (restore func()) {
+	l.mu.Lock()
+	snap := l.clone()
+	l.mu.Unlock()
+
+	var once sync.Once
+
+	restore = func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			l.restore(snap)
+		})
+	}
+
+	completed := false
+	defer func() {
+		if !completed {
+			restore()
+		}
+	}()
+
+	fn(l)
+
+	completed = true
+
+	return restore
+}
+
+// WithSettings applies temporary settings to the [Default] logger.
+// See [Logger.WithSettings].
+func WithSettings(fn func(*Logger)) (restore func()) { return Default.WithSettings(fn) }
+
+// restore copies every configuration field from snap back into l.
+// The caller must hold l.mu. The mutex itself is left untouched.
+func (l *Logger) restore(snap *Logger) {
+	l.alwaysStyleFieldsLevel = snap.alwaysStyleFieldsLevel
+	l.autoColorKeys = snap.autoColorKeys
+	l.batch = snap.batch
+	l.boolWords = snap.boolWords
+	l.collapseRepeatTimestamp = snap.collapseRepeatTimestamp
+	l.confirmDefault = snap.confirmDefault
+	l.defaultFields = snap.defaultFields
+	l.dictSeparator = snap.dictSeparator
+	l.dryRun = snap.dryRun
+	l.durationColumnWidth = snap.durationColumnWidth
+	l.durationUsesQuantityStyles = snap.durationUsesQuantityStyles
+	l.elapsedFormatFunc = snap.elapsedFormatFunc
+	l.elapsedMinimum = snap.elapsedMinimum
+	l.elapsedPrecision = snap.elapsedPrecision
+	l.elapsedRound = snap.elapsedRound
+	l.emptyMessageMode = snap.emptyMessageMode
+	l.emptyMessagePlaceholder = snap.emptyMessagePlaceholder
+	l.emptyRepr = snap.emptyRepr
+	l.exitFunc = snap.exitFunc
+	l.fatalExits = snap.fatalExits
+	l.fieldKeyAlign = snap.fieldKeyAlign
+	l.fieldLevels = snap.fieldLevels
+	l.fieldOrder = snap.fieldOrder
+	l.fieldPriorities = snap.fieldPriorities
+	l.fieldSort = snap.fieldSort
+	l.fieldStyleLevel = snap.fieldStyleLevel
+	l.fieldTimeFormat = snap.fieldTimeFormat
+	l.fields = snap.fields
+	l.handler = snap.handler
+	l.highlightMessageJSON = snap.highlightMessageJSON
+	l.indent = snap.indent
+	l.input = snap.input
+	l.keyTruncate = snap.keyTruncate
+	l.labelWidth = snap.labelWidth
+	l.labels = snap.labels
+	l.labelsPadded = snap.labelsPadded
+	l.leadingTagField = snap.leadingTagField
+	l.level = snap.level
+	l.levelAlign = snap.levelAlign
+	l.levelBadge = snap.levelBadge
+	l.levelRules = snap.levelRules
+	l.maxLineLen = snap.maxLineLen
+	l.measurePrecision = snap.measurePrecision
+	l.messageTruncateLen = snap.messageTruncateLen
+	l.messageTruncateMode = snap.messageTruncateMode
+	l.nilRepr = snap.nilRepr
+	l.nowFunc = snap.nowFunc
+	l.numberGrouping = snap.numberGrouping
+	l.omitEmpty = snap.omitEmpty
+	l.omitPredicate = snap.omitPredicate
+	l.omitZero = snap.omitZero
+	l.output = snap.output
+	l.parts = snap.parts
+	l.percentFormatFunc = snap.percentFormatFunc
+	l.percentPrecision = snap.percentPrecision
+	l.prefix = snap.prefix
+	l.prefixPlaceholderWhenEmpty = snap.prefixPlaceholderWhenEmpty
+	l.prefixes = snap.prefixes
+	l.quantityColumnWidth = snap.quantityColumnWidth
+	l.quantityThousandsSep = snap.quantityThousandsSep
+	l.quantityUnitsIgnoreCase = snap.quantityUnitsIgnoreCase
+	l.quoteOpen = snap.quoteOpen
+	l.quoteClose = snap.quoteClose
+	l.quoteMode = snap.quoteMode
+	l.reportGoroutine = snap.reportGoroutine
+	l.reportTimestamp = snap.reportTimestamp
+	l.sanitizeControl = snap.sanitizeControl
+	l.scrapeToken = snap.scrapeToken
+	l.sectionRule = snap.sectionRule
+	l.separatorText = snap.separatorText
+	l.statsPrecision = snap.statsPrecision
+	l.styles = snap.styles
+	l.tap = snap.tap
+	l.timeFormat = snap.timeFormat
+	l.timeLocation = snap.timeLocation
+	l.timestampGradient = snap.timestampGradient
+	l.timestampGradientStart = snap.timestampGradientStart
+	l.timestampGradientWindow = snap.timestampGradientWindow
+	l.treeIndent = snap.treeIndent
+	l.worker = snap.worker
+	l.wrapSlices = snap.wrapSlices
+
+	l.atomicLevel.Store(int32(l.gateLevel())) //nolint:gosec // Level values are small constants (0-6)
+	l.disabled.Store(snap.disabled.Load())
+}
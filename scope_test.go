@@ -0,0 +1,87 @@
+package clog
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithSettingsRestoresQuoteMode(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage, PartFields)
+
+	restore := l.WithSettings(func(l *Logger) {
+		l.SetQuoteMode(QuoteAlways)
+	})
+
+	l.Info().Str("k", "v").Msg("scoped")
+	assert.Equal(t, "scoped k=\"v\"\n", buf.String())
+
+	restore()
+	buf.Reset()
+
+	l.Info().Str("k", "v").Msg("restored")
+	assert.Equal(t, "restored k=v\n", buf.String())
+}
+
+func TestWithSettingsRestoresLevel(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+
+	restore := l.WithSettings(func(l *Logger) {
+		l.SetLevel(ErrorLevel)
+	})
+	assert.Equal(t, ErrorLevel, l.Level())
+	assert.Nil(t, l.Info())
+
+	restore()
+	assert.Equal(t, InfoLevel, l.Level())
+	assert.NotNil(t, l.Info())
+}
+
+func TestWithSettingsRestoreIdempotent(t *testing.T) {
+	l := New(TestOutput(&bytes.Buffer{}))
+
+	restore := l.WithSettings(func(l *Logger) {
+		l.SetLevel(WarnLevel)
+	})
+	restore()
+
+	l.SetLevel(DebugLevel)
+	restore()
+
+	assert.Equal(t, DebugLevel, l.Level())
+}
+
+func TestWithSettingsPanicRestores(t *testing.T) {
+	l := New(TestOutput(&bytes.Buffer{}))
+
+	require.PanicsWithValue(t, "boom", func() {
+		l.WithSettings(func(l *Logger) {
+			l.SetLevel(ErrorLevel)
+			panic("boom")
+		})
+	})
+
+	assert.Equal(t, InfoLevel, l.Level())
+}
+
+func TestWithSettingsDefault(t *testing.T) {
+	origDefault := Default
+	defer func() { Default = origDefault }()
+
+	Default = New(TestOutput(&bytes.Buffer{}))
+
+	restore := WithSettings(func(l *Logger) {
+		l.SetLevel(DebugLevel)
+	})
+	assert.Equal(t, DebugLevel, Default.Level())
+
+	restore()
+	assert.Equal(t, InfoLevel, Default.Level())
+}
@@ -0,0 +1,98 @@
+package clog
+
+import (
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sectionRuleChar is the character repeated to draw a section rule.
+const sectionRuleChar = "─"
+
+// Section writes a header line for title to the logger's output, styled
+// with [Styles.SectionHeader]. When enabled via [Logger.SetSectionRule], a
+// horizontal rule is drawn beneath the title.
+//
+// Section is a UI primitive like [Table], not a log entry: it bypasses the
+// level, parts and [Handler] configuration and writes directly to the
+// [Output]. Styles are omitted when colours are disabled.
+//
+//	clog.Section("Deploying")
+func (l *Logger) Section(title string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var style Style
+	if !l.colorsDisabled() {
+		style = l.styles.SectionHeader
+	}
+
+	var buf strings.Builder
+
+	emitStyled(&buf, title, style)
+	buf.WriteByte('\n')
+
+	if l.sectionRule {
+		width := l.output.Width()
+		if width <= 0 {
+			width = lipgloss.Width(title)
+		}
+
+		if width > 0 {
+			emitStyled(&buf, strings.Repeat(sectionRuleChar, width), style)
+			buf.WriteByte('\n')
+		}
+	}
+
+	_, _ = io.WriteString(l.writer(), buf.String())
+}
+
+// Section writes a section header to the [Default] logger's output.
+func Section(title string) { Default.Section(title) }
+
+// Newline writes a blank line to the logger's output, e.g. to separate
+// sections. Like [Logger.Section], it bypasses the level, parts and
+// [Handler] configuration, but it holds the logger's lock, so unlike
+// fmt.Println it never lands in the middle of a concurrent entry.
+func (l *Logger) Newline() { l.Newlines(1) }
+
+// Newlines writes n blank lines to the logger's output. See
+// [Logger.Newline]. Non-positive n writes nothing.
+func (l *Logger) Newlines(n int) {
+	if n <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = io.WriteString(l.writer(), strings.Repeat("\n", n))
+}
+
+// Inline writes msg to the logger's output as-is, with no level, prefix or
+// trailing newline, e.g. for a REPL prompt that the user's input follows
+// on the same line. Like [Logger.Newline], it holds the logger's lock, so
+// it never lands in the middle of a concurrent entry. If the output's
+// writer has a Flush method (e.g. a [bufio.Writer]), it is flushed.
+//
+//	clog.Inline("> ")
+func (l *Logger) Inline(msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, _ = io.WriteString(l.writer(), msg)
+
+	if f, ok := l.output.Writer().(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+}
+
+// Inline writes msg without a trailing newline to the [Default] logger's
+// output.
+func Inline(msg string) { Default.Inline(msg) }
+
+// Newline writes a blank line to the [Default] logger's output.
+func Newline() { Default.Newline() }
+
+// Newlines writes n blank lines to the [Default] logger's output.
+func Newlines(n int) { Default.Newlines(n) }
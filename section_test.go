@@ -0,0 +1,183 @@
+package clog
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSection(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Section("Deploying")
+
+	assert.Equal(t, "Deploying\n", buf.String())
+}
+
+func TestSectionColorAlways(t *testing.T) {
+	withTrueColor(t)
+
+	var buf bytes.Buffer
+
+	l := New(NewOutput(&buf, ColorAlways))
+	l.Section("Deploying")
+
+	want := DefaultStyles().SectionHeader.Render("Deploying")
+	assert.Contains(t, want, "\x1b[1m")
+	assert.Equal(t, want+"\n", buf.String())
+}
+
+func TestSectionBypassesLevelAndHandler(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetLevel(FatalLevel)
+	l.SetHandler(HandlerFunc(func(Entry) { t.Fatal("handler should not be called") }))
+	l.Section("Setup")
+
+	assert.Equal(t, "Setup\n", buf.String())
+}
+
+func TestSectionRule(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetSectionRule(true)
+	l.Section("Build")
+
+	// Not a terminal: the rule matches the title width.
+	assert.Equal(t, "Build\n─────\n", buf.String())
+}
+
+func TestSectionRuleTerminalWidth(t *testing.T) {
+	var buf bytes.Buffer
+
+	out := TestOutput(&buf)
+	out.width, out.widthDone = 12, true
+	l := New(out)
+	l.SetSectionRule(true)
+	l.Section("Build")
+
+	assert.Equal(t, "Build\n────────────\n", buf.String())
+}
+
+func TestNewline(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Info().Msg("one")
+	l.Newline()
+	l.Info().Msg("two")
+
+	assert.Equal(t, "INF ℹ️ one\n\nINF ℹ️ two\n", buf.String())
+}
+
+func TestNewlines(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Newlines(3)
+	l.Newlines(0)
+	l.Newlines(-1)
+
+	assert.Equal(t, "\n\n\n", buf.String())
+}
+
+func TestNewlineDoesNotInterleave(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+
+	const iterations = 200
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for range iterations {
+			l.Newline()
+		}
+	})
+	wg.Go(func() {
+		for range iterations {
+			l.Info().Str("k", "v").Msg("log")
+		}
+	})
+	wg.Wait()
+
+	lines := strings.Split(buf.String(), "\n")
+	assert.Len(t, lines, 2*iterations+1)
+	for _, line := range lines {
+		assert.Contains(t, []string{"", "INF ℹ️ log k=v"}, line)
+	}
+}
+
+func TestInline(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Inline("> ")
+
+	assert.Equal(t, "> ", buf.String())
+
+	l.Info().Msg("result")
+
+	assert.Equal(t, "> INF ℹ️ result\n", buf.String())
+}
+
+func TestInlineFlushes(t *testing.T) {
+	var buf bytes.Buffer
+
+	w := bufio.NewWriter(&buf)
+	l := New(TestOutput(w))
+	l.Inline("> ")
+
+	assert.Equal(t, "> ", buf.String())
+}
+
+func TestInlineDoesNotInterleave(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+
+	const iterations = 200
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for range iterations {
+			l.Inline("> ")
+		}
+	})
+	wg.Go(func() {
+		for range iterations {
+			l.Info().Str("k", "v").Msg("log")
+		}
+	})
+	wg.Wait()
+
+	out := buf.String()
+	assert.Equal(t, iterations, strings.Count(out, "> "))
+
+	// Stripping the prompts leaves only whole log lines.
+	lines := strings.Split(strings.ReplaceAll(out, "> ", ""), "\n")
+	assert.Len(t, lines, iterations+1)
+	for _, line := range lines[:iterations] {
+		assert.Equal(t, "INF ℹ️ log k=v", line)
+	}
+}
+
+func TestPackageLevelNewline(t *testing.T) {
+	var buf bytes.Buffer
+
+	origDefault := Default
+	defer func() { Default = origDefault }()
+	Default = New(TestOutput(&buf))
+
+	Newline()
+	Newlines(2)
+
+	assert.Equal(t, "\n\n\n", buf.String())
+}
@@ -0,0 +1,64 @@
+package clog
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// shellJoin joins name and args into a POSIX shell command line, quoting
+// each word with [shellQuote] so the result can be pasted into a shell.
+func shellJoin(name string, args []string) string {
+	var buf strings.Builder
+
+	buf.WriteString(shellQuote(name))
+	for _, arg := range args {
+		buf.WriteByte(' ')
+		buf.WriteString(shellQuote(arg))
+	}
+
+	return buf.String()
+}
+
+// execCmdLine returns the shell-quoted command line for c, using c.Path as
+// the program and c.Args[1:] as its arguments. Returns "" for a nil c.
+func execCmdLine(c *exec.Cmd) string {
+	if c == nil {
+		return ""
+	}
+
+	name := c.Path
+	if name == "" && len(c.Args) > 0 {
+		name = c.Args[0]
+	}
+
+	var args []string
+	if len(c.Args) > 1 {
+		args = c.Args[1:]
+	}
+
+	return shellJoin(name, args)
+}
+
+// shellQuote quotes s for a POSIX shell. Words made only of safe characters
+// are returned unchanged; anything else is wrapped in single quotes, with
+// each embedded single quote closed, backslash-escaped and reopened.
+func shellQuote(s string) string {
+	if s == "" {
+		return "''"
+	}
+
+	if !strings.ContainsFunc(s, isShellUnsafe) {
+		return s
+	}
+
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// isShellUnsafe reports whether r needs quoting in a POSIX shell word.
+func isShellUnsafe(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return false
+	}
+	return !strings.ContainsRune("@%+=:,./-_", r)
+}
@@ -0,0 +1,53 @@
+package clog
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"empty", "", "''"},
+		{"plain", "commit", "commit"},
+		{"flag", "--message=x", "--message=x"},
+		{"path", "/usr/bin/git", "/usr/bin/git"},
+		{"space", "my message", "'my message'"},
+		{"single_quote", "it's", `'it'\''s'`},
+		{"double_quote", `say "hi"`, `'say "hi"'`},
+		{"dollar", "$HOME", "'$HOME'"},
+		{"glob", "*.go", "'*.go'"},
+		{"semicolon", "a;b", "'a;b'"},
+		{"newline", "a\nb", "'a\nb'"},
+		{"unicode", "héllo", "'héllo'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, shellQuote(tt.input))
+		})
+	}
+}
+
+func TestShellJoin(t *testing.T) {
+	got := shellJoin("git", []string{"commit", "-m", "my message"})
+	assert.Equal(t, "git commit -m 'my message'", got)
+
+	assert.Equal(t, "ls", shellJoin("ls", nil))
+}
+
+func TestExecCmdLine(t *testing.T) {
+	c := &exec.Cmd{Path: "/bin/echo", Args: []string{"echo", "hello world"}}
+	assert.Equal(t, "/bin/echo 'hello world'", execCmdLine(c))
+
+	// Falls back to Args[0] when Path is unset.
+	c = &exec.Cmd{Args: []string{"echo", "hi"}}
+	assert.Equal(t, "echo hi", execCmdLine(c))
+
+	assert.Empty(t, execCmdLine(nil))
+}
@@ -7,6 +7,7 @@ import (
 	"runtime"
 	"slices"
 	"strings"
+	"time"
 )
 
 // SlogOptions configures a [SlogHandler].
@@ -81,6 +82,22 @@ func (h *SlogHandler) Handle(_ context.Context, r slog.Record) error {
 	return nil
 }
 
+// SlogRecord converts the entry into a [slog.Record], e.g. to forward it to
+// a [slog.Handler] from a custom clog [Handler]. The prefix, when set, is
+// added as a "prefix" attr before the fields. Strings, numbers, bools,
+// durations and times keep their [slog.Kind]; other values are stringified.
+// ANSI escapes (e.g. hyperlinks) are stripped with [StripANSI].
+func (e Entry) SlogRecord() slog.Record {
+	r := slog.NewRecord(e.Time, clogLevelToSlog(e.Level), StripANSI(e.Message), 0)
+	if e.Prefix != "" {
+		r.AddAttrs(slog.String("prefix", e.Prefix))
+	}
+	for _, f := range e.Fields {
+		r.AddAttrs(slog.Attr{Key: f.Key, Value: anyToSlogValue(f.Value)})
+	}
+	return r
+}
+
 // WithAttrs returns a new [SlogHandler] with the given attrs preset.
 func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
 	if len(attrs) == 0 {
@@ -180,6 +197,60 @@ func slogValueToAny(v slog.Value) any {
 	}
 }
 
+// anyToSlogValue converts a clog field value to a [slog.Value], preserving
+// the kind of strings, numbers, bools, durations and times.
+func anyToSlogValue(v any) slog.Value {
+	v, _ = unwrapNoted(v)
+	v = unwrapSQLNull(v)
+	v, _ = unwrapPrecise(v)
+
+	switch v := v.(type) {
+	case string:
+		return slog.StringValue(StripANSI(v))
+	case int:
+		return slog.IntValue(v)
+	case int64:
+		return slog.Int64Value(v)
+	case uint:
+		return slog.Uint64Value(uint64(v))
+	case uint64:
+		return slog.Uint64Value(v)
+	case float64:
+		return slog.Float64Value(v)
+	case percent:
+		return slog.Float64Value(float64(v))
+	case bool:
+		return slog.BoolValue(v)
+	case time.Duration:
+		return slog.DurationValue(v)
+	case elapsed:
+		return slog.DurationValue(time.Duration(v))
+	case backoff:
+		return slog.DurationValue(time.Duration(v))
+	case time.Time:
+		return slog.TimeValue(v)
+	case error:
+		return slog.StringValue(StripANSI(v.Error()))
+	case callerSite:
+		return slog.StringValue(v.String())
+	case textLines:
+		return slog.StringValue(StripANSI(strings.Join(v, "\n")))
+	case stats:
+		if v.count == 0 {
+			return slog.GroupValue(slog.Int("n", 0))
+		}
+		return slog.GroupValue(
+			slog.Int("n", v.count),
+			slog.Float64("min", v.minimum),
+			slog.Float64("avg", v.mean),
+			slog.Float64("max", v.maximum),
+		)
+	case nil:
+		return slog.AnyValue(nil)
+	}
+	return slog.StringValue(StripANSI(fmt.Sprint(v)))
+}
+
 // slogLevelToClog maps a [slog.Level] to a clog [Level].
 func slogLevelToClog(l slog.Level) Level {
 	switch {
@@ -197,3 +268,25 @@ func slogLevelToClog(l slog.Level) Level {
 		return FatalLevel
 	}
 }
+
+// clogLevelToSlog maps a clog [Level] to a [slog.Level], the inverse of
+// [slogLevelToClog]. [DryLevel] maps between info and warn.
+func clogLevelToSlog(l Level) slog.Level {
+	switch l {
+	case TraceLevel:
+		return slog.LevelDebug - 4 //nolint:mnd // one slog step below debug
+	case DebugLevel:
+		return slog.LevelDebug
+	case InfoLevel:
+		return slog.LevelInfo
+	case DryLevel:
+		return slog.LevelInfo + 2 //nolint:mnd // between info and warn
+	case WarnLevel:
+		return slog.LevelWarn
+	case ErrorLevel:
+		return slog.LevelError
+	case FatalLevel:
+		return slog.LevelError + 4 //nolint:mnd // one slog step above error
+	}
+	return slog.LevelInfo
+}
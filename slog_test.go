@@ -3,6 +3,8 @@ package clog
 import (
 	"bytes"
 	"context"
+	"errors"
+	"io"
 	"log/slog"
 	"strings"
 	"testing"
@@ -84,6 +86,79 @@ func TestSlogLevelMapping(t *testing.T) {
 	}
 }
 
+func TestClogLevelToSlogRoundTrip(t *testing.T) {
+	for _, level := range []Level{TraceLevel, DebugLevel, InfoLevel, WarnLevel, ErrorLevel, FatalLevel} {
+		assert.Equal(t, level, slogLevelToClog(clogLevelToSlog(level)), "%v", level)
+	}
+	assert.Equal(t, InfoLevel, slogLevelToClog(clogLevelToSlog(DryLevel)))
+}
+
+func TestEntrySlogRecord(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	e := Entry{
+		Level:   WarnLevel,
+		Message: "slow",
+		Prefix:  "⚠️",
+		Time:    ts,
+		Fields: []Field{
+			{Key: "count", Value: 3},
+			{Key: "ok", Value: true},
+			{Key: "took", Value: 2 * time.Second},
+			{Key: "at", Value: ts},
+			{Key: "ratio", Value: 0.5},
+			{Key: "ids", Value: []int{1, 2}},
+			{Key: "err", Value: errors.New("boom")},
+		},
+	}
+
+	r := e.SlogRecord()
+
+	assert.Equal(t, slog.LevelWarn, r.Level)
+	assert.Equal(t, "slow", r.Message)
+	assert.Equal(t, ts, r.Time)
+
+	attrs := map[string]slog.Value{}
+	var keys []string
+	r.Attrs(func(a slog.Attr) bool {
+		attrs[a.Key] = a.Value
+		keys = append(keys, a.Key)
+		return true
+	})
+
+	assert.Equal(t, []string{"prefix", "count", "ok", "took", "at", "ratio", "ids", "err"}, keys)
+	assert.Equal(t, slog.KindInt64, attrs["count"].Kind())
+	assert.Equal(t, int64(3), attrs["count"].Int64())
+	assert.Equal(t, slog.KindBool, attrs["ok"].Kind())
+	assert.Equal(t, slog.KindDuration, attrs["took"].Kind())
+	assert.Equal(t, 2*time.Second, attrs["took"].Duration())
+	assert.Equal(t, slog.KindTime, attrs["at"].Kind())
+	assert.Equal(t, slog.KindFloat64, attrs["ratio"].Kind())
+	assert.Equal(t, "[1 2]", attrs["ids"].String())
+	assert.Equal(t, "boom", attrs["err"].String())
+	assert.Equal(t, "⚠️", attrs["prefix"].String())
+}
+
+func TestEntrySlogRecordForward(t *testing.T) {
+	var buf bytes.Buffer
+
+	sink := slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+
+	l := NewWriter(io.Discard)
+	l.SetHandler(HandlerFunc(func(e Entry) {
+		_ = sink.Handle(context.Background(), e.SlogRecord())
+	}))
+	l.Info().Elapsed("took").Str("user", "alice").Msg("done")
+
+	assert.Regexp(t, `^level=INFO msg=done prefix=ℹ️ took=\S+ user=alice\n$`, buf.String())
+}
+
 func TestSlogEnabled(t *testing.T) {
 	var buf bytes.Buffer
 	l := New(TestOutput(&buf))
@@ -0,0 +1,193 @@
+package clog
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ConfigSnapshot is a point-in-time copy of a [Logger]'s effective
+// configuration, returned by [Logger.Snapshot]. It is intended for
+// debugging and support ("paste your clog config"); changing it has no
+// effect on the logger.
+type ConfigSnapshot struct {
+	ElapsedMinimum   time.Duration
+	ElapsedPrecision int
+	ElapsedRound     time.Duration
+	Enabled          bool
+	FieldSort        Sort
+	FieldTimeFormat  string
+	Handler          bool // true if a custom [Handler] is set
+	Level            Level
+	OmitEmpty        bool
+	OmitZero         bool
+	Parts            []Part
+	PercentPrecision int
+	QuoteClose       rune // 0 means same as QuoteOpen (or default)
+	QuoteMode        QuoteMode
+	QuoteOpen        rune // 0 means default ('"' via strconv.Quote)
+	ReportTimestamp  bool
+	SeparatorText    string
+	Styles           []string // names of [Styles] fields that are set (non-nil or non-empty)
+	TimeFormat       string
+	TimeLocation     string
+}
+
+// Snapshot returns the logger's current configuration as a plain value.
+func (l *Logger) Snapshot() ConfigSnapshot {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return ConfigSnapshot{
+		ElapsedMinimum:   l.elapsedMinimum,
+		ElapsedPrecision: l.elapsedPrecision,
+		ElapsedRound:     l.elapsedRound,
+		Enabled:          !l.disabled.Load(),
+		FieldSort:        l.fieldSort,
+		FieldTimeFormat:  l.fieldTimeFormat,
+		Handler:          l.handler != nil,
+		Level:            l.level,
+		OmitEmpty:        l.omitEmpty,
+		OmitZero:         l.omitZero,
+		Parts:            append([]Part(nil), l.parts...),
+		PercentPrecision: l.percentPrecision,
+		QuoteClose:       l.quoteClose,
+		QuoteMode:        l.quoteMode,
+		QuoteOpen:        l.quoteOpen,
+		ReportTimestamp:  l.reportTimestamp,
+		SeparatorText:    l.separatorText,
+		Styles:           setStyleNames(l.styles),
+		TimeFormat:       l.timeFormat,
+		TimeLocation:     l.timeLocation.String(),
+	}
+}
+
+// Snapshot returns the configuration of the [Default] logger.
+func Snapshot() ConfigSnapshot { return Default.Snapshot() }
+
+// String returns a readable multi-line dump of the snapshot, one
+// "name: value" pair per line.
+func (c ConfigSnapshot) String() string {
+	parts := make([]string, len(c.Parts))
+	for i, p := range c.Parts {
+		parts[i] = partName(p)
+	}
+
+	var b strings.Builder
+	line := func(name string, val any) {
+		fmt.Fprintf(&b, "%s: %v\n", name, val)
+	}
+
+	level := c.Level.String()
+	if name, ok := levelNames[c.Level]; ok {
+		level = name
+	}
+
+	line("level", level)
+	line("enabled", c.Enabled)
+	line("handler", c.Handler)
+	line("parts", strings.Join(parts, ", "))
+	line("report_timestamp", c.ReportTimestamp)
+	line("time_format", strconv.Quote(c.TimeFormat))
+	line("time_location", c.TimeLocation)
+	line("field_time_format", strconv.Quote(c.FieldTimeFormat))
+	line("quote_mode", quoteModeName(c.QuoteMode))
+	line("quote_chars", quoteChars(c.QuoteOpen, c.QuoteClose))
+	line("separator", strconv.Quote(c.SeparatorText))
+	line("field_sort", sortName(c.FieldSort))
+	line("omit_empty", c.OmitEmpty)
+	line("omit_zero", c.OmitZero)
+	line("elapsed_minimum", c.ElapsedMinimum)
+	line("elapsed_precision", c.ElapsedPrecision)
+	line("elapsed_round", c.ElapsedRound)
+	line("percent_precision", c.PercentPrecision)
+	line("styles", strings.Join(c.Styles, ", "))
+	return b.String()
+}
+
+// setStyleNames returns the names of the fields of s that are set, in
+// declaration order. Pointer fields count when non-nil; maps and slices
+// when non-empty.
+func setStyleNames(s *Styles) []string {
+	if s == nil {
+		return nil
+	}
+
+	var names []string
+
+	v := reflect.ValueOf(s).Elem()
+	for i := range v.NumField() {
+		f := v.Field(i)
+
+		var set bool
+		switch f.Kind() { //nolint:exhaustive // Styles only holds pointers, maps and slices
+		case reflect.Pointer:
+			set = !f.IsNil()
+		case reflect.Map, reflect.Slice:
+			set = f.Len() > 0
+		default:
+			set = !f.IsZero()
+		}
+		if set {
+			names = append(names, v.Type().Field(i).Name)
+		}
+	}
+	return names
+}
+
+func partName(p Part) string {
+	switch p {
+	case PartTimestamp:
+		return "timestamp"
+	case PartLevel:
+		return "level"
+	case PartPrefix:
+		return "prefix"
+	case PartMessage:
+		return "message"
+	case PartFields:
+		return "fields"
+	case PartGoroutine:
+		return "goroutine"
+	}
+	return "Part(" + strconv.Itoa(int(p)) + ")"
+}
+
+func quoteModeName(m QuoteMode) string {
+	switch m {
+	case QuoteAuto:
+		return "auto"
+	case QuoteAlways:
+		return "always"
+	case QuoteNever:
+		return "never"
+	}
+	return "QuoteMode(" + strconv.Itoa(int(m)) + ")"
+}
+
+func sortName(s Sort) string {
+	switch s {
+	case SortNone:
+		return "none"
+	case SortAscending:
+		return "ascending"
+	case SortDescending:
+		return "descending"
+	case SortNoneThenAscending:
+		return "none-then-ascending"
+	}
+	return "Sort(" + strconv.Itoa(int(s)) + ")"
+}
+
+// quoteChars describes the configured quote characters.
+func quoteChars(open, closing rune) string {
+	if open == 0 {
+		return "default"
+	}
+	if closing == 0 {
+		closing = open
+	}
+	return string(open) + string(closing)
+}
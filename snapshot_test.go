@@ -0,0 +1,70 @@
+package clog
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotDefaults(t *testing.T) {
+	s := NewWriter(io.Discard).Snapshot()
+
+	assert.Equal(t, InfoLevel, s.Level)
+	assert.True(t, s.Enabled)
+	assert.False(t, s.Handler)
+	assert.Equal(t, DefaultParts(), s.Parts)
+	assert.Equal(t, QuoteAuto, s.QuoteMode)
+	assert.Equal(t, time.Second, s.ElapsedMinimum)
+	assert.Contains(t, s.Styles, "Timestamp")
+	assert.NotContains(t, s.Styles, "TableBorder")
+}
+
+func TestSnapshotReflectsSetters(t *testing.T) {
+	l := NewWriter(io.Discard)
+	l.SetLevel(DebugLevel)
+	l.SetReportTimestamp(true)
+	l.SetTimeFormat(time.Kitchen)
+	l.SetParts(PartLevel, PartMessage)
+	l.SetQuoteMode(QuoteAlways)
+	l.SetQuoteChars('[', ']')
+	l.SetOmitEmpty(true)
+	l.SetFieldSort(SortAscending)
+	l.SetElapsedPrecision(2)
+	l.SetEnabled(false)
+
+	s := l.Snapshot()
+
+	assert.Equal(t, DebugLevel, s.Level)
+	assert.True(t, s.ReportTimestamp)
+	assert.Equal(t, time.Kitchen, s.TimeFormat)
+	assert.Equal(t, []Part{PartLevel, PartMessage}, s.Parts)
+	assert.Equal(t, QuoteAlways, s.QuoteMode)
+	assert.Equal(t, '[', s.QuoteOpen)
+	assert.Equal(t, ']', s.QuoteClose)
+	assert.True(t, s.OmitEmpty)
+	assert.Equal(t, SortAscending, s.FieldSort)
+	assert.Equal(t, 2, s.ElapsedPrecision)
+	assert.False(t, s.Enabled)
+
+	// The snapshot is a copy.
+	s.Parts[0] = PartFields
+	assert.Equal(t, []Part{PartLevel, PartMessage}, l.Snapshot().Parts)
+}
+
+func TestSnapshotString(t *testing.T) {
+	l := NewWriter(io.Discard)
+	l.SetParts(PartLevel, PartMessage)
+	l.SetFieldSort(SortDescending)
+	l.SetQuoteChars('«', '»')
+
+	got := l.Snapshot().String()
+
+	require.Contains(t, got, "level: info\n")
+	assert.Contains(t, got, "parts: level, message\n")
+	assert.Contains(t, got, "field_sort: descending\n")
+	assert.Contains(t, got, "quote_chars: «»\n")
+	assert.Contains(t, got, "quote_mode: auto\n")
+}
@@ -0,0 +1,72 @@
+package clog
+
+import (
+	"sync"
+	"time"
+)
+
+// Stopwatch records cumulative timings at named checkpoints, for profiling
+// the stages of a pipeline. Created by [Logger.Stopwatch]. It is safe for
+// concurrent use.
+//
+//	sw := clog.NewStopwatch()
+//	fetch()
+//	sw.Mark("fetch")
+//	parse()
+//	sw.Mark("parse")
+//	sw.Log(clog.InfoLevel) // INF ℹ️ fetch=2s parse=5s
+type Stopwatch struct {
+	logger *Logger
+
+	mu    sync.Mutex
+	start time.Time
+	last  time.Time
+	marks []Field
+}
+
+// Stopwatch returns a [Stopwatch] started now, according to the logger's
+// clock, that logs to l.
+func (l *Logger) Stopwatch() *Stopwatch {
+	l.mu.Lock()
+	now := l.now()
+	l.mu.Unlock()
+
+	return &Stopwatch{logger: l, start: now, last: now}
+}
+
+// NewStopwatch returns a [Stopwatch] that logs to the [Default] logger.
+func NewStopwatch() *Stopwatch { return Default.Stopwatch() }
+
+// Mark records a checkpoint named label, holding the time elapsed since
+// the stopwatch started. It returns the time elapsed since the previous
+// checkpoint (or the start, for the first).
+func (sw *Stopwatch) Mark(label string) time.Duration {
+	sw.logger.mu.Lock()
+	now := sw.logger.now()
+	sw.logger.mu.Unlock()
+
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	split := now.Sub(sw.last)
+	sw.last = now
+	sw.marks = append(sw.marks, Field{Key: label, Value: elapsed(now.Sub(sw.start))})
+	return split
+}
+
+// Log logs the checkpoints at level as [Event.Elapsed]-style fields, one per
+// [Stopwatch.Mark] in order, so [Logger.SetElapsedPrecision],
+// [Logger.SetElapsedRound] and [Logger.SetElapsedMinimum] apply.
+func (sw *Stopwatch) Log(level Level) {
+	sw.mu.Lock()
+	marks := sw.marks
+	sw.mu.Unlock()
+
+	e := sw.logger.newEvent(level)
+	if e == nil {
+		return
+	}
+
+	e.fields = append(e.fields, marks...)
+	e.Send()
+}
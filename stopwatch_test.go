@@ -0,0 +1,72 @@
+package clog
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock returns a clock starting at testLoggerTime and a function that
+// advances it.
+func fakeClock() (now func() time.Time, advance func(time.Duration)) {
+	t := testLoggerTime
+	return func() time.Time { return t }, func(d time.Duration) { t = t.Add(d) }
+}
+
+func TestStopwatch(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	now, advance := fakeClock()
+	l.nowFunc = now
+
+	sw := l.Stopwatch()
+	advance(2 * time.Second)
+	assert.Equal(t, 2*time.Second, sw.Mark("fetch"))
+	advance(3 * time.Second)
+	assert.Equal(t, 3*time.Second, sw.Mark("parse"))
+	sw.Log(InfoLevel)
+
+	assert.Equal(t, "INF ℹ️ fetch=2s parse=5s\n", buf.String())
+}
+
+func TestStopwatchElapsedSettings(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	now, advance := fakeClock()
+	l.nowFunc = now
+	l.SetElapsedPrecision(1)
+	l.SetElapsedRound(0)
+
+	sw := l.Stopwatch()
+	advance(500 * time.Millisecond)
+	sw.Mark("warm")
+	advance(1250 * time.Millisecond)
+	sw.Mark("done")
+	sw.Log(InfoLevel)
+
+	// "warm" is below the default one-second minimum.
+	assert.Equal(t, "INF ℹ️ done=1.8s\n", buf.String())
+}
+
+func TestStopwatchLevel(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	now, advance := fakeClock()
+	l.nowFunc = now
+
+	sw := l.Stopwatch()
+	advance(time.Second)
+	sw.Mark("a")
+	sw.Log(DebugLevel)
+
+	assert.Empty(t, buf.String())
+
+	sw.Log(WarnLevel)
+
+	assert.Equal(t, "WRN ⚠️ a=1s\n", buf.String())
+}
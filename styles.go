@@ -1,6 +1,9 @@
 package clog
 
 import (
+	"reflect"
+	"regexp"
+
 	"github.com/charmbracelet/lipgloss"
 	"github.com/lucasb-eyer/go-colorful"
 )
@@ -42,6 +45,20 @@ type ThresholdMap = map[string]Thresholds
 // LevelStyleMap maps log levels to lipgloss styles.
 type LevelStyleMap = map[Level]Style
 
+// PercentThreshold is a discrete colour band for Percent fields. See
+// [Styles.PercentThresholds].
+type PercentThreshold struct {
+	AtLeast float64 // Minimum percentage (inclusive) for this band.
+	Style   Style   // Style applied to the percent text.
+}
+
+// ValuePattern styles string values matching Pattern. See
+// [Styles.ValuePatterns].
+type ValuePattern struct {
+	Pattern *regexp.Regexp // Regular expression matched against the raw string value.
+	Style   Style          // Style applied on match.
+}
+
 // ValueStyleMap maps typed values to lipgloss styles. Keys use Go equality
 // (e.g. bool true != string "true").
 type ValueStyleMap = map[any]Style
@@ -88,6 +105,10 @@ const (
 	SortAscending
 	// SortDescending sorts fields by key Z→A.
 	SortDescending
+	// SortNoneThenAscending keeps logger fields (default and context fields)
+	// first in insertion order, followed by the event's own fields sorted by
+	// key A→Z.
+	SortNoneThenAscending
 )
 
 // JSONStyles configures per-token lipgloss styles for JSON syntax highlighting.
@@ -103,6 +124,10 @@ type JSONStyles struct {
 	// OmitCommas omits the comma between items. JSONSpacingAfterComma still
 	// applies and can be used to keep a space separator: {"a":1 "b":2}.
 	OmitCommas bool
+	// PreserveWhitespace keeps the input's whitespace, including newlines
+	// and indentation, instead of flattening it to a single line. Spacing
+	// is ignored when set. Has no effect in JSONModeFlat.
+	PreserveWhitespace bool
 	// Spacing controls where spaces are inserted. Zero (default) means no spaces.
 	// Use JSONSpacingAll for {"key": "value", "n": 1} style output.
 	Spacing JSONSpacing
@@ -187,10 +212,16 @@ func (s *JSONStyles) WithSpacing(spacing JSONSpacing) *JSONStyles {
 // Styles holds lipgloss styles for the logger's pretty output.
 // Pointer fields can be set to nil to disable that style entirely.
 type Styles struct {
+	// Style for the new side of Diff values (e.g. "2" in "1 → 2") [nil = plain text]
+	DiffNew Style
+	// Style for the old side of Diff values (e.g. "1" in "1 → 2") [nil = plain text]
+	DiffOld Style
 	// Duration unit -> thresholds (evaluated high->low).
 	DurationThresholds ThresholdMap
 	// Duration unit -> style override (e.g. "s" -> yellow).
 	DurationUnits StyleMap
+	// Style for command-line values [nil = falls back to FieldString]
+	FieldCmd Style
 	// Style for the numeric segments of duration values (e.g. "1" in "1m30s") [nil = plain text]
 	FieldDurationNumber Style
 	// Style for the unit segments of duration values (e.g. "m" in "1m30s") [nil = plain text]
@@ -204,6 +235,8 @@ type Styles struct {
 	// Per-token styles for JSON syntax highlighting.
 	// nil disables JSON highlighting; use [DefaultJSONStyles] to enable.
 	FieldJSON *JSONStyles
+	// Style for parenthetical field notes (e.g. "(default)" in "port=8080 (default)") [nil = plain text]
+	FieldNote Style
 	// Style for int/float field values [nil = plain text]
 	FieldNumber Style
 	// Base style for Percent fields (foreground overridden by gradient). nil = gradient color only.
@@ -212,6 +245,8 @@ type Styles struct {
 	FieldQuantityNumber Style
 	// Style for the unit part of quantity values (e.g. "km" in "5km") [nil = plain text]
 	FieldQuantityUnit Style
+	// Style for the attempt of an exhausted Retry (e.g. "5/5") [nil = falls back to FieldError]
+	FieldRetryExhausted Style
 	// Style for string field values [nil = plain text]
 	FieldString Style
 	// Style for time.Time field values [nil = plain text]
@@ -220,20 +255,47 @@ type Styles struct {
 	KeyDefault Style
 	// Field key name -> value style (e.g. "path" -> blue).
 	Keys StyleMap
+	// Style for the tag set with SetLeadingTagField [nil = plain text]
+	LeadingTag Style
+	// Level badge style used by SetLevelBadge [nil = derived from Levels]
+	LevelBadges LevelStyleMap
 	// Level label style (e.g. "INF", "ERR").
 	Levels LevelStyleMap
+	// Whole-line style per level, applied outside all part styles (e.g. red background for errors).
+	LineByLevel LevelStyleMap
 	// Message text style per level.
 	Messages LevelStyleMap
+	// Field key -> thresholds for number values; the first threshold met
+	// replaces FieldNumber (e.g. "errors" red at 100 and above).
+	NumberThresholds ThresholdMap
 	// Gradient stops for Percent fields (default: red → yellow → green).
 	PercentGradient []ColorStop
+	// Discrete bands for Percent fields; the band with the highest AtLeast not
+	// above the value replaces the gradient [nil = gradient only].
+	PercentThresholds []PercentThreshold
+	// Style for Confirm prompt questions [nil = plain text]
+	Prompt Style
 	// Quantity unit -> thresholds (evaluated high->low).
 	QuantityThresholds ThresholdMap
 	// Unit string -> style override (e.g. "km" -> green).
 	QuantityUnits StyleMap
+	// Style for level rules set with SetLevelRule [nil = plain text]
+	Rule Style
+	// Style for Section header lines and rules [nil = plain text]
+	SectionHeader Style
 	// Style for key/value separator.
 	Separator Style
+	// Style for Table border lines [nil = no border]
+	TableBorder Style
+	// Style for Table body cells [nil = plain text]
+	TableCell Style
+	// Style for Table header cells [nil = plain text]
+	TableHeader Style
 	// Style for the timestamp prefix.
 	Timestamp Style
+	// String value patterns -> style, checked in order when [Styles.Values]
+	// has no exact match. Applies to scalar values and slice elements.
+	ValuePatterns []ValuePattern
 	// Values maps typed values to styles. Keys use Go equality.
 	// Allows differentiating between e.g. `true` (bool) and "true" (string).
 	Values ValueStyleMap
@@ -242,6 +304,12 @@ type Styles struct {
 // DefaultStyles returns the default colour styles.
 func DefaultStyles() *Styles {
 	return &Styles{
+		DiffNew: new(
+			lipgloss.NewStyle().Foreground(lipgloss.Color("2")), // green
+		),
+		DiffOld: new(
+			lipgloss.NewStyle().Foreground(lipgloss.Color("1")), // red
+		),
 		FieldDurationNumber: new(
 			lipgloss.NewStyle().Foreground(lipgloss.Color("5")), // magenta
 		),
@@ -252,6 +320,7 @@ func DefaultStyles() *Styles {
 			lipgloss.NewStyle().Foreground(lipgloss.Color("1")), // red
 		),
 		FieldJSON: DefaultJSONStyles(),
+		FieldNote: new(lipgloss.NewStyle().Faint(true)),
 		FieldNumber: new(
 			lipgloss.NewStyle().Foreground(lipgloss.Color("5")), // magenta
 		),
@@ -297,16 +366,72 @@ func DefaultStyles() *Styles {
 		},
 		DurationThresholds: make(ThresholdMap),
 		DurationUnits:      make(StyleMap),
+		LeadingTag:         new(lipgloss.NewStyle().Bold(true)),
+		LineByLevel:        make(LevelStyleMap),
 		Messages:           DefaultMessageStyles(),
+		NumberThresholds:   make(ThresholdMap),
 		PercentGradient:    DefaultPercentGradient(),
+		Prompt:             new(lipgloss.NewStyle().Bold(true)),
 		QuantityThresholds: make(ThresholdMap),
 		QuantityUnits:      make(StyleMap),
+		Rule:               new(lipgloss.NewStyle().Faint(true)),
+		SectionHeader:      new(lipgloss.NewStyle().Bold(true)),
 		Separator:          new(lipgloss.NewStyle().Faint(true)),
+		TableHeader:        new(lipgloss.NewStyle().Bold(true)),
 		Timestamp:          new(lipgloss.NewStyle().Faint(true)),
 		Values:             DefaultValueStyles(),
 	}
 }
 
+// Overlay returns a new [Styles] with the non-nil fields of other laid over
+// s, so a theme can be written as a small diff of the defaults:
+//
+//	styles := clog.DefaultStyles().Overlay(&clog.Styles{
+//	    Messages: clog.LevelStyleMap{clog.ErrorLevel: new(lipgloss.NewStyle().Bold(true))},
+//	})
+//
+// Maps (such as Keys, Values, Levels, QuantityUnits and the threshold maps)
+// are merged entry by entry, with other's entries winning. Other fields,
+// including slices such as PercentGradient, are replaced wholesale. As nil
+// fields of other are skipped, an overlay cannot disable a style; set the
+// field to nil on the result instead. Neither s nor other is modified, and
+// either may be nil.
+func (s *Styles) Overlay(other *Styles) *Styles {
+	out := new(Styles)
+	if s != nil {
+		*out = *s
+	}
+	if other == nil {
+		other = new(Styles)
+	}
+
+	dst := reflect.ValueOf(out).Elem()
+	src := reflect.ValueOf(other).Elem()
+	for i := range dst.NumField() {
+		d, o := dst.Field(i), src.Field(i)
+		if d.Kind() != reflect.Map {
+			if !o.IsZero() {
+				d.Set(o)
+			}
+			continue
+		}
+
+		// Clone even when other has no entries, so out never shares a
+		// map with s.
+		if d.IsNil() && o.IsNil() {
+			continue
+		}
+		merged := reflect.MakeMapWithSize(d.Type(), d.Len()+o.Len())
+		for _, m := range []reflect.Value{d, o} {
+			for iter := m.MapRange(); iter.Next(); {
+				merged.SetMapIndex(iter.Key(), iter.Value())
+			}
+		}
+		d.Set(merged)
+	}
+	return out
+}
+
 // DefaultMessageStyles returns the default per-level message styles (unstyled).
 func DefaultMessageStyles() LevelStyleMap {
 	return LevelStyleMap{
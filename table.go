@@ -0,0 +1,160 @@
+package clog
+
+import (
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// tableColumnGap is the spacing between columns of a borderless table.
+const tableColumnGap = "  "
+
+// Table writes headers and rows to the logger's output as an aligned table.
+// Column widths are sized to the widest cell, accounting for ANSI escapes
+// and wide runes. Headers are styled with [Styles.TableHeader] and cells
+// with [Styles.TableCell]; setting [Styles.TableBorder] draws a border.
+//
+// Table is a UI primitive like [Spinner], not a log entry: it bypasses the
+// level, parts and [Handler] configuration and writes directly to the
+// [Output]. Styles are omitted when colours are disabled.
+//
+//	clog.Table([]string{"NAME", "STATUS"}, [][]string{
+//	    {"api", "healthy"},
+//	    {"worker", "degraded"},
+//	})
+func (l *Logger) Table(headers []string, rows [][]string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	s := renderTable(headers, rows, l.styles, l.colorsDisabled())
+	if s == "" {
+		return
+	}
+
+	_, _ = io.WriteString(l.writer(), s)
+}
+
+// Table writes an aligned table to the [Default] logger's output.
+func Table(headers []string, rows [][]string) { Default.Table(headers, rows) }
+
+// renderTable lays out headers and rows as newline-terminated table lines.
+// Returns "" when there are no headers or rows.
+func renderTable(headers []string, rows [][]string, styles *Styles, noColor bool) string {
+	cols := len(headers)
+	for _, row := range rows {
+		cols = max(cols, len(row))
+	}
+
+	if cols == 0 {
+		return ""
+	}
+
+	widths := make([]int, cols)
+	for i, h := range headers {
+		widths[i] = lipgloss.Width(h)
+	}
+
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], lipgloss.Width(cell))
+		}
+	}
+
+	var headerStyle, cellStyle, borderStyle Style
+	if !noColor {
+		headerStyle = styles.TableHeader
+		cellStyle = styles.TableCell
+		borderStyle = styles.TableBorder
+	}
+
+	bordered := styles.TableBorder != nil
+
+	var buf strings.Builder
+
+	if bordered {
+		writeTableRule(&buf, widths, borderStyle, "╭", "┬", "╮")
+	}
+
+	if len(headers) > 0 {
+		writeTableRow(&buf, headers, widths, headerStyle, borderStyle, bordered)
+		if bordered {
+			writeTableRule(&buf, widths, borderStyle, "├", "┼", "┤")
+		}
+	}
+
+	for _, row := range rows {
+		writeTableRow(&buf, row, widths, cellStyle, borderStyle, bordered)
+	}
+
+	if bordered {
+		writeTableRule(&buf, widths, borderStyle, "╰", "┴", "╯")
+	}
+
+	return buf.String()
+}
+
+// writeTableRow writes a single table row, padding each cell to its column
+// width. Missing trailing cells are rendered empty. Borderless rows do not
+// pad the final column so lines carry no trailing whitespace.
+func writeTableRow(
+	buf *strings.Builder,
+	cells []string,
+	widths []int,
+	cellStyle, borderStyle Style,
+	bordered bool,
+) {
+	var line strings.Builder
+
+	for i, w := range widths {
+		var cell string
+		if i < len(cells) {
+			cell = cells[i]
+		}
+
+		pad := strings.Repeat(" ", w-lipgloss.Width(cell))
+
+		if bordered {
+			emitStyled(&line, "│", borderStyle)
+			line.WriteByte(' ')
+			emitStyled(&line, cell, cellStyle)
+			line.WriteString(pad)
+			line.WriteByte(' ')
+			continue
+		}
+
+		if i > 0 {
+			line.WriteString(tableColumnGap)
+		}
+
+		emitStyled(&line, cell, cellStyle)
+		if i < len(widths)-1 {
+			line.WriteString(pad)
+		}
+	}
+
+	if bordered {
+		emitStyled(&line, "│", borderStyle)
+	}
+
+	buf.WriteString(strings.TrimRight(line.String(), " "))
+	buf.WriteByte('\n')
+}
+
+// writeTableRule writes a horizontal border line using the given corner and
+// junction characters.
+func writeTableRule(buf *strings.Builder, widths []int, style Style, left, mid, right string) {
+	var line strings.Builder
+
+	line.WriteString(left)
+	for i, w := range widths {
+		if i > 0 {
+			line.WriteString(mid)
+		}
+		line.WriteString(strings.Repeat("─", w+2)) //nolint:mnd // one space of padding per side
+	}
+	line.WriteString(right)
+
+	emitStyled(buf, line.String(), style)
+	buf.WriteByte('\n')
+}
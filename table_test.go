@@ -0,0 +1,97 @@
+package clog
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Table(
+		[]string{"NAME", "STATUS"},
+		[][]string{
+			{"api", "ok"},
+			{"worker-long", "degraded"},
+		},
+	)
+
+	want := "NAME         STATUS\n" +
+		"api          ok\n" +
+		"worker-long  degraded\n"
+	assert.Equal(t, want, buf.String())
+}
+
+func TestTableBypassesLevelAndHandler(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetLevel(FatalLevel)
+	l.SetHandler(HandlerFunc(func(Entry) { t.Fatal("handler should not be called") }))
+	l.Table([]string{"A"}, [][]string{{"1"}})
+
+	assert.Equal(t, "A\n1\n", buf.String())
+}
+
+func TestTableBordered(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	styles := DefaultStyles()
+	styles.TableBorder = new(lipgloss.NewStyle())
+	l.SetStyles(styles)
+	l.Table([]string{"NAME", "STATUS"}, [][]string{{"api", "ok"}})
+
+	want := "╭──────┬────────╮\n" +
+		"│ NAME │ STATUS │\n" +
+		"├──────┼────────┤\n" +
+		"│ api  │ ok     │\n" +
+		"╰──────┴────────╯\n"
+	assert.Equal(t, want, buf.String())
+}
+
+func TestTableRaggedRows(t *testing.T) {
+	got := renderTable(
+		[]string{"A"},
+		[][]string{{"1", "two"}, {}},
+		DefaultStyles(),
+		true,
+	)
+
+	assert.Equal(t, "A\n1  two\n\n", got)
+}
+
+func TestTableWideRunes(t *testing.T) {
+	got := renderTable(
+		[]string{"K", "V"},
+		[][]string{{"日本", "x"}, {"a", "y"}},
+		DefaultStyles(),
+		true,
+	)
+
+	assert.Equal(t, "K     V\n日本  x\na     y\n", got)
+}
+
+func TestTableStyled(t *testing.T) {
+	styles := DefaultStyles()
+	styles.TableCell = new(lipgloss.NewStyle().Foreground(lipgloss.Color("2")))
+
+	got := renderTable([]string{"K", "V"}, [][]string{{"long", "x"}}, styles, false)
+
+	want := styles.TableHeader.Render("K") + "     " + styles.TableHeader.Render("V") + "\n" +
+		styles.TableCell.Render("long") + "  " + styles.TableCell.Render("x") + "\n"
+	assert.Equal(t, want, got)
+}
+
+func TestTableEmpty(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.Table(nil, nil)
+
+	assert.Empty(t, buf.String())
+}
@@ -6,3 +6,15 @@ func IsTerminal() bool {
 	defer Default.mu.Unlock()
 	return Default.output.IsTTY()
 }
+
+// TerminalWidth returns the width of the [Default] logger's output terminal,
+// or 0 when the output is not a terminal.
+func TerminalWidth() int {
+	return Default.Output().Width()
+}
+
+// TerminalHeight returns the height of the [Default] logger's output terminal,
+// or 0 when the output is not a terminal.
+func TerminalHeight() int {
+	return Default.Output().Height()
+}
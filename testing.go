@@ -0,0 +1,62 @@
+package clog
+
+import (
+	"bytes"
+	"sync"
+	"time"
+)
+
+// TB is the subset of [testing.TB] used by [TestLogger]. It is satisfied by
+// *testing.T, *testing.B and *testing.F, without clog importing the testing
+// package into non-test builds.
+type TB interface {
+	Helper()
+	Log(args ...any)
+}
+
+// testLoggerTime is the fixed clock used by [TestLogger].
+var testLoggerTime = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// TestLogger returns a Logger that writes each output line to tb.Log, so
+// log output appears under the running (sub)test and is shown when it
+// fails. Colors are disabled and timestamps come from a fixed clock, making
+// output deterministic.
+//
+//	func TestDeploy(t *testing.T) {
+//		log := clog.TestLogger(t)
+//		deploy(log)
+//	}
+func TestLogger(tb TB) *Logger {
+	tb.Helper()
+
+	l := New(TestOutput(&tbWriter{tb: tb}))
+	l.nowFunc = func() time.Time { return testLoggerTime }
+	return l
+}
+
+// tbWriter is an [io.Writer] that passes complete lines to tb.Log. Since
+// tb.Log appends its own newline, the trailing newline is stripped; a
+// partial line is buffered until its newline arrives.
+type tbWriter struct {
+	mu  sync.Mutex
+	tb  TB
+	buf []byte
+}
+
+func (w *tbWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.tb.Helper()
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.tb.Log(string(w.buf[:i]))
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
@@ -0,0 +1,57 @@
+package clog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeTB records calls to Log.
+type fakeTB struct {
+	logs []string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Log(args ...any) {
+	for _, a := range args {
+		f.logs = append(f.logs, a.(string))
+	}
+}
+
+func TestTestLogger(t *testing.T) {
+	tb := &fakeTB{}
+
+	l := TestLogger(tb)
+	l.Info().Str("k", "v").Msg("hello")
+	l.Warn().Msg("careful")
+
+	assert.Equal(t, []string{"INF ℹ️ hello k=v", "WRN ⚠️ careful"}, tb.logs)
+}
+
+func TestTestLoggerFixedClock(t *testing.T) {
+	tb := &fakeTB{}
+
+	l := TestLogger(tb)
+	l.SetReportTimestamp(true)
+	l.SetTimeFormat("2006-01-02")
+	l.Info().Msg("hello")
+
+	assert.Equal(t, []string{"2024-01-01 INF ℹ️ hello"}, tb.logs)
+}
+
+func TestTBWriterBuffersPartialLines(t *testing.T) {
+	tb := &fakeTB{}
+	w := &tbWriter{tb: tb}
+
+	_, _ = w.Write([]byte("one\ntw"))
+	assert.Equal(t, []string{"one"}, tb.logs)
+
+	_, _ = w.Write([]byte("o\n"))
+	assert.Equal(t, []string{"one", "two"}, tb.logs)
+}
+
+func TestTestLoggerRealTB(t *testing.T) {
+	l := TestLogger(t)
+	l.Info().Msg("visible with -v")
+}
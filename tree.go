@@ -0,0 +1,46 @@
+package clog
+
+// Tree is a logger whose lines are indented one level beneath a parent
+// line. Created by [Logger.Tree]. All [Logger] methods are available, so
+// children are logged with the usual Info/Warn/Error chains and further
+// nesting uses Tree.Tree. Call [Tree.End] once the subtree is complete.
+//
+//	build := clog.NewTree("Building")
+//	build.Info().Str("pkg", "api").Msg("Compiled")
+//	test := build.Tree("Testing")
+//	test.Error().Str("pkg", "db").Msg("Failed")
+//	test.End()
+//	build.End()
+//
+// Indentation is applied by the built-in pretty formatter only; a custom
+// [Handler] receives child entries unchanged.
+type Tree struct {
+	*Logger
+}
+
+// Tree logs msg at info level and returns a [Tree] whose lines are indented
+// one level beneath it. The per-depth indentation is set with
+// [Logger.SetTreeIndent]. The tree shares the logger's configuration and
+// lock as of this call.
+func (l *Logger) Tree(msg string) *Tree {
+	l.Info().Msg(msg)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	child := l.clone()
+	child.mu = l.mu
+	child.indent = l.indent + l.treeIndent
+	child.atomicLevel.Store(int32(child.gateLevel())) //nolint:gosec // Level values are small constants (0-6)
+
+	return &Tree{Logger: child}
+}
+
+// NewTree logs msg at info level on the [Default] logger and returns an
+// indented [Tree] beneath it.
+func NewTree(msg string) *Tree { return Default.Tree(msg) }
+
+// End closes the tree. Events created on it afterwards are discarded.
+func (t *Tree) End() {
+	t.SetEnabled(false)
+}
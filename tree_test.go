@@ -0,0 +1,106 @@
+package clog
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTree(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage, PartFields)
+
+	tree := l.Tree("Building")
+	tree.Info().Str("pkg", "api").Msg("Compiled")
+	tree.Error().Str("pkg", "db").Msg("Failed")
+	tree.End()
+
+	l.Info().Msg("Done")
+
+	want := "Building\n" +
+		"  Compiled pkg=api\n" +
+		"  Failed pkg=db\n" +
+		"Done\n"
+	assert.Equal(t, want, buf.String())
+}
+
+func TestTreeNested(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage)
+	l.SetTreeIndent("│ ")
+
+	outer := l.Tree("outer")
+	outer.Info().Msg("a")
+	inner := outer.Tree("inner")
+	inner.Info().Msg("b")
+	inner.End()
+	outer.Info().Msg("c")
+	outer.End()
+
+	want := "outer\n" +
+		"│ a\n" +
+		"│ inner\n" +
+		"│ │ b\n" +
+		"│ c\n"
+	assert.Equal(t, want, buf.String())
+}
+
+func TestTreeEnd(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage)
+
+	tree := l.Tree("parent")
+	tree.End()
+	tree.Info().Msg("discarded")
+
+	assert.Equal(t, "parent\n", buf.String())
+	assert.True(t, l.Enabled(), "ending a tree must not disable its parent")
+}
+
+func TestTreeInheritsLevel(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetLevel(WarnLevel)
+
+	tree := l.Tree("parent")
+	assert.Nil(t, tree.Info())
+	assert.NotNil(t, tree.Warn())
+	assert.Empty(t, buf.String(), "parent line is logged at info level")
+}
+
+func TestTreeLevelForField(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(TestOutput(&buf))
+	l.SetParts(PartMessage)
+	l.SetLevelForField("component", "db", DebugLevel)
+
+	tree := l.Tree("parent")
+	tree.Debug().Str("component", "db").Msg("query")
+	tree.Debug().Msg("plain")
+
+	assert.Equal(t, "parent\n  query\n", buf.String())
+}
+
+func TestNewTree(t *testing.T) {
+	origDefault := Default
+	defer func() { Default = origDefault }()
+
+	var buf bytes.Buffer
+
+	Default = New(TestOutput(&buf))
+	Default.SetParts(PartMessage)
+
+	tree := NewTree("parent")
+	tree.Info().Msg("child")
+
+	assert.Equal(t, "parent\n  child\n", buf.String())
+}